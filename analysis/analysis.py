@@ -35,6 +35,9 @@ not_intersting_search.remove('.so')
 not_intersting_search.remove('.dylib')
 not_intersting_magic_search.remove('x-mach-binary')
 
+# Binaries whose strings Trufflehog extracts itself, with their location, are scanned as is instead of through 'strings'
+trufflehog_handled_mime_types = {"application/x-mach-binary", "application/x-sharedlib"}
+
 # Set up logging to file
 logging.basicConfig(level=logging.DEBUG, format='%(asctime)s - %(levelname)s - %(message)s', filename='app_analysis.log', filemode='a')
 logger = logging.getLogger(__name__)
@@ -212,7 +215,7 @@ def analyze_app(output_dir, gitleaks_config, app_name, platform, skip_secrets =
                 if ".lproj" in file_path:
                     lproj_file_analyzed.add(file)
                 file_names_analyzed.add(file)
-                if "text" not in mime_type:
+                if "text" not in mime_type and mime_type not in trufflehog_handled_mime_types:
                     create_strings_file(file_path)
                     try:
                         os.remove(file_path)
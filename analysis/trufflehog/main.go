@@ -23,10 +23,13 @@ import (
 	"go.uber.org/automaxprocs/maxprocs"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/analyzer"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/cache/file"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/cleantemp"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/config"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/configsecret"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/engine"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/defaults"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/feature"
@@ -36,6 +39,7 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/tui"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/updater"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/verificationcache"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/version"
 )
 
@@ -56,6 +60,13 @@ var (
 	onlyVerified        = cli.Flag("only-verified", "Only output verified results.").Hidden().Bool()
 	results             = cli.Flag("results", "Specifies which type(s) of results to output: verified, unknown, unverified, filtered_unverified. Defaults to all types.").String()
 
+	verificationPolicy    = cli.Flag("verification-policy", "Which detectors may verify results: safe-only (detectors that declare read-only, free verification), all or none.").Default(string(detectors.VerificationPolicyAll)).Enum(string(detectors.VerificationPolicySafeOnly), string(detectors.VerificationPolicyAll), string(detectors.VerificationPolicyNone))
+	verificationCachePath = cli.Flag("verification-cache", "Path to a file that caches verification outcomes across runs. Only hashes of the secrets are stored.").String()
+	verificationCacheTTL  = cli.Flag("verification-cache-ttl", "How long a cached verification outcome is used before the secret is verified again.").Default(verificationcache.DefaultTTL.String()).Duration()
+	verificationAuditLog  = cli.Flag("verification-audit-log", "Path to a JSONL file that records every request sent to verify a secret, keyed by a hash of the secret. Entries are appended.").String()
+	verificationRateLimit = cli.Flag("verification-rate-limit", "Maximum verification requests per second to each host, as RATE[:BURST]. Hosts that respond with 429 or 503 are always left alone for as long as they ask.").String()
+	detectorRateLimits    = cli.Flag("verification-rate-limit-detector", "Override the verification rate limit of a detector, as DETECTOR=RATE[:BURST].").StringMap()
+
 	allowVerificationOverlap   = cli.Flag("allow-verification-overlap", "Allow verification of similar credentials across detectors").Bool()
 	filterUnverified           = cli.Flag("filter-unverified", "Only output first unverified result per chunk per detector if there are more than one results.").Bool()
 	filterEntropy              = cli.Flag("filter-entropy", "Filter unverified results with Shannon entropy. Start with 3.0.").Float64()
@@ -73,6 +84,7 @@ var (
 	archiveTimeout       = cli.Flag("archive-timeout", "Maximum time to spend extracting an archive.").Duration()
 	includeDetectors     = cli.Flag("include-detectors", "Comma separated list of detector types to include. Protobuf name or IDs may be used, as well as ranges.").Default("all").String()
 	excludeDetectors     = cli.Flag("exclude-detectors", "Comma separated list of detector types to exclude. Protobuf name or IDs may be used, as well as ranges. IDs defined here take precedence over the include list.").String()
+	configSecrets        = cli.Flag("config-secrets", "Report high entropy values of secret-like configuration keys, such as api_key or clientSecret, found by a generic detector. Its results are reported apart from those of vendor detectors.").Bool()
 	jobReportFile        = cli.Flag("output-report", "Write a scan report to the provided path.").Hidden().OpenFile(os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
 
 	// Add feature flags
@@ -80,6 +92,7 @@ var (
 	forceSkipArchives  = cli.Flag("force-skip-archives", "Force skipping archives.").Bool()
 	skipAdditionalRefs = cli.Flag("skip-additional-refs", "Skip additional references.").Bool()
 	userAgentSuffix    = cli.Flag("user-agent-suffix", "Suffix to add to User-Agent.").String()
+	dexStringPool      = cli.Flag("dex-string-pool", "Scan every string of the dex files of Android apps, attributed to the class, method or field that uses it.").Bool()
 
 	gitScan             = cli.Command("git", "Find credentials in git repositories.")
 	gitScanURI          = gitScan.Arg("uri", "Git repository URL. https://, file://, or ssh:// schema expected.").Required().String()
@@ -137,8 +150,10 @@ var (
 	filesystemDirectories = filesystemScan.Flag("directory", "Path to directory to scan. You can repeat this flag.").Strings()
 	// TODO: Add more filesystem scan options. Currently only supports scanning a list of directories.
 	// filesystemScanRecursive = filesystemScan.Flag("recursive", "Scan recursively.").Short('r').Bool()
-	filesystemScanIncludePaths = filesystemScan.Flag("include-paths", "Path to file with newline separated regexes for files to include in scan.").Short('i').String()
-	filesystemScanExcludePaths = filesystemScan.Flag("exclude-paths", "Path to file with newline separated regexes for files to exclude in scan.").Short('x').String()
+	filesystemScanIncludePaths   = filesystemScan.Flag("include-paths", "Path to file with newline separated regexes for files to include in scan.").Short('i').String()
+	filesystemScanExcludePaths   = filesystemScan.Flag("exclude-paths", "Path to file with newline separated regexes for files to exclude in scan.").Short('x').String()
+	filesystemScanIncludeClasses = filesystemScan.Flag("include-classes", "Comma separated list of file classes to include in scan: web, system, binary, code, config, media, game, cryptography, archive, document, database, model, backup or unknown.").String()
+	filesystemScanExcludeClasses = filesystemScan.Flag("exclude-classes", "Comma separated list of file classes to exclude in scan. Classes defined here take precedence over the include list.").String()
 
 	s3Scan              = cli.Command("s3", "Find credentials in S3 buckets.")
 	s3ScanKey           = s3Scan.Flag("key", "S3 key used to authenticate. Can be provided with environment variable AWS_ACCESS_KEY_ID.").Envar("AWS_ACCESS_KEY_ID").String()
@@ -243,8 +258,10 @@ var (
 	huggingfaceIncludeDiscussions = huggingfaceScan.Flag("include-discussions", "Include discussions in scan.").Bool()
 	huggingfaceIncludePrs         = huggingfaceScan.Flag("include-prs", "Include pull requests in scan.").Bool()
 
-	verifyScan = cli.Command("verify", "Verify results.")
-	verifyFile = verifyScan.Flag("file", "Result file to verify.").Required().String()
+	verifyScan        = cli.Command("verify", "Verify results.")
+	verifyFiles       = verifyScan.Flag("file", "Result file to verify. You can repeat this flag.").Strings()
+	verifyDirectories = verifyScan.Flag("directory", "Directory of result files to verify. You can repeat this flag.").Strings()
+	verifyJSONL       = verifyScan.Flag("jsonl", `JSONL file with one result per line to verify. Use "-" to read from stdin and write to stdout. You can repeat this flag.`).Strings()
 
 	analyzeCmd = analyzer.Command(cli)
 
@@ -432,9 +449,16 @@ func run(state overseer.State) {
 		feature.UserAgentSuffix.Store(*userAgentSuffix)
 	}
 
+	if *dexStringPool {
+		feature.EnableDexStringPool.Store(true)
+	}
+
 	// OSS Default APK handling on
 	feature.EnableAPKHandler.Store(true)
 
+	// OSS Default IPA handling on
+	feature.EnableIPAHandler.Store(true)
+
 	conf := &config.Config{}
 	if *configFilename != "" {
 		var err error
@@ -481,14 +505,72 @@ func run(state overseer.State) {
 		logFatal(err, "failed to configure results flag")
 	}
 
+	policy, err := detectors.ParseVerificationPolicy(*verificationPolicy)
+	if err != nil {
+		logFatal(err, "failed to configure verification policy")
+	}
+
+	var verificationCache *verificationcache.Cache
+	if *verificationCachePath != "" {
+		store, err := file.NewCache[verificationcache.Entry](*verificationCachePath)
+		if err != nil {
+			logFatal(err, "failed to open the verification cache")
+		}
+		// Outcomes are written to the file as they are cached; closing it only
+		// compacts the file.
+		defer func() {
+			if err := store.Close(); err != nil {
+				logger.Error(err, "failed to save the verification cache")
+			}
+		}()
+		verificationCache = verificationcache.New(store, verificationcache.WithTTL(*verificationCacheTTL))
+	}
+
+	rateLimiterOpts, err := parseRateLimits(*verificationRateLimit, *detectorRateLimits)
+	if err != nil {
+		logFatal(err, "failed to configure verification rate limits")
+	}
+	detectors.SetRateLimiter(detectors.NewRateLimiter(rateLimiterOpts...))
+
+	var auditLog *detectors.AuditLog
+	if *verificationAuditLog != "" {
+		f, err := os.OpenFile(*verificationAuditLog, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+		if err != nil {
+			logFatal(err, "failed to open the verification audit log")
+		}
+		defer func() {
+			if err := auditLog.Err(); err != nil {
+				logger.Error(err, "failed to write the verification audit log")
+			}
+			if err := f.Close(); err != nil {
+				logger.Error(err, "failed to close the verification audit log")
+			}
+		}()
+		auditLog = detectors.NewAuditLog(f)
+	}
+	// Detectors that verify with the clients of the common package are only
+	// rate limited and audited through its round trip hook.
+	if auditLog != nil || len(rateLimiterOpts) > 0 {
+		defer detectors.InstallCommonClientHook()()
+	}
+
+	detectorList := append(defaults.DefaultDetectors(), conf.Detectors...)
+	// The generic config detector reports values that vendor detectors may report too, so it's opt-in.
+	if *configSecrets {
+		detectorList = append(detectorList, &configsecret.Scanner{})
+	}
+
 	engConf := engine.Config{
 		Concurrency: *concurrency,
 		// The engine must always be configured with the list of
 		// default detectors, which can be further filtered by the
 		// user. The filters are applied by the engine and are only
 		// subtractive.
-		Detectors:             append(defaults.DefaultDetectors(), conf.Detectors...),
-		Verify:                !*noVerification,
+		Detectors:             detectorList,
+		Verify:                !*noVerification && policy != detectors.VerificationPolicyNone,
+		VerificationPolicy:    policy,
+		VerificationCache:     verificationCache,
+		VerificationAuditLog:  auditLog,
 		IncludeDetectors:      *includeDetectors,
 		ExcludeDetectors:      *excludeDetectors,
 		CustomVerifiersOnly:   *customVerifiersOnly,
@@ -741,6 +823,8 @@ func runSingleScan(ctx context.Context, cmd string, cfg engine.Config) (metrics,
 			Paths:            paths,
 			IncludePathsFile: *filesystemScanIncludePaths,
 			ExcludePathsFile: *filesystemScanExcludePaths,
+			IncludeClasses:   strings.Split(*filesystemScanIncludeClasses, ","),
+			ExcludeClasses:   strings.Split(*filesystemScanExcludeClasses, ","),
 		}
 		if ref, err = eng.ScanFileSystem(ctx, cfg); err != nil {
 			return scanMetrics, fmt.Errorf("failed to scan filesystem: %v", err)
@@ -902,7 +986,15 @@ func runSingleScan(ctx context.Context, cmd string, cfg engine.Config) (metrics,
 			return scanMetrics, fmt.Errorf("failed to scan HuggingFace: %v", err)
 		}
 	case verifyScan.FullCommand():
-		eng.ScanVerify(ctx, *verifyFile)
+		cfg := engine.VerifyConfig{
+			Files:       *verifyFiles,
+			Directories: *verifyDirectories,
+			JSONL:       *verifyJSONL,
+			Concurrency: *concurrency,
+		}
+		if ref, err = eng.ScanVerify(ctx, cfg); err != nil {
+			return scanMetrics, fmt.Errorf("failed to verify results: %v", err)
+		}
 	default:
 		return scanMetrics, fmt.Errorf("invalid command: %s", cmd)
 	}
@@ -952,6 +1044,31 @@ func parseResults(input *string) (map[string]struct{}, error) {
 	return results, nil
 }
 
+// parseRateLimits parses the `--verification-rate-limit` and
+// `--verification-rate-limit-detector` flags into RateLimiter options.
+func parseRateLimits(defaultLimit string, detectorLimits map[string]string) ([]detectors.RateLimiterOption, error) {
+	var opts []detectors.RateLimiterOption
+	if defaultLimit != "" {
+		limit, err := detectors.ParseRateLimit(defaultLimit)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, detectors.WithDefaultRateLimit(limit))
+	}
+	for name, value := range detectorLimits {
+		id, err := config.ParseDetector(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid detector for rate limit: %w", err)
+		}
+		limit, err := detectors.ParseRateLimit(value)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, detectors.WithDetectorRateLimit(id.ID, limit))
+	}
+	return opts, nil
+}
+
 // logFatalFunc returns a log.Fatal style function. Calling the returned
 // function will terminate the program without cleanup.
 func logFatalFunc(logger logr.Logger) func(error, string, ...any) {
@@ -27,6 +27,7 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/analyzer/analyzers/stripe"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/analyzer/analyzers/twilio"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/analyzer/config"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
 )
 
 var (
@@ -52,7 +53,7 @@ func Commandv2(app *kingpin.Application) *kingpin.CmdClause {
 	analyzeKeyTypev2 = cli.Arg("key-type", keyTypeHelp).Enum(availableAnalyzers...)
 	//outputFile = cli.Flag("output", "Output file to write the analysis results.").String()
 	logFile = cli.Flag("logfile", "Logfile file to write the analysis results.").String()
-	secret = cli.Flag("secret", "Secret key to analyze.").Required().String()
+	secret = cli.Flag("secret", `Secret key to analyze. Multi-part secrets are given as a JSON object of their named parts, e.g. {"id":"...","secret":"..."}, or joined with ";-|".`).Required().String()
 
 	return cli
 }
@@ -63,7 +64,6 @@ func Runv2(cmd string) {
 	config.LogFile = *logFile
 	//config.OutputFile = *outputFile
 	config.LoggingEnabled = true
-	parts := strings.Split(*secret, ";-|")
 
 	switch strings.ToLower(*analyzeKeyTypev2) {
 	case "github", "8":
@@ -87,10 +87,11 @@ func Runv2(cmd string) {
 	case "slack", "13":
 		slack.AnalyzeAndPrintPermissions(config, *secret)
 	case "twilio", "26":
-		if len(parts) != 2 {
+		cred, err := detectors.ParseCredential(*secret, detectors.CredentialPartID, detectors.CredentialPartSecret)
+		if err != nil {
 			return
 		}
-		twilio.AnalyzeAndPrintPermissions(config, parts[0], parts[1])
+		twilio.AnalyzeAndPrintPermissions(config, cred.Get(detectors.CredentialPartID), cred.Get(detectors.CredentialPartSecret))
 	case "airbrake", "125", "126":
 		airbrake.AnalyzeAndPrintPermissions(config, *secret)
 	case "huggingface", "926":
@@ -114,10 +115,11 @@ func Runv2(cmd string) {
 	case "sourcegraph", "928":
 		sourcegraph.AnalyzeAndPrintPermissions(config, *secret)
 	case "shopify", "902":
-		if len(parts) != 2 {
+		cred, err := detectors.ParseCredential(*secret, detectors.CredentialPartToken, detectors.CredentialPartDomain)
+		if err != nil {
 			return
 		}
-		shopify.AnalyzeAndPrintPermissions(config, parts[0], parts[1])
+		shopify.AnalyzeAndPrintPermissions(config, cred.Get(detectors.CredentialPartToken), cred.Get(detectors.CredentialPartDomain))
 	case "opsgenie", "875":
 		opsgenie.AnalyzeAndPrintPermissions(config, *secret)
 	}
@@ -0,0 +1,223 @@
+// Package file provides a cache that persists its key/value pairs to a local
+// file, so they survive across runs.
+//
+// The file is an append-only log of JSON lines, one per Set or Delete, which
+// is read back into memory when the cache is opened and compacted when it is
+// closed. Values must therefore be JSON serializable. A file must only be
+// opened by one Cache at a time.
+package file
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Cache is a generic, file-backed cache that stores key/value pairs in memory
+// and appends every change to its file.
+type Cache[T any] struct {
+	mu    sync.RWMutex
+	path  string
+	items map[string]T
+	log   *os.File
+	// err is the first error encountered while writing the log. The Cache
+	// interface doesn't report errors, so it is returned by Close.
+	err error
+}
+
+// record is a single line of the log. A nil Value deletes the key.
+type record[T any] struct {
+	Key   string `json:"key"`
+	Value *T     `json:"value,omitempty"`
+}
+
+// NewCache opens the cache stored at path, creating the file and its parent
+// directories if they don't exist. Lines that can't be decoded, such as one
+// cut short by a crash, are skipped.
+func NewCache[T any](path string) (*Cache[T], error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cache file: %w", err)
+	}
+
+	items, err := readLog[T](f)
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to read cache file: %w", err)
+	}
+	return &Cache[T]{path: path, items: items, log: f}, nil
+}
+
+func readLog[T any](r io.Reader) (map[string]T, error) {
+	items := make(map[string]T)
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			var rec record[T]
+			if json.Unmarshal(line, &rec) == nil {
+				if rec.Value == nil {
+					delete(items, rec.Key)
+				} else {
+					items[rec.Key] = *rec.Value
+				}
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return items, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// append writes rec to the log. The caller must hold the write lock.
+func (c *Cache[T]) append(rec record[T]) {
+	if c.log == nil {
+		return
+	}
+	line, err := json.Marshal(rec)
+	if err == nil {
+		_, err = c.log.Write(append(line, '\n'))
+	}
+	if err != nil && c.err == nil {
+		c.err = err
+	}
+}
+
+// Set adds a key-value pair to the cache.
+func (c *Cache[T]) Set(key string, val T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items[key] = val
+	c.append(record[T]{Key: key, Value: &val})
+}
+
+// Get retrieves a value from the cache by key.
+func (c *Cache[T]) Get(key string) (T, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	val, ok := c.items[key]
+	return val, ok
+}
+
+// Exists checks if a key exists in the cache.
+func (c *Cache[T]) Exists(key string) bool {
+	_, ok := c.Get(key)
+	return ok
+}
+
+// Delete removes a key from the cache.
+func (c *Cache[T]) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.items[key]; !ok {
+		return
+	}
+	delete(c.items, key)
+	c.append(record[T]{Key: key})
+}
+
+// Clear removes all keys from the cache and truncates its file.
+func (c *Cache[T]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items = make(map[string]T)
+	if c.log == nil {
+		return
+	}
+	if err := c.log.Truncate(0); err != nil && c.err == nil {
+		c.err = err
+	}
+}
+
+// Count returns the number of key-value pairs in the cache.
+func (c *Cache[T]) Count() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.items)
+}
+
+// Keys returns all keys in the cache.
+func (c *Cache[T]) Keys() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	keys := make([]string, 0, len(c.items))
+	for k := range c.items {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Values returns all values in the cache.
+func (c *Cache[T]) Values() []T {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	values := make([]T, 0, len(c.items))
+	for _, v := range c.items {
+		values = append(values, v)
+	}
+	return values
+}
+
+// Contents returns a comma-separated string containing all keys in the cache.
+func (c *Cache[T]) Contents() string {
+	return strings.Join(c.Keys(), ",")
+}
+
+// Close compacts the cache file so it only contains the current key/value
+// pairs and closes it. It returns the first error encountered while writing
+// the file. The cache can't be used after it is closed.
+func (c *Cache[T]) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.log == nil {
+		return c.err
+	}
+	if err := c.log.Close(); err != nil && c.err == nil {
+		c.err = err
+	}
+	c.log = nil
+	if c.err != nil {
+		return c.err
+	}
+	c.err = c.compact()
+	return c.err
+}
+
+// compact replaces the log with one record per key. The file is written to a
+// temporary file first, so a crash never loses the existing entries.
+func (c *Cache[T]) compact() error {
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), "."+filepath.Base(c.path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+	for key, val := range c.items {
+		if err := enc.Encode(record[T]{Key: key, Value: &val}); err != nil {
+			_ = tmp.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path)
+}
@@ -0,0 +1,84 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	c, err := NewCache[string](filepath.Join(t.TempDir(), "cache.jsonl"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+
+	c.Set("key1", "value1")
+	v, ok := c.Get("key1")
+	assert.True(t, ok)
+	assert.Equal(t, "value1", v)
+	assert.True(t, c.Exists("key1"))
+	assert.Equal(t, 1, c.Count())
+
+	c.Delete("key1")
+	_, ok = c.Get("key1")
+	assert.False(t, ok)
+
+	c.Set("key2", "value2")
+	c.Set("key3", "value3")
+	keys := c.Keys()
+	sort.Strings(keys)
+	assert.Equal(t, []string{"key2", "key3"}, keys)
+	values := c.Values()
+	sort.Strings(values)
+	assert.Equal(t, []string{"value2", "value3"}, values)
+
+	c.Clear()
+	assert.Equal(t, 0, c.Count())
+}
+
+func TestCache_Persistence(t *testing.T) {
+	type entry struct {
+		Status string
+		Count  int
+	}
+	path := filepath.Join(t.TempDir(), "nested", "cache.jsonl")
+
+	c, err := NewCache[entry](path)
+	require.NoError(t, err)
+	c.Set("a", entry{Status: "verified", Count: 1})
+	c.Set("b", entry{Status: "invalid", Count: 2})
+	c.Set("a", entry{Status: "invalid", Count: 3})
+	c.Delete("b")
+
+	// Entries are written as they are set, before the cache is closed.
+	reopened, err := NewCache[entry](path)
+	require.NoError(t, err)
+	v, ok := reopened.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, entry{Status: "invalid", Count: 3}, v)
+	assert.False(t, reopened.Exists("b"))
+	require.NoError(t, reopened.Close())
+
+	// Closing compacts the log to one line per key.
+	require.NoError(t, c.Close())
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(data), "\n"))
+}
+
+func TestCache_SkipsCorruptLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.jsonl")
+	content := `{"key":"a","value":"1"}` + "\n" + `{"key":"b","val`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	c, err := NewCache[string](path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+	assert.Equal(t, 1, c.Count())
+	v, _ := c.Get("a")
+	assert.Equal(t, "1", v)
+}
@@ -7,6 +7,7 @@ import (
 	"net"
 	"net/http"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
@@ -82,7 +83,9 @@ type FakeTransport struct {
 }
 
 func (t FakeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	return t.CreateResponse(req)
+	res, err := t.CreateResponse(req)
+	RecordResponse(req, res)
+	return res, err
 }
 
 type CustomTransport struct {
@@ -98,7 +101,32 @@ func userAgent() string {
 
 func (t *CustomTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	req.Header.Add("User-Agent", userAgent())
-	return t.T.RoundTrip(req)
+	send := func(req *http.Request) (*http.Response, error) {
+		res, err := t.T.RoundTrip(req)
+		RecordResponse(req, res)
+		return res, err
+	}
+	if hook := roundTripHook.Load(); hook != nil {
+		return (*hook)(req, send)
+	}
+	return send(req)
+}
+
+// RoundTripHook sends req with send on behalf of a CustomTransport. It lets
+// packages that this one can't import, such as detectors, handle the requests
+// of the clients created here.
+type RoundTripHook func(req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error)
+
+var roundTripHook atomic.Pointer[RoundTripHook]
+
+// SetRoundTripHook makes every CustomTransport send its requests through hook.
+// A nil hook sends them directly.
+func SetRoundTripHook(hook RoundTripHook) {
+	if hook == nil {
+		roundTripHook.Store(nil)
+		return
+	}
+	roundTripHook.Store(&hook)
 }
 
 func NewCustomTransport(T http.RoundTripper) *CustomTransport {
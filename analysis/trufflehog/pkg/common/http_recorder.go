@@ -0,0 +1,38 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+)
+
+type responseRecorderKey struct{}
+
+// ResponseRecorder records the status code of HTTP responses received for
+// requests made with a context returned by WithResponseRecorder.
+type ResponseRecorder struct {
+	lastStatus atomic.Int32
+}
+
+// WithResponseRecorder returns a copy of ctx that records the HTTP responses
+// observed by the transports of this package and the detectors package.
+func WithResponseRecorder(ctx context.Context) (context.Context, *ResponseRecorder) {
+	r := &ResponseRecorder{}
+	return context.WithValue(ctx, responseRecorderKey{}, r), r
+}
+
+// LastStatus returns the status code of the last recorded response, or 0 if
+// no response was recorded.
+func (r *ResponseRecorder) LastStatus() int {
+	return int(r.lastStatus.Load())
+}
+
+// RecordResponse records res on the ResponseRecorder of req's context, if any.
+func RecordResponse(req *http.Request, res *http.Response) {
+	if res == nil {
+		return
+	}
+	if r, ok := req.Context().Value(responseRecorderKey{}).(*ResponseRecorder); ok {
+		r.lastStatus.Store(int32(res.StatusCode))
+	}
+}
@@ -60,9 +60,10 @@ func NewWebhookCustomRegex(pb *custom_detectorspb.CustomRegex) (*CustomRegexWebh
 
 var httpClient = common.SaneHttpClient()
 
-func (s CustomRegexWebhook) Verify(ctx context.Context, secret string) bool {
-	//TODO: add something here
-	return false
+func (s CustomRegexWebhook) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	// The webhook payload is built from all regex matches of a chunk, which
+	// aren't available when re-verifying a single secret.
+	return detectors.VerificationNotSupportedOutcome()
 }
 
 func (c *CustomRegexWebhook) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
@@ -2,26 +2,20 @@ package codecov
 
 import (
 	"context"
-	"net/http"
 	"strings"
 
 	regexp "github.com/wasilibs/go-re2"
 
-	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
 )
 
-type Scanner struct {
-	client *http.Client
-}
+type Scanner struct{}
 
 var (
 	// Ensure the Scanner satisfies the interface at compile time.
 	_ detectors.Detector = (*Scanner)(nil)
 
-	defaultClient = common.SaneHttpClient()
-
 	// Make sure that your group is surrounded in boundary characters such as below to reduce false positives.
 	keyPat = regexp.MustCompile(detectors.PrefixRegex([]string{"codecov"}) + `\b([a-z0-9]{32})\b`)
 )
@@ -32,13 +26,6 @@ func (s Scanner) Keywords() []string {
 	return []string{"codecov"}
 }
 
-func (s Scanner) getClient() *http.Client {
-	if s.client != nil {
-		return s.client
-	}
-	return defaultClient
-}
-
 // FromData will find and optionally verify Abbysale secrets in a given set of bytes.
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
@@ -57,10 +44,7 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 		}
 
 		if verify {
-			client := s.getClient()
-			isVerified, verificationErr := verifyMatch(ctx, client, resMatch)
-			s1.Verified = isVerified
-			s1.SetVerificationError(verificationErr, resMatch)
+			s1.SetVerificationError(detectors.ErrVerificationNotSupported)
 		}
 
 		results = append(results, s1)
@@ -69,15 +53,17 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	client := s.getClient()
-	isVerified, _ := verifyMatch(ctx, client, secret)
-	return isVerified
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	// Codecov upload tokens can only be used to upload coverage reports, which
+	// has side effects.
+	return detectors.VerificationNotSupportedOutcome()
 }
 
-func verifyMatch(ctx context.Context, client *http.Client, resMatch string) (bool, error) {
-	//TODO: imlempent
-	return false, nil
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		// The detector can't verify its secrets, so it sends no requests.
+		ReadOnly: true,
+	}
 }
 
 func (s Scanner) Type() detectorspb.DetectorType {
@@ -0,0 +1,25 @@
+package codecov
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+)
+
+func TestCodecov_VerificationNotSupported(t *testing.T) {
+	s := Scanner{}
+	outcome := s.Verify(context.Background(), "0123456789abcdef0123456789abcdef")
+	assert.Equal(t, detectors.VerificationStatusUnsupported, outcome.Status)
+	assert.ErrorIs(t, outcome.Error, detectors.ErrVerificationNotSupported)
+
+	results, err := s.FromData(context.Background(), true, []byte("CODECOV_TOKEN: codecov 0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.False(t, results[0].Verified)
+	// Verification errors of results are flattened to their message.
+	assert.EqualError(t, results[0].VerificationError(), detectors.ErrVerificationNotSupported.Error())
+}
@@ -0,0 +1,32 @@
+package cohere
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectorstest"
+)
+
+const (
+	testSecret = "u8jzPde0IgxLd6GncfBAepfJBd0Kh8oOOL8dKLzd"
+	testData   = `CO_API_KEY: cohere u8jzPde0IgxLd6GncfBAepfJBd0Kh8oOOL8dKLzd`
+)
+
+func TestCohere_Verify(t *testing.T) {
+	detectorstest.VerifierTest{
+		NewDetector: func(client *http.Client) detectors.Detector { return Scanner{client: client} },
+		Secret:      testSecret,
+		Data:        testData,
+		Method:      http.MethodGet,
+		URL:         "https://api.cohere.com/v1/models?page_size=1",
+		Header: map[string]string{
+			"Authorization": "Bearer " + testSecret,
+		},
+		Responses: []detectorstest.VerifierResponse{
+			{StatusCode: http.StatusOK, Want: detectors.VerificationStatusVerified},
+			{StatusCode: http.StatusUnauthorized, Want: detectors.VerificationStatusInvalid},
+			{StatusCode: http.StatusInternalServerError, Want: detectors.VerificationStatusIndeterminate},
+		},
+	}.Run(t)
+}
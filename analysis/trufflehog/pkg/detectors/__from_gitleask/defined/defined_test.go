@@ -0,0 +1,33 @@
+package defined
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectorstest"
+)
+
+const (
+	testSecret = "dnkey-oc9is0j8ht9lgmxg9edn581u33-xtplpft75v2seh60kvj50ce9uvw53efr4edt2sywb3wkh5dnsipz"
+	testData   = `DN_API_KEY=dnkey-oc9is0j8ht9lgmxg9edn581u33-xtplpft75v2seh60kvj50ce9uvw53efr4edt2sywb3wkh5dnsipz`
+)
+
+func TestDefined_Verify(t *testing.T) {
+	detectorstest.VerifierTest{
+		NewDetector: func(client *http.Client) detectors.Detector { return Scanner{client: client} },
+		Secret:      testSecret,
+		Data:        testData,
+		Method:      http.MethodGet,
+		URL:         "https://api.defined.net/v1/networks?pageSize=1",
+		Header: map[string]string{
+			"Authorization": "Bearer " + testSecret,
+		},
+		Responses: []detectorstest.VerifierResponse{
+			{StatusCode: http.StatusOK, Want: detectors.VerificationStatusVerified},
+			{StatusCode: http.StatusForbidden, Want: detectors.VerificationStatusVerified},
+			{StatusCode: http.StatusUnauthorized, Want: detectors.VerificationStatusInvalid},
+			{StatusCode: http.StatusInternalServerError, Want: detectors.VerificationStatusIndeterminate},
+		},
+	}.Run(t)
+}
@@ -0,0 +1,33 @@
+package duffel
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectorstest"
+)
+
+const (
+	testSecret = "duffel_test_z63FfkCzJr4i0B3JrTAwR4y9ojfljoQoaF1LlqsajAI"
+	testData   = `duffel token duffel_test_z63FfkCzJr4i0B3JrTAwR4y9ojfljoQoaF1LlqsajAI`
+)
+
+func TestDuffel_Verify(t *testing.T) {
+	detectorstest.VerifierTest{
+		NewDetector: func(client *http.Client) detectors.Detector { return Scanner{client: client} },
+		Secret:      testSecret,
+		Data:        testData,
+		Method:      http.MethodGet,
+		URL:         "https://api.duffel.com/air/airlines?limit=1",
+		Header: map[string]string{
+			"Authorization":  "Bearer " + testSecret,
+			"Duffel-Version": "v2",
+		},
+		Responses: []detectorstest.VerifierResponse{
+			{StatusCode: http.StatusOK, Want: detectors.VerificationStatusVerified},
+			{StatusCode: http.StatusUnauthorized, Want: detectors.VerificationStatusInvalid},
+			{StatusCode: http.StatusInternalServerError, Want: detectors.VerificationStatusIndeterminate},
+		},
+	}.Run(t)
+}
@@ -0,0 +1,33 @@
+package easypost
+
+import (
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectorstest"
+)
+
+const (
+	testSecret = "EZAKxui6d39zzzzg4zdmen2khvdgaj8gxbenyjqwx4hh5344tfjgvq4k7b"
+	testData   = `EASYPOST_API_KEY=EZAKxui6d39zzzzg4zdmen2khvdgaj8gxbenyjqwx4hh5344tfjgvq4k7b`
+)
+
+func TestEasypost_Verify(t *testing.T) {
+	detectorstest.VerifierTest{
+		NewDetector: func(client *http.Client) detectors.Detector { return Scanner{client: client} },
+		Secret:      testSecret,
+		Data:        testData,
+		Method:      http.MethodGet,
+		URL:         "https://api.easypost.com/v2/addresses?page_size=1",
+		Header: map[string]string{
+			"Authorization": "Basic " + base64.StdEncoding.EncodeToString([]byte(testSecret+":")),
+		},
+		Responses: []detectorstest.VerifierResponse{
+			{StatusCode: http.StatusOK, Want: detectors.VerificationStatusVerified},
+			{StatusCode: http.StatusUnauthorized, Want: detectors.VerificationStatusInvalid},
+			{StatusCode: http.StatusInternalServerError, Want: detectors.VerificationStatusIndeterminate},
+		},
+	}.Run(t)
+}
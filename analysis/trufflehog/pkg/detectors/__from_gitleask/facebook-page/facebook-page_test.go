@@ -0,0 +1,32 @@
+package facebook_page
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectorstest"
+)
+
+const (
+	testSecret = "EAACn88HxjSI6bWHtP3fS2qHx6kwXoIIXGvOoNZYW2mZp0zVZomHFwUbbYrEqmSM"
+	testData   = `page_token = "EAACn88HxjSI6bWHtP3fS2qHx6kwXoIIXGvOoNZYW2mZp0zVZomHFwUbbYrEqmSM"`
+)
+
+func TestFacebookPage_Verify(t *testing.T) {
+	detectorstest.VerifierTest{
+		NewDetector: func(client *http.Client) detectors.Detector { return Scanner{client: client} },
+		Secret:      testSecret,
+		Data:        testData,
+		Method:      http.MethodGet,
+		URL:         "https://graph.facebook.com/me?fields=id",
+		Header: map[string]string{
+			"Authorization": "Bearer " + testSecret,
+		},
+		Responses: []detectorstest.VerifierResponse{
+			{StatusCode: http.StatusOK, Body: `{"id":"1234"}`, Want: detectors.VerificationStatusVerified},
+			{StatusCode: http.StatusBadRequest, Body: `{"error":{"type":"OAuthException","code":190}}`, Want: detectors.VerificationStatusInvalid},
+			{StatusCode: http.StatusInternalServerError, Want: detectors.VerificationStatusIndeterminate},
+		},
+	}.Run(t)
+}
@@ -0,0 +1,33 @@
+package harness
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectorstest"
+)
+
+const (
+	testSecret = "pat.TBGzvAmwufUxbvJDCTbyvH.NsG9eh6Yo4gfqrc5XlrWi0B2.6R08qzjI6GKFSufrdZSl"
+	testData   = `HARNESS_TOKEN=pat.TBGzvAmwufUxbvJDCTbyvH.NsG9eh6Yo4gfqrc5XlrWi0B2.6R08qzjI6GKFSufrdZSl`
+)
+
+func TestHarness_Verify(t *testing.T) {
+	detectorstest.VerifierTest{
+		NewDetector: func(client *http.Client) detectors.Detector { return Scanner{client: client} },
+		Secret:      testSecret,
+		Data:        testData,
+		Method:      http.MethodGet,
+		URL:         "https://app.harness.io/ng/api/accounts/TBGzvAmwufUxbvJDCTbyvH",
+		Header: map[string]string{
+			"X-Api-Key": testSecret,
+		},
+		Responses: []detectorstest.VerifierResponse{
+			{StatusCode: http.StatusOK, Want: detectors.VerificationStatusVerified},
+			{StatusCode: http.StatusForbidden, Want: detectors.VerificationStatusVerified},
+			{StatusCode: http.StatusUnauthorized, Want: detectors.VerificationStatusInvalid},
+			{StatusCode: http.StatusInternalServerError, Want: detectors.VerificationStatusIndeterminate},
+		},
+	}.Run(t)
+}
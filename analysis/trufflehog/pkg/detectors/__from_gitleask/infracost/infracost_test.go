@@ -0,0 +1,34 @@
+package infracost
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectorstest"
+)
+
+const (
+	testSecret = "ico-lkrCaqx9vJupc94tnwlavyfErGPmpGXa"
+	testData   = `INFRACOST_API_KEY=ico-lkrCaqx9vJupc94tnwlavyfErGPmpGXa`
+)
+
+func TestInfracost_Verify(t *testing.T) {
+	detectorstest.VerifierTest{
+		NewDetector: func(client *http.Client) detectors.Detector { return Scanner{client: client} },
+		Secret:      testSecret,
+		Data:        testData,
+		Method:      http.MethodPost,
+		URL:         "https://pricing.api.infracost.io/graphql",
+		Header: map[string]string{
+			"X-Api-Key":    testSecret,
+			"Content-Type": "application/json",
+		},
+		Body: `{"query":"{ __typename }"}`,
+		Responses: []detectorstest.VerifierResponse{
+			{StatusCode: http.StatusOK, Want: detectors.VerificationStatusVerified},
+			{StatusCode: http.StatusForbidden, Want: detectors.VerificationStatusInvalid},
+			{StatusCode: http.StatusInternalServerError, Want: detectors.VerificationStatusIndeterminate},
+		},
+	}.Run(t)
+}
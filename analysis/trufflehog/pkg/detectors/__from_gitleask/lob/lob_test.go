@@ -0,0 +1,33 @@
+package lob
+
+import (
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectorstest"
+)
+
+const (
+	testSecret = "live_2824c1c099724caf4941d4072014b3ce107"
+	testData   = `lob key: live_2824c1c099724caf4941d4072014b3ce107`
+)
+
+func TestLob_Verify(t *testing.T) {
+	detectorstest.VerifierTest{
+		NewDetector: func(client *http.Client) detectors.Detector { return Scanner{client: client} },
+		Secret:      testSecret,
+		Data:        testData,
+		Method:      http.MethodGet,
+		URL:         "https://api.lob.com/v1/addresses?limit=1",
+		Header: map[string]string{
+			"Authorization": "Basic " + base64.StdEncoding.EncodeToString([]byte(testSecret+":")),
+		},
+		Responses: []detectorstest.VerifierResponse{
+			{StatusCode: http.StatusOK, Want: detectors.VerificationStatusVerified},
+			{StatusCode: http.StatusUnauthorized, Want: detectors.VerificationStatusInvalid},
+			{StatusCode: http.StatusInternalServerError, Want: detectors.VerificationStatusIndeterminate},
+		},
+	}.Run(t)
+}
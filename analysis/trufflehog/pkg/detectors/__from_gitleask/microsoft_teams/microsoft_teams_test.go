@@ -0,0 +1,33 @@
+package microsoftteams
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectorstest"
+)
+
+const (
+	testSecret = "https://acme.webhook.office.com/webhookb2/f80e222f-8287-67ef-c2f9-1624a8940f1f@836f99ee-e369-2f09-e2e8-c662248b483b/IncomingWebhook/7ffc050fec94dbca3a0aac36098b2cc2/bd818319-478d-a6bd-0c62-1de49f145fda"
+	testData   = `webhook: https://acme.webhook.office.com/webhookb2/f80e222f-8287-67ef-c2f9-1624a8940f1f@836f99ee-e369-2f09-e2e8-c662248b483b/IncomingWebhook/7ffc050fec94dbca3a0aac36098b2cc2/bd818319-478d-a6bd-0c62-1de49f145fda`
+)
+
+func TestMicrosoftTeams_Verify(t *testing.T) {
+	detectorstest.VerifierTest{
+		NewDetector: func(client *http.Client) detectors.Detector { return Scanner{client: client} },
+		Secret:      testSecret,
+		Data:        testData,
+		Method:      http.MethodPost,
+		URL:         testSecret,
+		Header: map[string]string{
+			"Content-Type": "application/json",
+		},
+		Body: `{"text":""}`,
+		Responses: []detectorstest.VerifierResponse{
+			{StatusCode: http.StatusBadRequest, Body: "Text is required", Want: detectors.VerificationStatusVerified},
+			{StatusCode: http.StatusNotFound, Want: detectors.VerificationStatusInvalid},
+			{StatusCode: http.StatusInternalServerError, Want: detectors.VerificationStatusIndeterminate},
+		},
+	}.Run(t)
+}
@@ -0,0 +1,32 @@
+package shippo
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectorstest"
+)
+
+const (
+	testSecret = "shippo_test_9988c79fc35526f7eaed46725a2a7b860dcd6c8a"
+	testData   = `SHIPPO_TOKEN=shippo_test_9988c79fc35526f7eaed46725a2a7b860dcd6c8a`
+)
+
+func TestShippo_Verify(t *testing.T) {
+	detectorstest.VerifierTest{
+		NewDetector: func(client *http.Client) detectors.Detector { return Scanner{client: client} },
+		Secret:      testSecret,
+		Data:        testData,
+		Method:      http.MethodGet,
+		URL:         "https://api.goshippo.com/addresses/?results=1",
+		Header: map[string]string{
+			"Authorization": "ShippoToken " + testSecret,
+		},
+		Responses: []detectorstest.VerifierResponse{
+			{StatusCode: http.StatusOK, Want: detectors.VerificationStatusVerified},
+			{StatusCode: http.StatusUnauthorized, Want: detectors.VerificationStatusInvalid},
+			{StatusCode: http.StatusInternalServerError, Want: detectors.VerificationStatusIndeterminate},
+		},
+	}.Run(t)
+}
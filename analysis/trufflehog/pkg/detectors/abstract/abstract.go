@@ -72,10 +72,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
 	client := s.getClient()
-	isVerified, _ := verifyAbstract(ctx, client, secret)
-	return isVerified
+	isVerified, err := verifyAbstract(ctx, client, secret)
+	return detectors.NewVerificationOutcome(isVerified, err, secret)
 }
 
 func verifyAbstract(ctx context.Context, client *http.Client, resMatch string) (bool, error) {
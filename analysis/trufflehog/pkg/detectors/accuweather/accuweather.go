@@ -72,10 +72,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
 	client := s.getClient()
-	isVerified, _ := verifyAccuweather(ctx, client, secret)
-	return isVerified
+	isVerified, err := verifyAccuweather(ctx, client, secret)
+	return detectors.NewVerificationOutcome(isVerified, err, secret)
 }
 
 func verifyAccuweather(ctx context.Context, client *http.Client, resMatch string) (bool, error) {
@@ -101,6 +101,15 @@ func verifyAccuweather(ctx context.Context, client *http.Client, resMatch string
 	}
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		// Every lookup counts against the paid quota of the key.
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "dataservice.accuweather.com"}},
+		ReadOnly: true,
+		Billable: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_Accuweather
 }
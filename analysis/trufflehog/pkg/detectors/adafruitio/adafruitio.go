@@ -72,10 +72,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
 	client := s.getClient()
-	isVerified, _ := verifyAdafruitIO(ctx, client, secret)
-	return isVerified
+	isVerified, err := verifyAdafruitIO(ctx, client, secret)
+	return detectors.NewVerificationOutcome(isVerified, err, secret)
 }
 
 func verifyAdafruitIO(ctx context.Context, client *http.Client, resMatch string) (bool, error) {
@@ -100,6 +100,13 @@ func verifyAdafruitIO(ctx context.Context, client *http.Client, resMatch string)
 	}
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "io.adafruit.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_AdafruitIO
 }
@@ -56,6 +56,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 				Raw:          []byte(resMatch),
 				RawV2:        []byte(resMatch + resIdMatch),
 			}
+			s1.SetCredential(
+				detectors.CredentialPart{Name: detectors.CredentialPartSecret, Value: resMatch},
+				detectors.CredentialPart{Name: detectors.CredentialPartID, Value: resIdMatch},
+			)
 
 			if verify {
 				verified, err := verifyAdobeIO(ctx, resMatch, resIdMatch)
@@ -91,13 +95,21 @@ func verifyAdobeIO(ctx context.Context, resMatch string, resIdMatch string) (boo
 
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 2 {
-		return false
-	}
-	isVerified, _ := verifyAdobeIO(ctx, parts[0], parts[1])
-	return isVerified
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartSecret, detectors.CredentialPartID}
+}
+
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	isVerified, err := verifyAdobeIO(
+		ctx,
+		cred.Get(detectors.CredentialPartSecret),
+		cred.Get(detectors.CredentialPartID),
+	)
+	return detectors.NewVerificationOutcome(isVerified, err, cred.Values()...)
 }
 
 func (s Scanner) Type() detectorspb.DetectorType {
@@ -67,6 +67,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 				Raw:          []byte(resMatch),
 				RawV2:        []byte(resMatch + resIdMatch),
 			}
+			s1.SetCredential(
+				detectors.CredentialPart{Name: detectors.CredentialPartSecret, Value: resMatch},
+				detectors.CredentialPart{Name: detectors.CredentialPartID, Value: resIdMatch},
+			)
 
 			if verify {
 				client := s.getClient()
@@ -82,15 +86,24 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 2 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartSecret, detectors.CredentialPartID}
+}
+
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
 	client := s.getClient()
 
-	isVerified, _ := verifyAdzuna(ctx, client, parts[0], parts[1])
-	return isVerified
+	isVerified, err := verifyAdzuna(
+		ctx,
+		client,
+		cred.Get(detectors.CredentialPartSecret),
+		cred.Get(detectors.CredentialPartID),
+	)
+	return detectors.NewVerificationOutcome(isVerified, err, cred.Values()...)
 }
 
 func verifyAdzuna(ctx context.Context, client *http.Client, resMatch, resIdMatch string) (bool, error) {
@@ -117,6 +130,15 @@ func verifyAdzuna(ctx context.Context, client *http.Client, resMatch, resIdMatch
 	}
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		// Every lookup counts against the paid quota of the key.
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "api.adzuna.com"}},
+		ReadOnly: true,
+		Billable: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_Adzuna
 }
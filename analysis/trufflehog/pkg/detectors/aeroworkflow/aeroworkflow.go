@@ -68,6 +68,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 				Raw:          []byte(resMatch),
 				RawV2:        []byte(resMatch + resIdMatch),
 			}
+			s1.SetCredential(
+				detectors.CredentialPart{Name: detectors.CredentialPartSecret, Value: resMatch},
+				detectors.CredentialPart{Name: detectors.CredentialPartID, Value: resIdMatch},
+			)
 
 			if verify {
 				client := s.getClient()
@@ -84,15 +88,24 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 2 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartSecret, detectors.CredentialPartID}
+}
+
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
 	client := s.getClient()
 
-	isVerified, _ := verifyAeroworkflow(ctx, client, parts[0], parts[1])
-	return isVerified
+	isVerified, err := verifyAeroworkflow(
+		ctx,
+		client,
+		cred.Get(detectors.CredentialPartSecret),
+		cred.Get(detectors.CredentialPartID),
+	)
+	return detectors.NewVerificationOutcome(isVerified, err, cred.Values()...)
 }
 
 func verifyAeroworkflow(ctx context.Context, client *http.Client, resMatch, resIdMatch string) (bool, error) {
@@ -121,6 +134,13 @@ func verifyAeroworkflow(ctx context.Context, client *http.Client, resMatch, resI
 	}
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "api.aeroworkflow.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_Aeroworkflow
 }
@@ -78,6 +78,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 				Raw:          []byte(resMatch),
 				RawV2:        []byte(resMatch + resSecret),
 			}
+			s1.SetCredential(
+				detectors.CredentialPart{Name: detectors.CredentialPartID, Value: resMatch},
+				detectors.CredentialPart{Name: detectors.CredentialPartSecret, Value: resSecret},
+			)
 
 			if verify {
 				client := s.getClient()
@@ -93,15 +97,24 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 2 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartID, detectors.CredentialPartSecret}
+}
+
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
 	client := s.getClient()
 
-	isVerified, _ := verifyAgora(ctx, client, parts[0], parts[1])
-	return isVerified
+	isVerified, err := verifyAgora(
+		ctx,
+		client,
+		cred.Get(detectors.CredentialPartID),
+		cred.Get(detectors.CredentialPartSecret),
+	)
+	return detectors.NewVerificationOutcome(isVerified, err, cred.Values()...)
 }
 
 func verifyAgora(ctx context.Context, client *http.Client, resMatch, resSecret string) (bool, error) {
@@ -128,6 +141,13 @@ func verifyAgora(ctx context.Context, client *http.Client, resMatch, resSecret s
 	}
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "api.agora.io"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_Agora
 }
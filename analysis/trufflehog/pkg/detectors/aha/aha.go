@@ -65,6 +65,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 			DetectorType: detectorspb.DetectorType_Aha,
 			Raw:          []byte(resMatch),
 		}
+		s1.SetCredential(
+			detectors.CredentialPart{Name: detectors.CredentialPartToken, Value: resMatch},
+			detectors.CredentialPart{Name: detectors.CredentialPartDomain, Value: resURLMatch},
+		)
 
 		if verify {
 			client := s.getClient()
@@ -79,15 +83,24 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 2 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartToken, detectors.CredentialPartDomain}
+}
+
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
 	client := s.getClient()
 
-	isVerified, _ := verifyAha(ctx, client, parts[0], parts[1])
-	return isVerified
+	isVerified, err := verifyAha(
+		ctx,
+		client,
+		cred.Get(detectors.CredentialPartToken),
+		cred.Get(detectors.CredentialPartDomain),
+	)
+	return detectors.NewVerificationOutcome(isVerified, err, cred.Values()...)
 }
 
 func verifyAha(ctx context.Context, client *http.Client, resMatch, resURLMatch string) (bool, error) {
@@ -116,6 +129,13 @@ func verifyAha(ctx context.Context, client *http.Client, resMatch, resURLMatch s
 	}
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "*.aha.io"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_Aha
 }
@@ -58,6 +58,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 				Raw:          []byte(resMatch),
 				RawV2:        []byte(resMatch + resIdMatch),
 			}
+			s1.SetCredential(
+				detectors.CredentialPart{Name: detectors.CredentialPartSecret, Value: resMatch},
+				detectors.CredentialPart{Name: detectors.CredentialPartID, Value: resIdMatch},
+			)
 			s1.ExtraData = map[string]string{
 				"rotation_guide": "https://howtorotate.com/docs/tutorials/airbrake/",
 			}
@@ -97,14 +101,29 @@ func verifyAirbrake(ctx context.Context, client *http.Client, resMatch string, r
 
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 2 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartSecret, detectors.CredentialPartID}
+}
 
-	isVerified, _ := verifyAirbrake(ctx, client, parts[0], parts[1])
-	return isVerified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	isVerified, err := verifyAirbrake(
+		ctx,
+		client,
+		cred.Get(detectors.CredentialPartSecret),
+		cred.Get(detectors.CredentialPartID),
+	)
+	return detectors.NewVerificationOutcome(isVerified, err, cred.Values()...)
+}
+
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		// Verifying records a deploy in the project.
+		Requests: []detectors.VerificationRequest{{Method: "POST", Host: "api.airbrake.io"}},
+	}
 }
 
 func (s Scanner) Type() detectorspb.DetectorType {
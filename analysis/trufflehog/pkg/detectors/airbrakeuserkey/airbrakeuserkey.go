@@ -74,9 +74,16 @@ func verifyAirbrake(ctx context.Context, client *http.Client, resMatch string) (
 	return false, nil
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	isVerified, _ := verifyAirbrake(ctx, client, secret)
-	return isVerified
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	isVerified, err := verifyAirbrake(ctx, client, secret)
+	return detectors.NewVerificationOutcome(isVerified, err, secret)
+}
+
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "api.airbrake.io"}},
+		ReadOnly: true,
+	}
 }
 
 func (s Scanner) Type() detectorspb.DetectorType {
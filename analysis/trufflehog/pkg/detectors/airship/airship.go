@@ -80,9 +80,16 @@ func verifyAirship(ctx context.Context, client *http.Client, secret string) (boo
 
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	isVerified, _ := verifyAirship(ctx, client, secret)
-	return isVerified
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	isVerified, err := verifyAirship(ctx, client, secret)
+	return detectors.NewVerificationOutcome(isVerified, err, secret)
+}
+
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "go.urbanairship.com"}},
+		ReadOnly: true,
+	}
 }
 
 func (s Scanner) Type() detectorspb.DetectorType {
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"strings"
 
 	regexp "github.com/wasilibs/go-re2"
 
@@ -104,6 +103,10 @@ func createResult(app string, key string, verified bool, err error) *detectors.R
 		RawV2:        []byte(fmt.Sprintf("%s%s", app, key)),
 		Verified:     verified,
 	}
+	r.SetCredential(
+		detectors.CredentialPart{Name: detectors.CredentialPartSecret, Value: key},
+		detectors.CredentialPart{Name: detectors.CredentialPartID, Value: app},
+	)
 
 	if app != "" {
 		r.RawV2 = []byte(fmt.Sprintf(`%s:%s`, app, key))
@@ -115,14 +118,22 @@ func createResult(app string, key string, verified bool, err error) *detectors.R
 	return r
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 2 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartSecret, detectors.CredentialPartID}
+}
 
-	isVerified, _ := verifyMatch(ctx, defaultClient, parts[0], parts[1])
-	return isVerified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	isVerified, err := verifyMatch(
+		ctx,
+		defaultClient,
+		cred.Get(detectors.CredentialPartID),
+		cred.Get(detectors.CredentialPartSecret),
+	)
+	return detectors.NewVerificationOutcome(isVerified, err, cred.Values()...)
 }
 
 func verifyMatch(ctx context.Context, client *http.Client, app string, key string) (bool, error) {
@@ -163,6 +174,13 @@ func verifyMatch(ctx context.Context, client *http.Client, app string, key strin
 	}
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "api.airtable.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_AirtableApiKey
 }
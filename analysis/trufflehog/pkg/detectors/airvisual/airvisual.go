@@ -79,12 +79,19 @@ func verifyAirvisual(ctx context.Context, client *http.Client, resMatch string)
 	return false, nil
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	verified, _ := verifyAirvisual(ctx, client, secret)
-	return verified
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	verified, err := verifyAirvisual(ctx, client, secret)
+	return detectors.NewVerificationOutcome(verified, err, secret)
 
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "api.airvisual.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_AirVisual
 }
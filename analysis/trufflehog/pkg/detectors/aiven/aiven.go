@@ -79,9 +79,16 @@ func verifyAiven(ctx context.Context, client *http.Client, resMatch string) (boo
 
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	verified, _ := verifyAiven(ctx, client, secret)
-	return verified
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	verified, err := verifyAiven(ctx, client, secret)
+	return detectors.NewVerificationOutcome(verified, err, secret)
+}
+
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "api.aiven.io"}},
+		ReadOnly: true,
+	}
 }
 
 func (s Scanner) Type() detectorspb.DetectorType {
@@ -65,9 +65,9 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	verified, _, _ := verifyMatch(ctx, defaultClient, secret)
-	return verified
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	verified, extraData, err := verifyMatch(ctx, defaultClient, secret)
+	return detectors.NewVerificationOutcome(verified, err, secret).WithExtraData(extraData)
 }
 
 func verifyMatch(ctx context.Context, client *http.Client, token string) (bool, map[string]string, error) {
@@ -97,6 +97,15 @@ func verifyMatch(ctx context.Context, client *http.Client, token string) (bool,
 	}
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		// Every lookup counts against the paid quota of the key.
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "eth-mainnet.g.alchemy.com"}},
+		ReadOnly: true,
+		Billable: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_Alchemy
 }
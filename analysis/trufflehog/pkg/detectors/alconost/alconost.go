@@ -62,9 +62,9 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 
 	return results, nil
 }
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	verified, _ := verifyMatch(ctx, client, secret)
-	return verified
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	verified, err := verifyMatch(ctx, client, secret)
+	return detectors.NewVerificationOutcome(verified, err, secret)
 }
 
 func verifyMatch(ctx context.Context, client *http.Client, secret string) (bool, error) {
@@ -60,6 +60,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 				Raw:          []byte(token),
 				RawV2:        []byte(token + ":" + id),
 			}
+			s1.SetCredential(
+				detectors.CredentialPart{Name: detectors.CredentialPartToken, Value: token},
+				detectors.CredentialPart{Name: detectors.CredentialPartEmail, Value: id},
+			)
 
 			if verify {
 				client := s.client
@@ -79,14 +83,22 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 2 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartToken, detectors.CredentialPartEmail}
+}
 
-	verified, _ := verifyCredentials(ctx, defaultClient, parts[0], parts[1])
-	return verified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	verified, err := verifyCredentials(
+		ctx,
+		defaultClient,
+		cred.Get(detectors.CredentialPartToken),
+		cred.Get(detectors.CredentialPartEmail),
+	)
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
 }
 
 func verifyCredentials(ctx context.Context, client *http.Client, token, username string) (bool, error) {
@@ -115,6 +127,13 @@ func verifyCredentials(ctx context.Context, client *http.Client, token, username
 	}
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "api.alegra.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_Alegra
 }
@@ -81,9 +81,18 @@ func verifyMatch(ctx context.Context, client *http.Client, resMatch string) (boo
 
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	verified, _ := verifyMatch(ctx, client, secret)
-	return verified
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	verified, err := verifyMatch(ctx, client, secret)
+	return detectors.NewVerificationOutcome(verified, err, secret)
+}
+
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		// Every lookup counts against the paid quota of the key.
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "api.aletheiaapi.com"}},
+		ReadOnly: true,
+		Billable: true,
+	}
 }
 
 func (s Scanner) Type() detectorspb.DetectorType {
@@ -65,6 +65,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 				Raw:          []byte(key),
 				RawV2:        []byte(id + key),
 			}
+			r.SetCredential(
+				detectors.CredentialPart{Name: detectors.CredentialPartSecret, Value: key},
+				detectors.CredentialPart{Name: detectors.CredentialPartID, Value: id},
+			)
 
 			if verify {
 				// Verify if the key is a valid Algolia Admin Key.
@@ -83,14 +87,21 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 2 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartSecret, detectors.CredentialPartID}
+}
 
-	verified, _, _ := verifyMatch(ctx, parts[0], parts[1])
-	return verified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	verified, extraData, err := verifyMatch(
+		ctx,
+		cred.Get(detectors.CredentialPartID),
+		cred.Get(detectors.CredentialPartSecret),
+	)
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...).WithExtraData(extraData)
 }
 
 // https://www.algolia.com/doc/guides/security/api-keys/#access-control-list-acl
@@ -163,6 +174,13 @@ type keyResponse struct {
 	Description string   `json:"description"`
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "*.algolia.net"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_AlgoliaAdminKey
 }
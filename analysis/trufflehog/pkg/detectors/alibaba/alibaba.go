@@ -113,6 +113,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 				Raw:          []byte(resMatch),
 				RawV2:        []byte(resMatch + resIdMatch),
 			}
+			s1.SetCredential(
+				detectors.CredentialPart{Name: detectors.CredentialPartSecret, Value: resMatch},
+				detectors.CredentialPart{Name: detectors.CredentialPartID, Value: resIdMatch},
+			)
 
 			if verify {
 				client := s.getClient()
@@ -128,14 +132,22 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 2 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartSecret, detectors.CredentialPartID}
+}
 
-	verified, _ := verifyAlibaba(ctx, s.getClient(), parts[0], parts[1])
-	return verified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	verified, err := verifyAlibaba(
+		ctx,
+		s.getClient(),
+		cred.Get(detectors.CredentialPartSecret),
+		cred.Get(detectors.CredentialPartID),
+	)
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
 }
 
 func verifyAlibaba(ctx context.Context, client *http.Client, resMatch, resIdMatch string) (bool, error) {
@@ -190,6 +202,13 @@ func verifyAlibaba(ctx context.Context, client *http.Client, resMatch, resIdMatc
 	}
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "ecs.aliyuncs.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_Alibaba
 }
@@ -78,9 +78,16 @@ func verifyMatch(ctx context.Context, client *http.Client, secret string) (bool,
 
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	verified, _ := verifyMatch(ctx, client, secret)
-	return verified
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	verified, err := verifyMatch(ctx, client, secret)
+	return detectors.NewVerificationOutcome(verified, err, secret)
+}
+
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "otx.alienvault.com"}},
+		ReadOnly: true,
+	}
 }
 
 func (s Scanner) Type() detectorspb.DetectorType {
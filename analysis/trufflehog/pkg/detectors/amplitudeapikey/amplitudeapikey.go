@@ -62,6 +62,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 				Raw:          []byte(resMatch),
 				RawV2:        []byte(resMatch + resSecretMatch),
 			}
+			s1.SetCredential(
+				detectors.CredentialPart{Name: detectors.CredentialPartID, Value: resMatch},
+				detectors.CredentialPart{Name: detectors.CredentialPartSecret, Value: resSecretMatch},
+			)
 
 			if verify {
 				verified, err := verifyMatch(ctx, client, resMatch, resSecretMatch)
@@ -95,14 +99,29 @@ func verifyMatch(ctx context.Context, client *http.Client, resMatch, resSecretMa
 
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 2 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartID, detectors.CredentialPartSecret}
+}
 
-	verified, _ := verifyMatch(ctx, client, parts[0], parts[1])
-	return verified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	verified, err := verifyMatch(
+		ctx,
+		client,
+		cred.Get(detectors.CredentialPartID),
+		cred.Get(detectors.CredentialPartSecret),
+	)
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
+}
+
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "amplitude.com"}},
+		ReadOnly: true,
+	}
 }
 
 func (s Scanner) Type() detectorspb.DetectorType {
@@ -73,9 +73,9 @@ type response struct {
 	} `json:"error"`
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	verified, _ := verifyToken(ctx, defaultClient, secret)
-	return verified
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	verified, err := verifyToken(ctx, defaultClient, secret)
+	return detectors.NewVerificationOutcome(verified, err, secret)
 }
 
 func verifyToken(ctx context.Context, client *http.Client, apiKey string) (bool, error) {
@@ -126,6 +126,15 @@ func verifyToken(ctx context.Context, client *http.Client, apiKey string) (bool,
 	}
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		// The message sent to the model is billed per token.
+		Requests: []detectors.VerificationRequest{{Method: "POST", Host: "api.anthropic.com"}},
+		ReadOnly: true,
+		Billable: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_Anthropic
 }
@@ -62,6 +62,15 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		// Every screenshot is billed to the key.
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "api.apiflash.com"}},
+		ReadOnly: true,
+		Billable: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_Apiflash
 }
@@ -70,9 +79,9 @@ func (s Scanner) Description() string {
 	return "Apiflash is a screenshot API service. Apiflash keys can be used to access and utilize the screenshot API service."
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	verified, _ := verifyAPIFlash(ctx, client, secret)
-	return verified
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	verified, err := verifyAPIFlash(ctx, client, secret)
+	return detectors.NewVerificationOutcome(verified, err, secret)
 }
 
 func verifyAPIFlash(ctx context.Context, client *http.Client, accessKey string) (bool, error) {
@@ -68,6 +68,11 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 					Raw:          []byte(resMatch),
 					RawV2:        []byte(resMatch + ";-|" + resUserMatch + ";-|" + resIdMatch),
 				}
+				s1.SetCredential(
+					detectors.CredentialPart{Name: detectors.CredentialPartSecret, Value: resMatch},
+					detectors.CredentialPart{Name: detectors.CredentialPartUsername, Value: resUserMatch},
+					detectors.CredentialPart{Name: detectors.CredentialPartID, Value: resIdMatch},
+				)
 				if verify {
 					verified, err := verifyMatch(ctx, client, resMatch, resUserMatch, resIdMatch)
 					if err != nil {
@@ -101,15 +106,30 @@ func verifyMatch(ctx context.Context, client *http.Client, resMatch, resUserMatc
 
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 3 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartSecret, detectors.CredentialPartUsername, detectors.CredentialPartID}
+}
 
-	verified, _ := verifyMatch(ctx, client, parts[0], parts[1], parts[3])
-	return verified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	verified, err := verifyMatch(
+		ctx,
+		client,
+		cred.Get(detectors.CredentialPartSecret),
+		cred.Get(detectors.CredentialPartUsername),
+		cred.Get(detectors.CredentialPartID),
+	)
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
+}
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "api.appcues.com"}},
+		ReadOnly: true,
+	}
 }
 
 func (s Scanner) Type() detectorspb.DetectorType {
@@ -79,9 +79,18 @@ func verifyMatch(ctx context.Context, client *http.Client, secret string) (bool,
 	return false, nil
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	verified, _ := verifyMatch(ctx, client, secret)
-	return verified
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	verified, err := verifyMatch(ctx, client, secret)
+	return detectors.NewVerificationOutcome(verified, err, secret)
+}
+
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		// Every conversion is billed to the key.
+		Requests: []detectors.VerificationRequest{{Method: "POST", Host: "www.appsynergy.com"}},
+		ReadOnly: true,
+		Billable: true,
+	}
 }
 
 func (s Scanner) Type() detectorspb.DetectorType {
@@ -72,6 +72,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 				Raw:          []byte(resMatch),
 				RawV2:        []byte(resMatch + ";-|" + URL),
 			}
+			s1.SetCredential(
+				detectors.CredentialPart{Name: detectors.CredentialPartToken, Value: resMatch},
+				detectors.CredentialPart{Name: detectors.CredentialPartEndpoint, Value: URL},
+			)
 
 			if verify {
 				isVerified, verificationErr := verifyArtifactory(ctx, client, URL, resMatch)
@@ -87,14 +91,22 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 2 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartToken, detectors.CredentialPartEndpoint}
+}
 
-	verified, _ := verifyArtifactory(ctx, defaultClient, parts[0], parts[1])
-	return verified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	verified, err := verifyArtifactory(
+		ctx,
+		defaultClient,
+		cred.Get(detectors.CredentialPartEndpoint),
+		cred.Get(detectors.CredentialPartToken),
+	)
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
 }
 
 func verifyArtifactory(ctx context.Context, client *http.Client, resURLMatch, resMatch string) (bool, error) {
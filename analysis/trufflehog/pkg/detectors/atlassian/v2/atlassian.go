@@ -88,9 +88,9 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	verified, _, _ := verifyMatch(ctx, defaultClient, secret)
-	return verified
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	verified, _, err := verifyMatch(ctx, defaultClient, secret)
+	return detectors.NewVerificationOutcome(verified, err, secret)
 }
 
 func verifyMatch(ctx context.Context, client *http.Client, token string) (bool, *OrgRes, error) {
@@ -125,6 +125,13 @@ func verifyMatch(ctx context.Context, client *http.Client, token string) (bool,
 	}
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "api.atlassian.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_Atlassian
 }
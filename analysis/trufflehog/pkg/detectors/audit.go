@@ -0,0 +1,193 @@
+package detectors
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HashSecret returns the hex-encoded SHA-256 digest of secret. It identifies a
+// secret in the verification audit log and the verification cache without
+// storing the secret itself.
+func HashSecret(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
+
+// AuditEntry records a request sent to verify a secret. It never contains the
+// secret itself.
+type AuditEntry struct {
+	Time     time.Time `json:"time"`
+	Detector string    `json:"detector"`
+	// SecretHash is the HashSecret of the secret the request verified, in the
+	// form returned by Result.VerificationSecret. It is empty if the request
+	// couldn't be attributed to one of the secrets being verified.
+	SecretHash string `json:"secret_hash,omitempty"`
+	Method     string `json:"method"`
+	Host       string `json:"host"`
+	// Status is the HTTP status code of the response, or 0 if there was none.
+	Status    int    `json:"status,omitempty"`
+	LatencyMS int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// AuditLog writes the requests sent to verify secrets as JSON lines. It is safe
+// for concurrent use.
+type AuditLog struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	err error
+}
+
+// NewAuditLog creates an AuditLog that writes to w.
+func NewAuditLog(w io.Writer) *AuditLog {
+	return &AuditLog{enc: json.NewEncoder(w)}
+}
+
+// Record writes e to the log. Entries are dropped after the first write error,
+// which is returned by Err.
+func (l *AuditLog) Record(e AuditEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.err != nil {
+		return
+	}
+	l.err = l.enc.Encode(e)
+}
+
+// Err returns the first error writing to the log, if any.
+func (l *AuditLog) Err() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.err
+}
+
+// AuditSecret is a secret whose verification requests are recorded in an
+// AuditLog.
+type AuditSecret struct {
+	hash string
+	// values are the forms of the secret that may appear in its requests.
+	// They attribute requests to the secret when several secrets are verified
+	// with the same context.
+	values []string
+}
+
+// NewAuditSecret returns the AuditSecret for secret. Parts are other forms of
+// the secret that its requests may contain, such as the parts of a multi-part
+// credential.
+func NewAuditSecret(secret string, parts ...string) AuditSecret {
+	return AuditSecret{
+		hash:   HashSecret(secret),
+		values: append([]string{secret}, parts...),
+	}
+}
+
+// ResultAuditSecret returns the AuditSecret for a result found with
+// Detector.FromData.
+func ResultAuditSecret(r *Result) AuditSecret {
+	parts := []string{string(r.Raw), string(r.RawV2)}
+	if r.Credential != nil {
+		parts = append(parts, r.Credential.Values()...)
+	}
+	return NewAuditSecret(r.VerificationSecret(), parts...)
+}
+
+type auditScopeKey struct{}
+
+type auditScope struct {
+	log      *AuditLog
+	detector string
+	secrets  []AuditSecret
+}
+
+// WithAuditLog returns a copy of ctx whose verification requests are recorded
+// in log as sent by detector to verify one of secrets. It returns ctx if log is
+// nil.
+func WithAuditLog(ctx context.Context, log *AuditLog, detector string, secrets ...AuditSecret) context.Context {
+	if log == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, auditScopeKey{}, &auditScope{log: log, detector: detector, secrets: secrets})
+}
+
+// recordAudit records a request sent at start in the audit log of its context,
+// if any.
+func recordAudit(req *http.Request, res *http.Response, err error, start time.Time) {
+	scope, ok := req.Context().Value(auditScopeKey{}).(*auditScope)
+	if !ok {
+		return
+	}
+	e := AuditEntry{
+		Time:       start.UTC(),
+		Detector:   scope.detector,
+		SecretHash: scope.secretHash(req),
+		Method:     req.Method,
+		Host:       req.URL.Host,
+		LatencyMS:  time.Since(start).Milliseconds(),
+	}
+	if res != nil {
+		e.Status = res.StatusCode
+	}
+	if err != nil {
+		e.Error = scope.redact(err.Error())
+	}
+	scope.log.Record(e)
+}
+
+// secretHash returns the hash of the secret that req verifies. With several
+// secrets in scope, it is the one whose value appears in the request.
+func (s *auditScope) secretHash(req *http.Request) string {
+	if len(s.secrets) == 1 {
+		return s.secrets[0].hash
+	}
+
+	fields := []string{req.URL.String()}
+	for _, values := range req.Header {
+		fields = append(fields, values...)
+	}
+	if user, pass, ok := req.BasicAuth(); ok {
+		fields = append(fields, user, pass)
+	}
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(body)
+			_ = body.Close()
+			fields = append(fields, string(data))
+		}
+	}
+
+	// The longest match wins, in case one secret contains another.
+	hash, longest := "", 0
+	for _, secret := range s.secrets {
+		for _, v := range secret.values {
+			if len(v) <= longest {
+				continue
+			}
+			for _, f := range fields {
+				if strings.Contains(f, v) {
+					hash, longest = secret.hash, len(v)
+					break
+				}
+			}
+		}
+	}
+	return hash
+}
+
+// redact removes the secrets in scope from str.
+func (s *auditScope) redact(str string) string {
+	for _, secret := range s.secrets {
+		for _, v := range secret.values {
+			if v != "" {
+				str = strings.ReplaceAll(str, v, "[REDACTED]")
+			}
+		}
+	}
+	return str
+}
@@ -0,0 +1,127 @@
+package detectors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+)
+
+func TestAuditLog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "Bearer valid-token" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	log := NewAuditLog(&buf)
+	client := NewDetectorHttpClient()
+
+	// Requests are attributed to the secret they contain.
+	ctx := WithAuditLog(context.Background(), log, "Test",
+		NewAuditSecret("valid-token"), NewAuditSecret("id:invalid-token", "invalid-token"))
+	for _, token := range []string{"valid-token", "invalid-token"} {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		res, err := client.Do(req)
+		require.NoError(t, err)
+		_ = res.Body.Close()
+	}
+
+	// Requests without an audit scope aren't recorded.
+	res, err := client.Get(server.URL)
+	require.NoError(t, err)
+	_ = res.Body.Close()
+
+	require.NoError(t, log.Err())
+	assert.NotContains(t, buf.String(), "token")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	var entries [2]AuditEntry
+	for i, line := range lines {
+		require.NoError(t, json.Unmarshal([]byte(line), &entries[i]))
+		assert.Equal(t, "Test", entries[i].Detector)
+		assert.Equal(t, http.MethodGet, entries[i].Method)
+		assert.Equal(t, serverURL.Host, entries[i].Host)
+		assert.False(t, entries[i].Time.IsZero())
+	}
+	assert.Equal(t, HashSecret("valid-token"), entries[0].SecretHash)
+	assert.Equal(t, http.StatusOK, entries[0].Status)
+	assert.Equal(t, HashSecret("id:invalid-token"), entries[1].SecretHash)
+	assert.Equal(t, http.StatusUnauthorized, entries[1].Status)
+}
+
+func TestAuditLog_Error(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewAuditLog(&buf)
+	client := NewDetectorHttpClient()
+
+	// The secret is redacted from errors.
+	ctx := WithAuditLog(context.Background(), log, "Test", NewAuditSecret("secret-token"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://127.0.0.1:1/?key=secret-token", nil)
+	require.NoError(t, err)
+	_, err = client.Do(req)
+	require.Error(t, err)
+
+	var entry AuditEntry
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, HashSecret("secret-token"), entry.SecretHash)
+	assert.Zero(t, entry.Status)
+	assert.NotEmpty(t, entry.Error)
+	assert.NotContains(t, buf.String(), "secret-token")
+}
+
+func TestAuditLog_CommonClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	log := NewAuditLog(&buf)
+	get := func(client *http.Client) {
+		ctx := WithAuditLog(context.Background(), log, "Test", NewAuditSecret("secret-token"))
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		res, err := client.Do(req)
+		require.NoError(t, err)
+		_ = res.Body.Close()
+	}
+
+	// Clients of the common package only record their requests once the hook
+	// is installed.
+	get(common.SaneHttpClient())
+	assert.Empty(t, buf.String())
+
+	installCommonClientHook(t)
+	for _, client := range []*http.Client{common.SaneHttpClient(), common.RetryableHTTPClient()} {
+		get(client)
+	}
+
+	require.NoError(t, log.Err())
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	for _, line := range lines {
+		var entry AuditEntry
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		assert.Equal(t, HashSecret("secret-token"), entry.SecretHash)
+		assert.Equal(t, http.StatusForbidden, entry.Status)
+	}
+}
@@ -65,6 +65,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 				Raw:          []byte(managementAPITokenRes),
 				RawV2:        []byte(managementAPITokenRes + ";-|" + domainRes),
 			}
+			s1.SetCredential(
+				detectors.CredentialPart{Name: detectors.CredentialPartToken, Value: managementAPITokenRes},
+				detectors.CredentialPart{Name: detectors.CredentialPartDomain, Value: domainRes},
+			)
 
 			if verify {
 				/*
@@ -102,14 +106,29 @@ func verifyMatch(ctx context.Context, client *http.Client, managementAPITokenRes
 	return false, nil
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 2 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartToken, detectors.CredentialPartDomain}
+}
 
-	verified, _ := verifyMatch(ctx, client, parts[0], parts[1])
-	return verified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	verified, err := verifyMatch(
+		ctx,
+		client,
+		cred.Get(detectors.CredentialPartToken),
+		cred.Get(detectors.CredentialPartDomain),
+	)
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
+}
+
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "*.auth0.com"}},
+		ReadOnly: true,
+	}
 }
 
 func (s Scanner) Type() detectorspb.DetectorType {
@@ -66,6 +66,11 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 					Raw:          []byte(clientSecretRes),
 					RawV2:        []byte(clientIdRes + ";-|" + clientSecretRes + ";-|" + domainRes),
 				}
+				s1.SetCredential(
+					detectors.CredentialPart{Name: detectors.CredentialPartID, Value: clientIdRes},
+					detectors.CredentialPart{Name: detectors.CredentialPartSecret, Value: clientSecretRes},
+					detectors.CredentialPart{Name: detectors.CredentialPartDomain, Value: domainRes},
+				)
 
 				if verify {
 					/*
@@ -124,14 +129,23 @@ func verifyMatch(ctx context.Context, client *http.Client, clientIdRes, clientSe
 	return false, nil
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 3 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartID, detectors.CredentialPartSecret, detectors.CredentialPartDomain}
+}
 
-	verified, _ := verifyMatch(ctx, client, parts[0], parts[1], parts[2])
-	return verified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	verified, err := verifyMatch(
+		ctx,
+		client,
+		cred.Get(detectors.CredentialPartID),
+		cred.Get(detectors.CredentialPartSecret),
+		cred.Get(detectors.CredentialPartDomain),
+	)
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
 }
 
 func (s Scanner) Type() detectorspb.DetectorType {
@@ -55,8 +55,12 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 			s1 := detectors.Result{
 				DetectorType: detectorspb.DetectorType_Autodesk,
 				Raw:          []byte(resMatch),
-				RawV2:        []byte(resMatch + ";-|" + resSecret),
+				RawV2:        []byte(resMatch + detectors.CredentialSeparator + resSecret),
 			}
+			s1.SetCredential(
+				detectors.CredentialPart{Name: detectors.CredentialPartID, Value: resMatch},
+				detectors.CredentialPart{Name: detectors.CredentialPartSecret, Value: resSecret},
+			)
 
 			if verify {
 				verified, err := verifyMatch(ctx, client, resMatch, resSecret)
@@ -89,14 +93,25 @@ func verifyMatch(ctx context.Context, client *http.Client, resMatch, resSecret s
 	return false, nil
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 2 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartID, detectors.CredentialPartSecret}
+}
 
-	verified, _ := verifyMatch(ctx, client, parts[0], parts[1])
-	return verified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	verified, err := verifyMatch(ctx, client, cred.Get(detectors.CredentialPartID), cred.Get(detectors.CredentialPartSecret))
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
+}
+
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		// Authenticating only issues a short-lived access token.
+		Requests: []detectors.VerificationRequest{{Method: "POST", Host: "developer.api.autodesk.com"}},
+		ReadOnly: true,
+	}
 }
 
 func (s Scanner) Type() detectorspb.DetectorType {
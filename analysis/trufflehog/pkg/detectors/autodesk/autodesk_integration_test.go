@@ -96,6 +96,7 @@ func TestAutodesk_FromChunk(t *testing.T) {
 					t.Fatalf("no raw secret present: \n %+v", got[i])
 				}
 				got[i].Raw = nil
+				got[i].Credential = nil
 			}
 			if diff := pretty.Compare(got, tt.want); diff != "" {
 				t.Errorf("Autodesk.FromData() %s diff: (-got +want)\n%s", tt.name, diff)
@@ -102,9 +102,16 @@ func verifyMatch(ctx context.Context, client *http.Client, resMatch string) (boo
 
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	verified, _, _ := verifyMatch(ctx, client, secret)
-	return verified
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	verified, err, extraData := verifyMatch(ctx, client, secret)
+	return detectors.NewVerificationOutcome(verified, err, secret).WithExtraData(extraData)
+}
+
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "api.autoklose.com"}},
+		ReadOnly: true,
+	}
 }
 
 func (s Scanner) Type() detectorspb.DetectorType {
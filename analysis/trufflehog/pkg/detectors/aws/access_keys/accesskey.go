@@ -116,6 +116,10 @@ func (s scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 					"resource_type": aws.ResourceTypes[idMatch[:4]],
 				},
 			}
+			s1.SetCredential(
+				detectors.CredentialPart{Name: detectors.CredentialPartID, Value: idMatch},
+				detectors.CredentialPart{Name: detectors.CredentialPartSecret, Value: secretMatch},
+			)
 
 			// Decode the account ID.
 			account, err := aws.GetAccountNumFromID(idMatch)
@@ -130,7 +134,7 @@ func (s scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 					isCanary = true
 					s1.ExtraData["message"] = thinkstMessage
 					if verify {
-						verified, arn, err := s.verifyCanary(idMatch, secretMatch)
+						verified, arn, err := s.verifyCanary(ctx, idMatch, secretMatch)
 						s1.Verified = verified
 						if arn != "" {
 							s1.ExtraData["arn"] = arn
@@ -142,7 +146,7 @@ func (s scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 					isCanary = true
 					s1.ExtraData["message"] = thinkstKnockoffsMessage
 					if verify {
-						verified, arn, err := s.verifyCanary(idMatch, secretMatch)
+						verified, arn, err := s.verifyCanary(ctx, idMatch, secretMatch)
 						s1.Verified = verified
 						if arn != "" {
 							s1.ExtraData["arn"] = arn
@@ -315,25 +319,36 @@ func (s scanner) verifyMatch(ctx context.Context, resIDMatch, resSecretMatch str
 	}
 }
 
-func (s scanner) Verify(ctx context.Context, secret string) bool {
-	fmt.Println("Verifying AWS secret")
-
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 2 {
-		return false
-	}
+func (s scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
 
-	fmt.Printf("Verifying AWS secret: %s %s\n", parts[0], parts[1])
+func (s scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartID, detectors.CredentialPartSecret}
+}
 
-	verified, details, _ := s.verifyMatch(ctx, parts[0], parts[1], true)
-	fmt.Printf("Verified: %t\n", details)
-	return verified
+func (s scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	id, secret := cred.Get(detectors.CredentialPartID), cred.Get(detectors.CredentialPartSecret)
+	verified, extraData, err := s.verifyMatch(ctx, id, secret, true)
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...).WithExtraData(extraData)
 }
 
 func (s scanner) CleanResults(results []detectors.Result) []detectors.Result {
 	return aws.CleanResults(results)
 }
 
+func (s scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		// Canary keys are verified by publishing an SMS to SNS. Canary keys
+		// aren't allowed to publish, and the error identifies the key's owner,
+		// but the request is a write.
+		Requests: []detectors.VerificationRequest{
+			{Method: "GET", Host: "sts.amazonaws.com"},
+			{Method: "POST", Host: "sns.us-east-1.amazonaws.com"},
+		},
+	}
+}
+
 func (s scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_AWS
 }
@@ -42,7 +42,7 @@ func TestAWS_FromChunk_InvalidValidReuseIDSequence(t *testing.T) {
 
 	d := scanner{}
 
-	ignoreOpts := []cmp.Option{cmpopts.IgnoreFields(detectors.Result{}, "RawV2", "Raw", "verificationError")}
+	ignoreOpts := []cmp.Option{cmpopts.IgnoreFields(detectors.Result{}, "RawV2", "Raw", "Credential", "verificationError")}
 
 	got, err := d.FromData(ctx, true, []byte(fmt.Sprintf("aws %s %s", id, inactiveSecret)))
 	if assert.NoError(t, err) {
@@ -420,7 +420,7 @@ func TestAWS_FromChunk(t *testing.T) {
 				}
 			}
 			ignoreOpts := []cmp.Option{
-				cmpopts.IgnoreFields(detectors.Result{}, "RawV2", "Raw", "verificationError"),
+				cmpopts.IgnoreFields(detectors.Result{}, "RawV2", "Raw", "Credential", "verificationError"),
 				cmpopts.SortSlices(func(x, y detectors.Result) bool {
 					return x.Redacted < y.Redacted
 				}),
@@ -1,6 +1,7 @@
 package access_keys
 
 import (
+	"context"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -44,7 +45,12 @@ var (
 	}
 )
 
-func (s scanner) verifyCanary(resIDMatch, resSecretMatch string) (bool, string, error) {
+func (s scanner) verifyCanary(ctx context.Context, resIDMatch, resSecretMatch string) (bool, string, error) {
+	client := s.verificationClient
+	if client == nil {
+		client = defaultVerificationClient
+	}
+
 	// Prep AWS Creds for SNS
 	sess := session.Must(session.NewSession(&aws.Config{
 		Region: aws.String("us-east-1"), // any region seems to work
@@ -53,12 +59,12 @@ func (s scanner) verifyCanary(resIDMatch, resSecretMatch string) (bool, string,
 			resSecretMatch,
 			"",
 		),
-		HTTPClient: s.verificationClient,
+		HTTPClient: client,
 	}))
 	svc := sns.New(sess)
 
 	// Prep vars and Publish to SNS
-	_, err := svc.Publish(&sns.PublishInput{
+	_, err := svc.PublishWithContext(ctx, &sns.PublishInput{
 		Message:     aws.String("foo"),
 		PhoneNumber: aws.String("1"),
 	})
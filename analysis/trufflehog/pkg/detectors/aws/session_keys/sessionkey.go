@@ -119,6 +119,11 @@ func (s scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 					Redacted:     idMatch,
 					ExtraData:    make(map[string]string),
 				}
+				s1.SetCredential(
+					detectors.CredentialPart{Name: detectors.CredentialPartID, Value: idMatch},
+					detectors.CredentialPart{Name: detectors.CredentialPartSecret, Value: secretMatch},
+					detectors.CredentialPart{Name: detectors.CredentialPartToken, Value: sessionMatch},
+				)
 
 				if verify {
 					isVerified, extraData, verificationErr := s.verifyMatch(ctx, idMatch, secretMatch, sessionMatch, true)
@@ -156,14 +161,22 @@ func (s scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
-func (s scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 3 {
-		return false
-	}
+func (s scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartID, detectors.CredentialPartSecret, detectors.CredentialPartToken}
+}
 
-	verified, _, _ := s.verifyMatch(ctx, parts[0], parts[1], parts[2], true)
-	return verified
+func (s scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	verified, extraData, err := s.verifyMatch(ctx,
+		cred.Get(detectors.CredentialPartID),
+		cred.Get(detectors.CredentialPartSecret),
+		cred.Get(detectors.CredentialPartToken),
+		true,
+	)
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...).WithExtraData(extraData)
 }
 
 func (s scanner) ShouldCleanResultsIrrespectiveOfConfiguration() bool {
@@ -307,6 +320,13 @@ func checkSessionToken(sessionToken string, secret string) bool {
 	return true
 }
 
+func (s scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "sts.amazonaws.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_AWSSessionKey
 }
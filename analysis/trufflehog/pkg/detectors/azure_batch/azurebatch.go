@@ -59,6 +59,11 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 				RawV2:        []byte(endpoint + ";-|" + accountKey + ";-|" + accountName),
 				Redacted:     endpoint,
 			}
+			s1.SetCredential(
+				detectors.CredentialPart{Name: detectors.CredentialPartEndpoint, Value: endpoint},
+				detectors.CredentialPart{Name: detectors.CredentialPartSecret, Value: accountKey},
+				detectors.CredentialPart{Name: detectors.CredentialPartAccount, Value: accountName},
+			)
 
 			if verify {
 				client := s.client
@@ -115,20 +120,36 @@ func verifyMatch(ctx context.Context, client *http.Client, endpoint, accountKey,
 
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 3 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartEndpoint, detectors.CredentialPartSecret, detectors.CredentialPartAccount}
+}
 
-	verified, _ := verifyMatch(ctx, defaultClient, parts[0], parts[1], parts[2])
-	return verified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	verified, err := verifyMatch(
+		ctx,
+		defaultClient,
+		cred.Get(detectors.CredentialPartEndpoint),
+		cred.Get(detectors.CredentialPartSecret),
+		cred.Get(detectors.CredentialPartAccount),
+	)
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
 }
 
 func (s Scanner) IsFalsePositive(_ detectors.Result) (bool, string) {
 	return false, ""
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "*.batch.azure.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_AzureBatch
 }
@@ -3,7 +3,6 @@ package v1
 import (
 	"context"
 	"net/http"
-	"strings"
 
 	regexp "github.com/wasilibs/go-re2"
 
@@ -69,6 +68,13 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "POST", Host: "login.microsoftonline.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_Azure
 }
@@ -90,13 +96,23 @@ func findSecretMatches(data string) map[string]struct{} {
 	return uniqueMatches
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 3 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+// CredentialParts matches v2, which finds the credentials of both versions.
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartTenant, detectors.CredentialPartID, detectors.CredentialPartSecret}
+}
 
-	isVerified, _, _ := serviceprincipal.VerifyCredentials(ctx, defaultClient, parts[0], parts[1], parts[2])
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	isVerified, extraData, err := serviceprincipal.VerifyCredentials(
+		ctx,
+		defaultClient,
+		cred.Get(detectors.CredentialPartTenant),
+		cred.Get(detectors.CredentialPartID),
+		cred.Get(detectors.CredentialPartSecret),
+	)
 
-	return isVerified
+	return detectors.NewVerificationOutcome(isVerified, err, cred.Values()...).WithExtraData(extraData)
 }
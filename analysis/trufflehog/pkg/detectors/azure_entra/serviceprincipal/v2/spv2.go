@@ -5,7 +5,6 @@ import (
 	"errors"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
@@ -42,6 +41,13 @@ func (s Scanner) Keywords() []string {
 	return []string{"q~"}
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "POST", Host: "login.microsoftonline.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_Azure
 }
@@ -153,15 +159,24 @@ SecretLoop:
 	return results
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 3 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartTenant, detectors.CredentialPartID, detectors.CredentialPartSecret}
+}
 
-	isVerified, _, _ := serviceprincipal.VerifyCredentials(ctx, defaultClient, parts[0], parts[1], parts[2])
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	isVerified, extraData, err := serviceprincipal.VerifyCredentials(
+		ctx,
+		defaultClient,
+		cred.Get(detectors.CredentialPartTenant),
+		cred.Get(detectors.CredentialPartID),
+		cred.Get(detectors.CredentialPartSecret),
+	)
 
-	return isVerified
+	return detectors.NewVerificationOutcome(isVerified, err, cred.Values()...).WithExtraData(extraData)
 }
 
 func createResult(tenantId string, clientId string, clientSecret string, verified bool, extraData map[string]string, err error) *detectors.Result {
@@ -173,6 +188,11 @@ func createResult(tenantId string, clientId string, clientSecret string, verifie
 		//Redacted:     clientSecret[:5] + "...",
 		RawV2: []byte(tenantId + ";-|" + clientId + ";-|" + clientSecret),
 	}
+	r.SetCredential(
+		detectors.CredentialPart{Name: detectors.CredentialPartTenant, Value: tenantId},
+		detectors.CredentialPart{Name: detectors.CredentialPartID, Value: clientId},
+		detectors.CredentialPart{Name: detectors.CredentialPartSecret, Value: clientSecret},
+	)
 	r.SetVerificationError(err, clientSecret)
 
 	// Tenant ID is required for verification, but it may not always be present.
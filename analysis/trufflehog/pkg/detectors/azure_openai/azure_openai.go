@@ -3,6 +3,7 @@ package azure_openai
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -42,6 +43,13 @@ func (s Scanner) Keywords() []string {
 	return []string{".openai.azure.com"}
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "*.openai.azure.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_AzureOpenAI
 }
@@ -106,27 +114,42 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 			}
 		}
 		s1.RawV2 = []byte(token + ";-|" + url_string)
+		s1.SetCredential(
+			detectors.CredentialPart{Name: detectors.CredentialPartToken, Value: token},
+			detectors.CredentialPart{Name: detectors.CredentialPartEndpoint, Value: url_string},
+		)
 
 		results = append(results, s1)
 	}
 	return
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+// CredentialParts returns the parts of a credential. Its endpoint is the list
+// of all service URLs found with the token, separated by commas.
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartToken, detectors.CredentialPartEndpoint}
+}
+
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
 	logCtx := logContext.AddLogger(ctx)
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 2 {
-		return false
-	}
-	urls := strings.Split(parts[1], ",")
+	token := cred.Get(detectors.CredentialPartToken)
+	var errs []error
+	urls := strings.Split(cred.Get(detectors.CredentialPartEndpoint), ",")
 	for _, url := range urls {
-		verified, _, _ := verifyAzureToken(logCtx, common.SaneHttpClient(), url, parts[0])
+		verified, extraData, err := verifyAzureToken(logCtx, common.SaneHttpClient(), url, token)
 		if verified {
-			return true
+			return detectors.NewVerificationOutcome(true, nil).WithExtraData(extraData)
+		}
+		if err != nil {
+			errs = append(errs, err)
 		}
 	}
 
-	return false
+	return detectors.NewVerificationOutcome(false, errors.Join(errs...), token)
 }
 
 func verifyAzureToken(ctx logContext.Context, client *http.Client, baseUrl, token string) (bool, map[string]string, error) {
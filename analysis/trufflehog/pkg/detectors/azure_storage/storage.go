@@ -50,6 +50,13 @@ func (s Scanner) Keywords() []string {
 	}
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "*.blob.core.windows.net"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_AzureStorage
 }
@@ -103,6 +110,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 					"Account_name": name,
 				},
 			}
+			s1.SetCredential(
+				detectors.CredentialPart{Name: detectors.CredentialPartSecret, Value: key},
+				detectors.CredentialPart{Name: detectors.CredentialPartAccount, Value: name},
+			)
 
 			if verify {
 				client := s.client
@@ -125,16 +136,26 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 2 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartSecret, detectors.CredentialPartAccount}
+}
+
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
 	extraData := map[string]string{
-		"Account_name": parts[0],
+		"Account_name": cred.Get(detectors.CredentialPartAccount),
 	}
-	isVerified, _ := s.verifyMatch(ctx, defaultClient, parts[0], parts[1], extraData)
-	return isVerified
+	isVerified, err := s.verifyMatch(
+		ctx,
+		defaultClient,
+		cred.Get(detectors.CredentialPartAccount),
+		cred.Get(detectors.CredentialPartSecret),
+		extraData,
+	)
+	return detectors.NewVerificationOutcome(isVerified, err, cred.Values()...)
 }
 
 type storageResponse struct {
@@ -41,6 +41,13 @@ func (s Scanner) Keywords() []string {
 	return []string{".azurecr.io"}
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "*.azurecr.io"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_AzureContainerRegistry
 }
@@ -82,6 +89,10 @@ EndpointLoop:
 				RawV2:        []byte(username + ";-|" + password),
 				Redacted:     username,
 			}
+			r.SetCredential(
+				detectors.CredentialPart{Name: detectors.CredentialPartUsername, Value: username},
+				detectors.CredentialPart{Name: detectors.CredentialPartPassword, Value: password},
+			)
 
 			if verify {
 				if invalidHosts.Exists(username) {
@@ -155,12 +166,20 @@ func verifyMatch(ctx context.Context, client *http.Client, username string, pass
 	}
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 2 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartUsername, detectors.CredentialPartPassword}
+}
 
-	verified, _ := verifyMatch(ctx, defaultClient, parts[0], parts[1])
-	return verified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	verified, err := verifyMatch(
+		ctx,
+		defaultClient,
+		cred.Get(detectors.CredentialPartUsername),
+		cred.Get(detectors.CredentialPartPassword),
+	)
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
 }
@@ -57,6 +57,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 				Raw:          []byte(resMatch),
 				RawV2:        []byte(resMatch + ";-|" + resOrgMatch),
 			}
+			s1.SetCredential(
+				detectors.CredentialPart{Name: detectors.CredentialPartToken, Value: resMatch},
+				detectors.CredentialPart{Name: detectors.CredentialPartAccount, Value: resOrgMatch},
+			)
 
 			if verify {
 				client := s.client
@@ -102,6 +106,13 @@ func verifyMatch(ctx context.Context, client *http.Client, resMatch, resOrgMatch
 	return false, nil
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "dev.azure.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_AzureDevopsPersonalAccessToken
 }
@@ -110,12 +121,20 @@ func (s Scanner) Description() string {
 	return "Azure DevOps is a suite of development tools provided by Microsoft. Personal Access Tokens (PATs) are used to authenticate and authorize access to Azure DevOps services and resources."
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 2 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartToken, detectors.CredentialPartAccount}
+}
 
-	verified, _ := verifyMatch(ctx, defaultClient, parts[0], parts[1])
-	return verified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	verified, err := verifyMatch(
+		ctx,
+		defaultClient,
+		cred.Get(detectors.CredentialPartToken),
+		cred.Get(detectors.CredentialPartAccount),
+	)
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
 }
@@ -48,6 +48,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 				Raw:          []byte(resMatch),
 				RawV2:        []byte(resMatch + ";-|" + resUrl),
 			}
+			s1.SetCredential(
+				detectors.CredentialPart{Name: detectors.CredentialPartToken, Value: resMatch},
+				detectors.CredentialPart{Name: detectors.CredentialPartEndpoint, Value: resUrl},
+			)
 
 			if verify {
 				client := s.client
@@ -98,12 +102,20 @@ func (s Scanner) Description() string {
 	return "Azure Functions is a serverless compute service that lets you run event-triggered code without having to explicitly provision or manage infrastructure. Azure Function Keys can be used to access and manage these functions."
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 2 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartToken, detectors.CredentialPartEndpoint}
+}
 
-	verified, _ := verifyMatch(ctx, defaultClient, parts[0], parts[1])
-	return verified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	verified, err := verifyMatch(
+		ctx,
+		defaultClient,
+		cred.Get(detectors.CredentialPartToken),
+		cred.Get(detectors.CredentialPartEndpoint),
+	)
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
 }
@@ -2,6 +2,7 @@ package bitfinex
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"net/http"
 	"strings"
@@ -78,6 +79,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 			}
 		}
 		s1.RawV2 = []byte(apiKeyRes + ";-|" + seretMatchString)
+		s1.SetCredential(
+			detectors.CredentialPart{Name: detectors.CredentialPartID, Value: apiKeyRes},
+			detectors.CredentialPart{Name: detectors.CredentialPartSecret, Value: seretMatchString},
+		)
 
 		// By appending results in the outer loop we can reduce false positives if there are multiple
 		// combinations of secrets and IDs found.
@@ -112,20 +117,39 @@ func verifyMatch(ctx context.Context, client *http.Client, apiKeyRes, apiSecretR
 	return false, nil
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 2 {
-		return false
-	}
-	for _, pvalue := range strings.Split(parts[1], ",") {
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+// CredentialParts returns the parts of a credential. Its secret is the list of
+// all candidate secrets found with the API key, separated by commas.
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartID, detectors.CredentialPartSecret}
+}
+
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	apiKey := cred.Get(detectors.CredentialPartID)
+	var errs []error
+	pvalues := strings.Split(cred.Get(detectors.CredentialPartSecret), ",")
+	for _, pvalue := range pvalues {
 
-		verified, _ := verifyMatch(ctx, client, parts[0], pvalue)
+		verified, err := verifyMatch(ctx, client, apiKey, pvalue)
 		if verified {
-			return verified
+			return detectors.NewVerificationOutcome(true, nil)
+		}
+		if err != nil {
+			errs = append(errs, err)
 		}
 	}
-	return false
+	return detectors.NewVerificationOutcome(false, errors.Join(errs...), append(pvalues, apiKey)...)
+
+}
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "POST", Host: "api-pub.bitfinex.com"}},
+		ReadOnly: true,
+	}
 }
 
 func (s Scanner) Type() detectorspb.DetectorType {
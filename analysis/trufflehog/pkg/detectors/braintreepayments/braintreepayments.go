@@ -65,6 +65,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 				Raw:          []byte(resMatch),
 				RawV2:        []byte(resMatch + ";-|" + resIdMatch),
 			}
+			s1.SetCredential(
+				detectors.CredentialPart{Name: detectors.CredentialPartSecret, Value: resMatch},
+				detectors.CredentialPart{Name: detectors.CredentialPartID, Value: resIdMatch},
+			)
 
 			if verify {
 				client := s.getClient()
@@ -81,15 +85,18 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	url := s.getBraintreeURL()
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 2 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
 
-	verified, _ := verifyBraintree(ctx, s.getClient(), url, parts[0], parts[1])
-	return verified
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartSecret, detectors.CredentialPartID}
+}
+
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	url := s.getBraintreeURL()
+	verified, err := verifyBraintree(ctx, s.getClient(), url, cred.Get(detectors.CredentialPartSecret), cred.Get(detectors.CredentialPartID))
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
 }
 
 func (s Scanner) getBraintreeURL() string {
@@ -139,6 +146,13 @@ func verifyBraintree(ctx context.Context, client *http.Client, url, privKey, pub
 	return false, nil
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "POST", Host: "payments.braintree-api.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_BraintreePayments
 }
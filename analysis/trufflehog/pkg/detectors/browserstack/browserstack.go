@@ -72,6 +72,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 				Raw:          []byte(resMatch),
 				RawV2:        []byte(resMatch + resUserMatch),
 			}
+			s1.SetCredential(
+				detectors.CredentialPart{Name: detectors.CredentialPartToken, Value: resMatch},
+				detectors.CredentialPart{Name: detectors.CredentialPartUsername, Value: resUserMatch},
+			)
 
 			if verify {
 				// browserstack (via cloudflare) requires cookies to be enabled
@@ -93,18 +97,26 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 2 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartToken, detectors.CredentialPartUsername}
+}
 
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
 	jar, err := cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
 	if err != nil {
-		return false
+		return detectors.NewVerificationOutcome(false, err)
 	}
-	verified, _ := verifyBrowserStackCredentials(ctx, s.getClient(jar), parts[0], parts[1])
-	return verified
+	verified, err := verifyBrowserStackCredentials(
+		ctx,
+		s.getClient(jar),
+		cred.Get(detectors.CredentialPartToken),
+		cred.Get(detectors.CredentialPartUsername),
+	)
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
 }
 
 func verifyBrowserStackCredentials(ctx context.Context, client *http.Client, accessKey, username string) (bool, error) {
@@ -139,6 +151,13 @@ func verifyBrowserStackCredentials(ctx context.Context, client *http.Client, acc
 	return false, nil
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "www.browserstack.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_BrowserStack
 }
@@ -63,11 +63,19 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
 
-	_, verified, _ := v1.VerifyBuildKite(ctx, client, secret)
-	return verified
+	extraData, verified, err := v1.VerifyBuildKite(ctx, client, secret)
+	return detectors.NewVerificationOutcome(verified, err, secret).WithExtraData(extraData)
 }
+
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "api.buildkite.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_Buildkite
 }
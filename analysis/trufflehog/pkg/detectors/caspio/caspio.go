@@ -67,6 +67,11 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 					Raw:          []byte(resMatch),
 					RawV2:        []byte(resMatch + resIdMatch + resDomainMatch),
 				}
+				s1.SetCredential(
+					detectors.CredentialPart{Name: detectors.CredentialPartSecret, Value: resMatch},
+					detectors.CredentialPart{Name: detectors.CredentialPartID, Value: resIdMatch},
+					detectors.CredentialPart{Name: detectors.CredentialPartDomain, Value: resDomainMatch},
+				)
 
 				if verify {
 					verified, err := verifyMatch(ctx, client, resMatch, resIdMatch, resDomainMatch)
@@ -101,6 +106,13 @@ func verifyMatch(ctx context.Context, client *http.Client, resMatch, resIdMatch,
 	return false, nil
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "POST", Host: "*.caspio.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_Caspio
 }
@@ -109,12 +121,21 @@ func (s Scanner) Description() string {
 	return "Caspio is a cloud platform for building custom database applications. Caspio credentials can be used to access and manage these applications."
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 3 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartSecret, detectors.CredentialPartID, detectors.CredentialPartDomain}
+}
 
-	verified, _ := verifyMatch(ctx, client, parts[0], parts[1], parts[2])
-	return verified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	verified, err := verifyMatch(
+		ctx,
+		client,
+		cred.Get(detectors.CredentialPartSecret),
+		cred.Get(detectors.CredentialPartID),
+		cred.Get(detectors.CredentialPartDomain),
+	)
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
 }
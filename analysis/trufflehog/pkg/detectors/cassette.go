@@ -0,0 +1,340 @@
+package detectors
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+)
+
+// CassetteMode selects whether a CassetteTransport records live HTTP traffic or
+// replays a recording.
+type CassetteMode int
+
+const (
+	// CassetteModeReplay answers requests from the cassette and fails requests
+	// that weren't recorded. It never touches the network.
+	CassetteModeReplay CassetteMode = iota
+	// CassetteModeRecord sends requests to the network and records them.
+	CassetteModeRecord
+)
+
+// RecordCassettesEnv is the environment variable that switches
+// CassetteModeFromEnv to recording.
+const RecordCassettesEnv = "TRUFFLEHOG_RECORD_CASSETTES"
+
+// CassetteModeFromEnv returns CassetteModeRecord if RecordCassettesEnv is set
+// to a true value, and CassetteModeReplay otherwise.
+func CassetteModeFromEnv() CassetteMode {
+	if record, _ := strconv.ParseBool(os.Getenv(RecordCassettesEnv)); record {
+		return CassetteModeRecord
+	}
+	return CassetteModeReplay
+}
+
+// ScrubbedPlaceholder replaces secrets in recorded interactions. It needs no
+// escaping, so a scrubbed token that a replayed response hands back to the
+// detector matches the recording wherever the detector puts it.
+const ScrubbedPlaceholder = "SCRUBBED"
+
+// sensitiveHeaders are never recorded, since they usually carry an encoded
+// form of a secret that scrubbing by value would miss.
+var sensitiveHeaders = map[string]struct{}{
+	"Authorization":        {},
+	"Proxy-Authorization":  {},
+	"Cookie":               {},
+	"Set-Cookie":           {},
+	"X-Api-Key":            {},
+	"X-Auth-Token":         {},
+	"X-Amz-Security-Token": {},
+}
+
+// Cassette is a recording of the HTTP interactions of a test.
+type Cassette struct {
+	Interactions []CassetteInteraction `json:"interactions"`
+}
+
+// CassetteInteraction is a single recorded request and its response.
+type CassetteInteraction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// CassetteRequest is a recorded request.
+type CassetteRequest struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// CassetteResponse is a recorded response.
+type CassetteResponse struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// CassetteMatcher reports whether a request, already scrubbed, matches a
+// recorded one.
+type CassetteMatcher func(req, recorded CassetteRequest) bool
+
+// DefaultCassetteMatcher matches requests by method, URL and body.
+func DefaultCassetteMatcher(req, recorded CassetteRequest) bool {
+	return req.Method == recorded.Method && req.URL == recorded.URL && req.Body == recorded.Body
+}
+
+// ErrCassetteNoMatch is returned when replaying a request that wasn't recorded.
+var ErrCassetteNoMatch = errors.New("no recorded interaction matches the request")
+
+// CassetteTransport is an http.RoundTripper that records HTTP interactions to a
+// cassette file or replays them from it, so the verification logic of
+// detectors can be tested offline and deterministically. Secrets are scrubbed
+// from everything that is recorded, and the requests being replayed are
+// scrubbed the same way before they are matched.
+//
+// Interactions are replayed in the order they were recorded; each recorded
+// interaction answers at most one request.
+type CassetteTransport struct {
+	path      string
+	mode      CassetteMode
+	secrets   []string
+	matcher   CassetteMatcher
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// CassetteOption configures a CassetteTransport.
+type CassetteOption func(*CassetteTransport)
+
+// WithCassetteMode sets the mode of the transport. The default is
+// CassetteModeReplay.
+func WithCassetteMode(mode CassetteMode) CassetteOption {
+	return func(t *CassetteTransport) { t.mode = mode }
+}
+
+// WithScrubbedSecrets adds values, such as the secrets being verified or
+// tokens returned by an API, to scrub from the cassette.
+func WithScrubbedSecrets(secrets ...string) CassetteOption {
+	return func(t *CassetteTransport) {
+		for _, s := range secrets {
+			if s != "" {
+				t.secrets = append(t.secrets, s)
+			}
+		}
+	}
+}
+
+// WithCassetteMatcher sets how requests are matched to recorded interactions,
+// e.g. to ignore a body that contains a timestamp.
+func WithCassetteMatcher(matcher CassetteMatcher) CassetteOption {
+	return func(t *CassetteTransport) { t.matcher = matcher }
+}
+
+// WithCassetteRecordingTransport sets the transport used to send requests when
+// recording. The default is NewDetectorTransport(nil).
+func WithCassetteRecordingTransport(transport http.RoundTripper) CassetteOption {
+	return func(t *CassetteTransport) { t.transport = transport }
+}
+
+// NewCassetteTransport creates a CassetteTransport for the cassette at path.
+// When replaying, the cassette must exist. When recording, any existing
+// cassette is replaced by Save.
+func NewCassetteTransport(path string, opts ...CassetteOption) (*CassetteTransport, error) {
+	t := &CassetteTransport{path: path, matcher: DefaultCassetteMatcher}
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	if t.mode == CassetteModeRecord {
+		if t.transport == nil {
+			t.transport = NewDetectorTransport(nil)
+		}
+		return t, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+	if err := json.Unmarshal(data, &t.cassette); err != nil {
+		return nil, fmt.Errorf("failed to decode cassette %q: %w", path, err)
+	}
+	t.used = make([]bool, len(t.cassette.Interactions))
+	return t, nil
+}
+
+// NewCassetteClient creates an HTTP client for detectors that uses a
+// CassetteTransport, along with the transport so recordings can be saved.
+func NewCassetteClient(path string, opts ...CassetteOption) (*http.Client, *CassetteTransport, error) {
+	t, err := NewCassetteTransport(path, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	client := NewDetectorHttpClient(
+		WithTransport(t),
+		WithTimeout(DefaultResponseTimeout),
+		WithNoFollowRedirects(),
+	)
+	return client, t, nil
+}
+
+func (t *CassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := t.scrubRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var res *http.Response
+	if t.mode == CassetteModeRecord {
+		res, err = t.record(req, recorded)
+	} else {
+		res, err = t.replay(req, recorded)
+	}
+	common.RecordResponse(req, res)
+	return res, err
+}
+
+func (t *CassetteTransport) record(req *http.Request, recorded CassetteRequest) (*http.Response, error) {
+	res, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cassette.Interactions = append(t.cassette.Interactions, CassetteInteraction{
+		Request: recorded,
+		Response: CassetteResponse{
+			StatusCode: res.StatusCode,
+			Headers:    t.scrubHeaders(res.Header),
+			Body:       t.scrub(string(body)),
+		},
+	})
+	return res, nil
+}
+
+func (t *CassetteTransport) replay(req *http.Request, recorded CassetteRequest) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, interaction := range t.cassette.Interactions {
+		if t.used[i] || !t.matcher(recorded, interaction.Request) {
+			continue
+		}
+		t.used[i] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Headers.Clone(),
+			Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s %s", ErrCassetteNoMatch, recorded.Method, recorded.URL)
+}
+
+// Unused returns the recorded interactions that haven't been replayed, which
+// usually means the detector stopped making a request it used to make.
+func (t *CassetteTransport) Unused() []CassetteInteraction {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var unused []CassetteInteraction
+	for i, used := range t.used {
+		if !used {
+			unused = append(unused, t.cassette.Interactions[i])
+		}
+	}
+	return unused
+}
+
+// Save writes the recorded interactions to the cassette file, creating its
+// directory if needed. It does nothing when replaying.
+func (t *CassetteTransport) Save() error {
+	if t.mode != CassetteModeRecord {
+		return nil
+	}
+	t.mu.Lock()
+	data, err := json.MarshalIndent(t.cassette, "", "  ")
+	t.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(t.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(t.path, append(data, '\n'), 0o644)
+}
+
+// scrubRequest returns the scrubbed form of req, restoring its body so it can
+// still be sent.
+func (t *CassetteTransport) scrubRequest(req *http.Request) (CassetteRequest, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return CassetteRequest{}, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	return CassetteRequest{
+		Method:  req.Method,
+		URL:     t.scrub(req.URL.String()),
+		Headers: t.scrubHeaders(req.Header),
+		Body:    t.scrub(string(body)),
+	}, nil
+}
+
+func (t *CassetteTransport) scrubHeaders(headers http.Header) http.Header {
+	if len(headers) == 0 {
+		return nil
+	}
+	scrubbed := make(http.Header, len(headers))
+	for name, values := range headers {
+		if _, ok := sensitiveHeaders[http.CanonicalHeaderKey(name)]; ok {
+			scrubbed[name] = []string{ScrubbedPlaceholder}
+			continue
+		}
+		for _, v := range values {
+			scrubbed[name] = append(scrubbed[name], t.scrub(v))
+		}
+	}
+	return scrubbed
+}
+
+// scrub replaces every secret in s with ScrubbedPlaceholder. Secrets are also
+// matched in their URL-escaped form, since they often appear in query strings
+// and form bodies.
+func (t *CassetteTransport) scrub(s string) string {
+	for _, secret := range t.secrets {
+		s = strings.ReplaceAll(s, secret, ScrubbedPlaceholder)
+		if escaped := url.QueryEscape(secret); escaped != secret {
+			s = strings.ReplaceAll(s, escaped, ScrubbedPlaceholder)
+		}
+	}
+	return s
+}
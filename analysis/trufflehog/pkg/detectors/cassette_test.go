@@ -0,0 +1,123 @@
+package detectors
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+)
+
+// exchangeAndCall makes a two-step OAuth style request: it exchanges secret for
+// a token and calls the API with it.
+func exchangeAndCall(ctx context.Context, client *http.Client, baseURL, secret string) (int, error) {
+	form := url.Values{"client_secret": {secret}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	res, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	token, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return 0, err
+	}
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/me?token="+string(token), nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+string(token))
+	res, err = client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	_ = res.Body.Close()
+	return res.StatusCode, nil
+}
+
+func TestCassetteTransport_RecordAndReplay(t *testing.T) {
+	const secret = "sk_live_s3cr3t/+"
+	const token = "tok_12345"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			_ = r.ParseForm()
+			if r.PostForm.Get("client_secret") != secret {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = fmt.Fprint(w, token)
+		case "/me":
+			if r.Header.Get("Authorization") != "Bearer "+token {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cassettes", "oauth.json")
+	client, recorder, err := NewCassetteClient(path,
+		WithCassetteMode(CassetteModeRecord),
+		WithCassetteRecordingTransport(http.DefaultTransport),
+		WithScrubbedSecrets(secret, token, server.URL),
+	)
+	require.NoError(t, err)
+	status, err := exchangeAndCall(ctx, client, server.URL, secret)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, status)
+	require.NoError(t, recorder.Save())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "s3cr3t")
+	assert.NotContains(t, string(data), token)
+	assert.NotContains(t, string(data), "127.0.0.1")
+
+	// Replaying doesn't need the server. The token handed back by the replayed
+	// response is the placeholder, which matches the recorded second request.
+	server.Close()
+	client, replayer, err := NewCassetteClient(path, WithScrubbedSecrets(secret, server.URL))
+	require.NoError(t, err)
+	ctx, responses := common.WithResponseRecorder(ctx)
+	status, err = exchangeAndCall(ctx, client, server.URL, secret)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, status)
+	assert.Equal(t, http.StatusNoContent, responses.LastStatus())
+	assert.Empty(t, replayer.Unused())
+
+	// Every recorded interaction answers a single request.
+	_, err = exchangeAndCall(ctx, client, server.URL, secret)
+	assert.ErrorIs(t, err, ErrCassetteNoMatch)
+}
+
+func TestCassetteTransport_ReplayMismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"interactions":[{"request":{"method":"GET","url":"https://example.com/a"},"response":{"status_code":200}}]}`), 0o644))
+
+	client, cassette, err := NewCassetteClient(path)
+	require.NoError(t, err)
+	_, err = client.Get("https://example.com/b")
+	assert.ErrorIs(t, err, ErrCassetteNoMatch)
+	assert.Len(t, cassette.Unused(), 1)
+
+	_, _, err = NewCassetteClient(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
@@ -73,6 +73,11 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 					Raw:          []byte(resKeyMatch),
 					RawV2:        []byte(resKeyMatch + ";-|" + resUserIdMatch + ";-|" + resSecretMatch),
 				}
+				s1.SetCredential(
+					detectors.CredentialPart{Name: detectors.CredentialPartKey, Value: resKeyMatch},
+					detectors.CredentialPart{Name: detectors.CredentialPartID, Value: resUserIdMatch},
+					detectors.CredentialPart{Name: detectors.CredentialPartSecret, Value: resSecretMatch},
+				)
 
 				if verify {
 					verified, err := verifyMatch(ctx, client, resKeyMatch, resUserIdMatch, resSecretMatch)
@@ -130,14 +135,23 @@ func verifyMatch(ctx context.Context, client *http.Client, resKeyMatch, resUserI
 	return false, nil
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 3 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartKey, detectors.CredentialPartID, detectors.CredentialPartSecret}
+}
 
-	verified, _ := verifyMatch(ctx, client, parts[0], parts[1], parts[2])
-	return verified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	verified, err := verifyMatch(
+		ctx,
+		client,
+		cred.Get(detectors.CredentialPartKey),
+		cred.Get(detectors.CredentialPartID),
+		cred.Get(detectors.CredentialPartSecret),
+	)
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
 }
 
 type Response struct {
@@ -153,6 +167,13 @@ func getCexIOPassphrase(apiSecret string, apiKey string, nonce string, userId st
 	return strings.ToUpper(hex.EncodeToString(macsum))
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "POST", Host: "cex.io"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_CexIO
 }
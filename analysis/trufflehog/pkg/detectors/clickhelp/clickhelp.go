@@ -66,6 +66,11 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 					Raw:          []byte(resServer),
 					RawV2:        []byte(resServer + ";-|" + resEmail + ";-|" + resKey),
 				}
+				s1.SetCredential(
+					detectors.CredentialPart{Name: detectors.CredentialPartDomain, Value: resServer},
+					detectors.CredentialPart{Name: detectors.CredentialPartEmail, Value: resEmail},
+					detectors.CredentialPart{Name: detectors.CredentialPartToken, Value: resKey},
+				)
 
 				if verify {
 					verified, err := verifyMatch(ctx, client, resServer, resEmail, resKey)
@@ -84,6 +89,13 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "*.try.clickhelp.co"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_ClickHelp
 }
@@ -92,14 +104,23 @@ func (s Scanner) Description() string {
 	return "ClickHelp is a documentation tool that allows users to create and manage online documentation. ClickHelp API keys can be used to access and modify documentation data."
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 3 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartDomain, detectors.CredentialPartEmail, detectors.CredentialPartToken}
+}
 
-	verified, _ := verifyMatch(ctx, client, parts[0], parts[1], parts[2])
-	return verified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	verified, err := verifyMatch(
+		ctx,
+		client,
+		cred.Get(detectors.CredentialPartDomain),
+		cred.Get(detectors.CredentialPartEmail),
+		cred.Get(detectors.CredentialPartToken),
+	)
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
 }
 func verifyMatch(ctx context.Context, client *http.Client, resServer, resEmail, resKey string) (bool, error) {
 	data := fmt.Sprintf("%s:%s", resEmail, resKey)
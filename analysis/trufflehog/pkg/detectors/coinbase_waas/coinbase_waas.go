@@ -76,6 +76,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 				Raw:          []byte(resPrivKeyMatch),
 				RawV2:        []byte(resPrivKeyMatch + resKeyNameMatch),
 			}
+			s1.SetCredential(
+				detectors.CredentialPart{Name: detectors.CredentialPartSecret, Value: resPrivKeyMatch},
+				detectors.CredentialPart{Name: detectors.CredentialPartID, Value: resKeyNameMatch},
+			)
 
 			if verify {
 				isVerified, verificationErr := s.verifyMatch(ctx, resKeyNameMatch, resPrivKeyMatch)
@@ -94,14 +98,17 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 2 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartSecret, detectors.CredentialPartID}
+}
 
-	verified, _ := s.verifyMatch(ctx, parts[0], parts[1])
-	return verified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	verified, err := s.verifyMatch(ctx, cred.Get(detectors.CredentialPartID), cred.Get(detectors.CredentialPartSecret))
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
 }
 
 func isValidECPrivateKey(pemKey []byte) bool {
@@ -154,6 +161,13 @@ func (s Scanner) verifyMatch(ctx context.Context, apiKeyName, privKey string) (b
 	return true, nil
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "api.developer.coinbase.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_CoinbaseWaaS
 }
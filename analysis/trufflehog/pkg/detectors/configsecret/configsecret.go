@@ -0,0 +1,187 @@
+package configsecret
+
+import (
+	"context"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+// Scanner is a generic detector that finds secrets of configuration files by the key they're assigned to rather
+// than by their format, catching in-house secrets that no vendor detector knows of. It reports values of secret-like
+// keys, such as api_key, clientSecret or AUTH_TOKEN, that have high entropy.
+//
+// Its results are those of a generic detector, reported apart from vendor detectors' results. It doesn't implement
+// CustomFalsePositiveChecker, so they're filtered with the default false positive wordlists.
+type Scanner struct{}
+
+var (
+	// Ensure the Scanner satisfies the interface at compile time.
+	_ detectors.Detector              = (*Scanner)(nil)
+	_ detectors.MaxSecretSizeProvider = (*Scanner)(nil)
+	_ detectors.StartOffsetProvider   = (*Scanner)(nil)
+
+	// secretWords are words that make a key secret-like wherever they are in the key.
+	secretWords = map[string]struct{}{"bearer": {}, "jwt": {}, "credential": {}, "credentials": {}, "clientid": {}}
+	// secretSuffixes are words that make a key secret-like when the key ends with them.
+	secretSuffixes = map[string]struct{}{"api": {}, "key": {}, "secret": {}, "token": {}}
+	// compoundPrefixes are words that secret suffixes are written together with in lowercase keys, as in apikey.
+	compoundPrefixes = map[string]struct{}{
+		"api": {}, "access": {}, "app": {}, "auth": {}, "client": {}, "consumer": {}, "encryption": {}, "license": {},
+		"master": {}, "private": {}, "refresh": {}, "secret": {}, "session": {}, "signing": {},
+	}
+)
+
+const (
+	minValueLength = 8
+	maxValueLength = 1024
+	// minValueEntropy is the lowest Shannon entropy, in bits per character, of the values that are reported.
+	minValueEntropy = 3.0
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"api", "key", "secret", "token", "bearer", "jwt", "credential", "client_id", "clientid"}
+}
+
+// maxSpan is how far from a keyword the detector looks, both ways. It covers whole chunks, since structured files
+// can only be parsed, and the paths of their keys found, from their start.
+const maxSpan = math.MaxInt32
+
+func (Scanner) MaxSecretSize() int64 { return maxSpan }
+
+func (Scanner) StartOffset() int64 { return maxSpan }
+
+// FromData will find secret-like configuration values in a given set of bytes.
+func (s Scanner) FromData(_ context.Context, _ bool, data []byte) (results []detectors.Result, err error) {
+	for _, entry := range parseEntries(data) {
+		if !isSecretKey(entry.key) {
+			continue
+		}
+		value, ok := secretValue(entry.value)
+		if !ok {
+			continue
+		}
+
+		results = append(results, detectors.Result{
+			DetectorType: detectorspb.DetectorType_Generic,
+			Raw:          []byte(value),
+			ExtraData:    map[string]string{"key_path": entry.path},
+		})
+	}
+	return results, nil
+}
+
+// isSecretKey reports whether a key names a secret, matching its words, split on punctuation and case changes.
+func isSecretKey(key string) bool {
+	words := keyWords(key)
+	for i, word := range words {
+		if _, ok := secretWords[word]; ok {
+			return true
+		}
+		if word == "client" && i+1 < len(words) && words[i+1] == "id" {
+			return true
+		}
+	}
+	if len(words) == 0 {
+		return false
+	}
+
+	last := words[len(words)-1]
+	if _, ok := secretSuffixes[last]; ok {
+		return true
+	}
+	for suffix := range secretSuffixes {
+		if prefix, found := strings.CutSuffix(last, suffix); found {
+			if _, ok := compoundPrefixes[prefix]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// keyWords returns the lowercase words of a key, as in google_api_key, apiKey or API-KEY.
+func keyWords(key string) []string {
+	var (
+		words []string
+		word  []rune
+	)
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+	}
+
+	runes := []rune(key)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+			continue
+		case unicode.IsUpper(r) && i > 0:
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush()
+			}
+		}
+		word = append(word, r)
+	}
+	flush()
+	return words
+}
+
+// secretValue returns the secret of a value, without the scheme of an Authorization header value, and whether it
+// may be a secret: long enough, without whitespace, high entropy and containing a digit. References to variables
+// and URLs aren't secrets themselves.
+func secretValue(value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	for _, scheme := range []string{"Bearer ", "Basic ", "Token "} {
+		if len(value) > len(scheme) && strings.EqualFold(value[:len(scheme)], scheme) {
+			value = strings.TrimSpace(value[len(scheme):])
+			break
+		}
+	}
+
+	if len(value) < minValueLength || len(value) > maxValueLength {
+		return "", false
+	}
+	if strings.IndexFunc(value, unicode.IsSpace) >= 0 || strings.Contains(value, "://") {
+		return "", false
+	}
+	for _, prefix := range []string{"${", "$(", "{{", "%(", "@"} {
+		if strings.HasPrefix(value, prefix) {
+			return "", false
+		}
+	}
+	if !detectors.HasDigit(value) || detectors.StringShannonEntropy(value) < minValueEntropy {
+		return "", false
+	}
+	return value, true
+}
+
+func (s Scanner) Verify(_ context.Context, _ string) detectors.VerificationOutcome {
+	// The values of configuration files belong to arbitrary services.
+	return detectors.VerificationNotSupportedOutcome()
+}
+
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		// The detector can't verify its secrets, so it sends no requests.
+		ReadOnly: true,
+	}
+}
+
+func (s Scanner) Type() detectorspb.DetectorType {
+	return detectorspb.DetectorType_Generic
+}
+
+func (s Scanner) Description() string {
+	return "Secret-like value of a configuration file key, found by its key rather than by a vendor's secret format."
+}
@@ -0,0 +1,197 @@
+package configsecret
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+const secret = "q8ZtR2vK9xLm4PwN7sYb"
+
+func TestConfigSecret_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  map[string]string
+	}{
+		{
+			name: "json",
+			input: `{"name": "app", "services": {"payments": {"apiKey": "` + secret + `", "url": "https://pay.example.com"}},
+				"tokens": ["` + secret + `-2"], "retries": 3}`,
+			want: map[string]string{"services.payments.apiKey": secret},
+		},
+		{
+			name:  "yaml",
+			input: "server:\n  port: 8080\n  auth:\n    client_secret: " + secret + "\n    client_id: app\n",
+			want:  map[string]string{"server.auth.client_secret": secret},
+		},
+		{
+			name:  "properties",
+			input: "# Service settings\nservice.url=https://api.example.com\nservice.auth-token = " + secret + "\nservice.timeout=30\n",
+			want:  map[string]string{"service.auth-token": secret},
+		},
+		{
+			name:  "env",
+			input: "export APP_ENV=production\nexport APP_ACCESS_KEY=\"" + secret + "\"\nPAYMENTS_BEARER=Bearer " + secret + "\n",
+			want:  map[string]string{"APP_ACCESS_KEY": secret, "PAYMENTS_BEARER": secret},
+		},
+		{
+			name:  "flattened property list",
+			input: "CFBundleIdentifier = com.example.app\nServices.Analytics.apikey = " + secret + "\n",
+			want:  map[string]string{"Services.Analytics.apikey": secret},
+		},
+		{
+			name: "android resources",
+			input: `<?xml version="1.0" encoding="utf-8"?>
+<resources>
+    <string name="app_name">Example</string>
+    <string name="backend_api_key">` + secret + `</string>
+</resources>`,
+			want: map[string]string{"resources.backend_api_key": secret},
+		},
+		{
+			name: "android manifest",
+			input: `<manifest xmlns:android="http://schemas.android.com/apk/res/android" package="com.example.app">
+    <application android:label="@string/app_name">
+        <meta-data android:name="com.example.SDK_TOKEN" android:value="` + secret + `"/>
+        <meta-data android:name="com.example.API_KEY" android:value="@string/backend_api_key"/>
+    </application>
+</manifest>`,
+			want: map[string]string{"manifest.application.com.example.SDK_TOKEN": secret},
+		},
+		{
+			name: "xml property list",
+			input: `<plist version="1.0"><dict>
+    <key>Backend</key><dict><key>SecretKey</key><string>` + secret + `</string></dict>
+</dict></plist>`,
+			want: map[string]string{"plist.dict.Backend.SecretKey": secret},
+		},
+		{
+			name:  "json fragment",
+			input: `"region": "eu-west-1", "jwt": "` + secret + `", "monkey": "` + secret + `-2" }`,
+			want:  map[string]string{"jwt": secret},
+		},
+		{
+			name: "invalid",
+			input: "api_key = ${API_KEY}\ntoken: changeme\nsecret = sk-short\napi = https://api.example.com/v1\n" +
+				"keyboard_layout = " + secret + "\npublic_key = aaaaaaaaaaaaaaaa1\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("keywords '%v' not matched by: %s", d.Keywords(), test.input)
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			if err != nil {
+				t.Errorf("error = %v", err)
+				return
+			}
+
+			var actual map[string]string
+			for _, r := range results {
+				if actual == nil {
+					actual = make(map[string]string)
+				}
+				actual[r.ExtraData["key_path"]] = string(r.Raw)
+			}
+			if diff := cmp.Diff(test.want, actual); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestConfigSecret_Chunk(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+	padding := strings.Repeat("lorem ipsum dolor sit amet ", 60)
+
+	tests := []struct {
+		name  string
+		input string
+		want  map[string]string
+	}{
+		{
+			name:  "minified json",
+			input: `{"description":"` + padding + `","services":{"payments":{"apiKey":"` + secret + `"}}}`,
+			want:  map[string]string{"services.payments.apiKey": secret},
+		},
+		{
+			name: "pretty-printed json",
+			input: "{\n  \"services\": {\n    \"payments\": {\n      \"description\": \"" + padding + "\",\n" +
+				"      \"apiKey\": \"" + secret + "\"\n    }\n  }\n}\n",
+			want: map[string]string{"services.payments.apiKey": secret},
+		},
+	}
+
+	// The engine only passes detectors the data around the keywords of the chunks they match.
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if len(test.input) <= 1024 {
+				t.Fatalf("input is %d bytes, want more than 1 KB", len(test.input))
+			}
+			var actual map[string]string
+			for _, match := range ahoCorasickCore.FindDetectorMatches([]byte(test.input)) {
+				for _, data := range match.Matches() {
+					results, err := d.FromData(context.Background(), false, data)
+					if err != nil {
+						t.Errorf("error = %v", err)
+						return
+					}
+					for _, r := range results {
+						if actual == nil {
+							actual = make(map[string]string)
+						}
+						actual[r.ExtraData["key_path"]] = string(r.Raw)
+					}
+				}
+			}
+			if diff := cmp.Diff(test.want, actual); diff != "" {
+				t.Errorf("%s diff: (-want +got)\n%s", test.name, diff)
+			}
+		})
+	}
+}
+
+func TestIsSecretKey(t *testing.T) {
+	tests := map[string]bool{
+		"api_key":         true,
+		"apiKey":          true,
+		"APIKey":          true,
+		"GOOGLE_API_KEY":  true,
+		"apikey":          true,
+		"clientSecret":    true,
+		"client_id":       true,
+		"ClientID":        true,
+		"auth-token":      true,
+		"refreshtoken":    true,
+		"jwtSigningSalt":  true,
+		"credentials":     true,
+		"bearer":          true,
+		"api":             true,
+		"monkey":          false,
+		"keyboard":        false,
+		"tokenizer":       false,
+		"secretary_email": false,
+		"keyAlias":        false,
+		"":                false,
+	}
+	for key, want := range tests {
+		if got := isSecretKey(key); got != want {
+			t.Errorf("isSecretKey(%q) = %v, want %v", key, got, want)
+		}
+	}
+}
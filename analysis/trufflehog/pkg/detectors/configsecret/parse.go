@@ -0,0 +1,220 @@
+package configsecret
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+	"gopkg.in/yaml.v3"
+)
+
+// entry is a value of a configuration file, along with the key it's assigned to and the path of that key.
+type entry struct {
+	path  string
+	key   string
+	value string
+}
+
+// parseEntries returns the string values of the configuration data, which may be JSON, YAML or XML, or .properties
+// and .env files and the "key.path = value" lines that file handlers flatten property lists and resources into.
+// Data that doesn't parse as a whole, such as a chunk holding part of a file, is read line by line.
+func parseEntries(data []byte) []entry {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil
+	}
+
+	var entries []entry
+	switch trimmed[0] {
+	case '{', '[':
+		var v any
+		if err := json.Unmarshal(trimmed, &v); err == nil {
+			walkValue(v, "", "", &entries)
+			return entries
+		}
+	case '<':
+		if entries, err := parseXML(trimmed); err == nil {
+			return entries
+		}
+	default:
+		var v any
+		if err := yaml.Unmarshal(trimmed, &v); err == nil {
+			switch v.(type) {
+			case map[string]any, map[any]any, []any:
+				walkValue(v, "", "", &entries)
+				return entries
+			}
+		}
+	}
+	return parseLines(data)
+}
+
+// walkValue appends the string values of a decoded JSON or YAML value to entries. Keys are joined by dots and list
+// indexes appended in brackets, the items of a list being assigned to the key of the list.
+func walkValue(v any, path, key string, entries *[]entry) {
+	switch val := v.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			walkValue(val[k], joinKeyPath(path, k), k, entries)
+		}
+	case map[any]any:
+		keys := make([]string, 0, len(val))
+		values := make(map[string]any, len(val))
+		for k, item := range val {
+			keys = append(keys, fmt.Sprint(k))
+			values[fmt.Sprint(k)] = item
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			walkValue(values[k], joinKeyPath(path, k), k, entries)
+		}
+	case []any:
+		for i, item := range val {
+			walkValue(item, path+"["+strconv.Itoa(i)+"]", key, entries)
+		}
+	case string:
+		*entries = append(*entries, entry{path: path, key: key, value: val})
+	}
+}
+
+// parseXML returns the attributes and text of the elements of an XML document, keyed by the element's name, or by
+// its name or key attribute as in Android resources and manifest meta-data. The key of a property list is assigned
+// to the element following it.
+func parseXML(data []byte) ([]entry, error) {
+	type element struct {
+		path, key string
+		text      strings.Builder
+	}
+
+	var (
+		entries    []entry
+		stack      []*element
+		pendingKey string
+	)
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			parent := ""
+			if len(stack) > 0 {
+				parent = stack[len(stack)-1].path
+			}
+			key := t.Name.Local
+			for _, attr := range t.Attr {
+				if (attr.Name.Local == "name" || attr.Name.Local == "key") && attr.Value != "" {
+					key = attr.Value
+				}
+			}
+			if pendingKey != "" {
+				key, pendingKey = pendingKey, ""
+			}
+			elem := &element{path: joinKeyPath(parent, key), key: key}
+			for _, attr := range t.Attr {
+				switch attr.Name.Local {
+				case "name", "key":
+				case "value":
+					entries = append(entries, entry{path: elem.path, key: key, value: attr.Value})
+				default:
+					entries = append(entries, entry{
+						path:  joinKeyPath(elem.path, attr.Name.Local),
+						key:   attr.Name.Local,
+						value: attr.Value,
+					})
+				}
+			}
+			stack = append(stack, elem)
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(t)
+			}
+		case xml.EndElement:
+			if len(stack) == 0 {
+				continue
+			}
+			elem := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			text := strings.TrimSpace(elem.text.String())
+			if t.Name.Local == "key" && elem.key == "key" {
+				pendingKey = text
+				continue
+			}
+			if text != "" {
+				entries = append(entries, entry{path: elem.path, key: elem.key, value: text})
+			}
+		}
+	}
+	return entries, nil
+}
+
+// linePat matches "key = value" and "key: value" lines, with optionally quoted keys and values, such as those of
+// .properties and .env files, flattened property lists and resources, and fragments of JSON and YAML.
+var linePat = regexp.MustCompile(`^\s*(?:export\s+)?["']?([\w.\-\[\]]+)["']?\s*[=:]\s*(.*?)[\s,;]*$`)
+
+// quotedPairPat matches the "key": "value" pairs of JSON, which minified JSON holds several of per line.
+var quotedPairPat = regexp.MustCompile(`"([^"\\]+)"\s*:\s*("(?:[^"\\]|\\.)*")`)
+
+// parseLines returns the values of the "key = value" and "key: value" lines of data, and of the JSON pairs of lines
+// holding several.
+func parseLines(data []byte) []entry {
+	var entries []entry
+	for _, line := range strings.Split(string(data), "\n") {
+		if pairs := quotedPairPat.FindAllStringSubmatch(line, -1); len(pairs) > 1 {
+			for _, pair := range pairs {
+				if value, err := strconv.Unquote(pair[2]); err == nil {
+					entries = append(entries, entry{path: pair[1], key: lastKey(pair[1]), value: value})
+				}
+			}
+			continue
+		}
+
+		m := linePat.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		path, value := m[1], m[2]
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		entries = append(entries, entry{path: path, key: lastKey(path), value: value})
+	}
+	return entries
+}
+
+// lastKey returns the last key of a key path, without list indexes.
+func lastKey(path string) string {
+	for strings.HasSuffix(path, "]") {
+		i := strings.LastIndexByte(path, '[')
+		if i < 0 {
+			break
+		}
+		path = path[:i]
+	}
+	return path[strings.LastIndexByte(path, '.')+1:]
+}
+
+func joinKeyPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -63,6 +63,14 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		// Verifying creates a conversion task.
+		Requests: []detectors.VerificationRequest{{Method: "POST", Host: "api.conversiontools.io"}},
+		Billable: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_ConversionTools
 }
@@ -71,9 +79,9 @@ func (s Scanner) Description() string {
 	return "ConversionTools is a service used for various data conversion tasks. The API keys can be used to access and perform these tasks."
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	verified, _ := verifyMatch(ctx, client, secret)
-	return verified
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	verified, err := verifyMatch(ctx, client, secret)
+	return detectors.NewVerificationOutcome(verified, err, secret)
 }
 func verifyMatch(ctx context.Context, client *http.Client, resMatch string) (bool, error) {
 	payload := strings.NewReader(`{  "type": "convert.website_to_jpg",  "options": {    "url": "http://google.com",    "images": "yes"  }}`)
@@ -95,6 +95,11 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 					DetectorType: detectorspb.DetectorType_Couchbase,
 					Raw:          []byte(fmt.Sprintf("%s:%s@%s", resUsernameMatch, resPasswordMatch, resConnectionStringMatch)),
 				}
+				s1.SetCredential(
+					detectors.CredentialPart{Name: detectors.CredentialPartUsername, Value: resUsernameMatch},
+					detectors.CredentialPart{Name: detectors.CredentialPartPassword, Value: resPasswordMatch},
+					detectors.CredentialPart{Name: detectors.CredentialPartEndpoint, Value: resConnectionStringMatch},
+				)
 
 				if verify {
 					verified, err := verifyMatch(ctx, resPasswordMatch, resPasswordMatch, resConnectionStringMatch)
@@ -112,6 +117,13 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "couchbase", Host: "cb.*.cloud.couchbase.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_Couchbase
 }
@@ -120,14 +132,22 @@ func (s Scanner) Description() string {
 	return "Couchbase is a distributed NoSQL cloud database. Couchbase credentials can be used to access and modify data within the Couchbase database."
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 3 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartUsername, detectors.CredentialPartPassword, detectors.CredentialPartEndpoint}
+}
 
-	verified, _ := verifyMatch(ctx, parts[0], parts[1], parts[3])
-	return verified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	verified, err := verifyMatch(
+		ctx,
+		cred.Get(detectors.CredentialPartUsername),
+		cred.Get(detectors.CredentialPartPassword),
+		cred.Get(detectors.CredentialPartEndpoint),
+	)
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
 }
 func verifyMatch(ctx context.Context, resUsernameMatch, resPasswordMatch, resConnectionStringMatch string) (bool, error) {
 	options := gocb.ClusterOptions{
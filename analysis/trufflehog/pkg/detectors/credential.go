@@ -0,0 +1,208 @@
+package detectors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+)
+
+// CredentialSeparator joins the parts of a multi-part secret in its legacy
+// string form, e.g. in RawV2 or in the secret passed to Detector.Verify.
+const CredentialSeparator = ";-|"
+
+// CredentialPartName names a part of a multi-part credential.
+type CredentialPartName string
+
+const (
+	CredentialPartID       CredentialPartName = "id"
+	CredentialPartSecret   CredentialPartName = "secret"
+	CredentialPartToken    CredentialPartName = "token"
+	CredentialPartEndpoint CredentialPartName = "endpoint"
+	CredentialPartDomain   CredentialPartName = "domain"
+	CredentialPartEmail    CredentialPartName = "email"
+	CredentialPartUsername CredentialPartName = "username"
+	CredentialPartPassword CredentialPartName = "password"
+
+	CredentialPartKey         CredentialPartName = "key"
+	CredentialPartTenant      CredentialPartName = "tenant"
+	CredentialPartAccount     CredentialPartName = "account"
+	CredentialPartPassphrase  CredentialPartName = "passphrase"
+	CredentialPartTokenSecret CredentialPartName = "token_secret"
+)
+
+// CredentialPart is a single named part of a Credential.
+type CredentialPart struct {
+	Name  CredentialPartName
+	Value string
+}
+
+// Credential is a secret made up of several named parts, such as an access key
+// ID and its secret. The order of the parts is preserved; it is the order of
+// the legacy ";-|" form.
+//
+// A Credential is encoded in JSON as an object mapping part names to values,
+// e.g. {"id":"AKIA...","secret":"..."}.
+type Credential struct {
+	parts []CredentialPart
+}
+
+// NewCredential creates a Credential from its parts.
+func NewCredential(parts ...CredentialPart) Credential {
+	return Credential{parts: parts}
+}
+
+// Get returns the value of the part called name, or "" if there is none.
+func (c Credential) Get(name CredentialPartName) string {
+	for _, p := range c.parts {
+		if p.Name == name {
+			return p.Value
+		}
+	}
+	return ""
+}
+
+// Parts returns the parts of the credential in order.
+func (c Credential) Parts() []CredentialPart {
+	return c.parts
+}
+
+// Values returns the values of all parts in order. They are useful to redact
+// verification errors.
+func (c Credential) Values() []string {
+	values := make([]string, len(c.parts))
+	for i, p := range c.parts {
+		values[i] = p.Value
+	}
+	return values
+}
+
+// String returns the legacy form of the credential, its values joined with
+// CredentialSeparator.
+func (c Credential) String() string {
+	return strings.Join(c.Values(), CredentialSeparator)
+}
+
+func (c Credential) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, p := range c.parts {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(string(p.Name))
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(p.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (c *Credential) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil {
+		return err
+	} else if tok != json.Delim('{') {
+		return fmt.Errorf("credential must be a JSON object")
+	}
+
+	// Decode token by token, since a map would lose the order of the parts.
+	var parts []CredentialPart
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		name, _ := tok.(string)
+		var value string
+		if err := dec.Decode(&value); err != nil {
+			return fmt.Errorf("credential part %q: %w", name, err)
+		}
+		parts = append(parts, CredentialPart{Name: CredentialPartName(name), Value: value})
+	}
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	c.parts = parts
+	return nil
+}
+
+// ParseCredential parses a secret into a Credential with the given parts. The
+// secret is either a JSON encoded Credential, which must contain every part,
+// or the legacy form with exactly one value per part, in order.
+func ParseCredential(secret string, names ...CredentialPartName) (Credential, error) {
+	if strings.HasPrefix(strings.TrimSpace(secret), "{") {
+		var cred Credential
+		if err := json.Unmarshal([]byte(secret), &cred); err != nil {
+			return Credential{}, fmt.Errorf("%w: %v", ErrMalformedSecret, err)
+		}
+		for _, name := range names {
+			if cred.Get(name) == "" {
+				return Credential{}, fmt.Errorf("%w: missing credential part %q", ErrMalformedSecret, name)
+			}
+		}
+		return cred, nil
+	}
+
+	values := strings.Split(secret, CredentialSeparator)
+	if len(values) != len(names) {
+		return Credential{}, fmt.Errorf("%w: expected %d parts, got %d", ErrMalformedSecret, len(names), len(values))
+	}
+	parts := make([]CredentialPart, len(names))
+	for i, name := range names {
+		parts[i] = CredentialPart{Name: name, Value: values[i]}
+	}
+	return NewCredential(parts...), nil
+}
+
+// CredentialVerifier is an optional interface that a detector of multi-part
+// credentials can implement to verify a Credential by the names of its parts
+// instead of their position in the legacy form.
+type CredentialVerifier interface {
+	// CredentialParts returns the names of the parts of the credentials the
+	// detector finds, in the order of their legacy form.
+	CredentialParts() []CredentialPartName
+	VerifyCredential(ctx context.Context, cred Credential) VerificationOutcome
+}
+
+// VerifyLegacyCredential parses secret, in either form accepted by
+// ParseCredential, and verifies it with d. CredentialVerifiers use it to
+// implement Detector.Verify.
+func VerifyLegacyCredential(ctx context.Context, d CredentialVerifier, secret string) VerificationOutcome {
+	cred, err := ParseCredential(secret, d.CredentialParts()...)
+	if err != nil {
+		return MalformedSecretOutcome()
+	}
+	return d.VerifyCredential(ctx, cred)
+}
+
+// VerifyCredential verifies cred using d like VerifySecret. Detectors that
+// don't implement CredentialVerifier are passed the legacy form of cred.
+func VerifyCredential(ctx context.Context, d Detector, cred Credential) VerificationOutcome {
+	v, ok := d.(CredentialVerifier)
+	if !ok {
+		return VerifySecret(ctx, d, cred.String())
+	}
+	for _, name := range v.CredentialParts() {
+		if cred.Get(name) == "" {
+			return MalformedSecretOutcome()
+		}
+	}
+	ctx, recorder := common.WithResponseRecorder(ctx)
+	outcome := v.VerifyCredential(ctx, cred)
+	if outcome.HTTPStatus == 0 {
+		outcome.HTTPStatus = recorder.LastStatus()
+	}
+	return outcome
+}
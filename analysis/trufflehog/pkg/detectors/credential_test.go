@@ -0,0 +1,114 @@
+package detectors
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+func TestCredential_JSON(t *testing.T) {
+	cred := NewCredential(
+		CredentialPart{Name: CredentialPartSecret, Value: "s3cr3t"},
+		CredentialPart{Name: CredentialPartID, Value: "AKIA"},
+	)
+
+	data, err := json.Marshal(cred)
+	require.NoError(t, err)
+	// Parts keep their order instead of being sorted like map keys.
+	assert.JSONEq(t, `{"secret":"s3cr3t","id":"AKIA"}`, string(data))
+	assert.Equal(t, `{"secret":"s3cr3t","id":"AKIA"}`, string(data))
+
+	var decoded Credential
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, cred, decoded)
+	assert.Equal(t, "s3cr3t;-|AKIA", decoded.String())
+
+	assert.Error(t, json.Unmarshal([]byte(`["AKIA"]`), &decoded))
+	assert.Error(t, json.Unmarshal([]byte(`{"id":1}`), &decoded))
+}
+
+func TestParseCredential(t *testing.T) {
+	names := []CredentialPartName{CredentialPartID, CredentialPartSecret}
+	tests := []struct {
+		name    string
+		secret  string
+		want    Credential
+		wantErr bool
+	}{
+		{
+			name:   "legacy",
+			secret: "AKIA;-|s3cr3t",
+			want: NewCredential(
+				CredentialPart{Name: CredentialPartID, Value: "AKIA"},
+				CredentialPart{Name: CredentialPartSecret, Value: "s3cr3t"},
+			),
+		},
+		{
+			name:   "json",
+			secret: `{"secret":"s3cr3t","id":"AKIA"}`,
+			want: NewCredential(
+				CredentialPart{Name: CredentialPartSecret, Value: "s3cr3t"},
+				CredentialPart{Name: CredentialPartID, Value: "AKIA"},
+			),
+		},
+		{name: "legacy with missing part", secret: "AKIA", wantErr: true},
+		{name: "legacy with extra part", secret: "AKIA;-|s3cr3t;-|token", wantErr: true},
+		{name: "json with missing part", secret: `{"id":"AKIA"}`, wantErr: true},
+		{name: "invalid json", secret: `{"id":`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCredential(tt.secret, names...)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrMalformedSecret)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+type credentialVerifyDetector struct {
+	fakeDetector
+}
+
+func (credentialVerifyDetector) Type() detectorspb.DetectorType { return detectorspb.DetectorType_AWS }
+
+func (d credentialVerifyDetector) Verify(ctx context.Context, secret string) VerificationOutcome {
+	return VerifyLegacyCredential(ctx, d, secret)
+}
+
+func (credentialVerifyDetector) CredentialParts() []CredentialPartName {
+	return []CredentialPartName{CredentialPartID, CredentialPartSecret}
+}
+
+func (credentialVerifyDetector) VerifyCredential(_ context.Context, cred Credential) VerificationOutcome {
+	verified := cred.Get(CredentialPartID) == "AKIA" && cred.Get(CredentialPartSecret) == "s3cr3t"
+	return NewVerificationOutcome(verified, nil)
+}
+
+func TestVerifyCredential(t *testing.T) {
+	ctx := context.Background()
+	d := credentialVerifyDetector{}
+
+	// Parts are matched by name, regardless of their order.
+	cred := NewCredential(
+		CredentialPart{Name: CredentialPartSecret, Value: "s3cr3t"},
+		CredentialPart{Name: CredentialPartID, Value: "AKIA"},
+	)
+	assert.True(t, VerifyCredential(ctx, d, cred).Verified())
+	assert.True(t, d.Verify(ctx, "AKIA;-|s3cr3t").Verified())
+	assert.True(t, d.Verify(ctx, `{"secret":"s3cr3t","id":"AKIA"}`).Verified())
+
+	missing := NewCredential(CredentialPart{Name: CredentialPartID, Value: "AKIA"})
+	outcome := VerifyCredential(ctx, d, missing)
+	assert.Equal(t, VerificationStatusInvalid, outcome.Status)
+	assert.ErrorIs(t, outcome.Error, ErrMalformedSecret)
+	assert.ErrorIs(t, d.Verify(ctx, "AKIA").Error, ErrMalformedSecret)
+}
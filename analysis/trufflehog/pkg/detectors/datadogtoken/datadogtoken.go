@@ -124,6 +124,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 					"Type": "Application+APIKey",
 				},
 			}
+			s1.SetCredential(
+				detectors.CredentialPart{Name: detectors.CredentialPartToken, Value: resAppMatch},
+				detectors.CredentialPart{Name: detectors.CredentialPartKey, Value: resApiMatch},
+			)
 
 			if verify {
 				verified, err := s.verifyMatch(ctx, client, resAppMatch, resApiMatch)
@@ -160,19 +164,29 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	var appMatch, apiMatch string
-	if len(parts) == 1 {
-		apiMatch = parts[0]
-		appMatch = ""
-	} else {
-		appMatch = parts[0]
-		apiMatch = parts[1]
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	if !strings.Contains(secret, detectors.CredentialSeparator) && !strings.HasPrefix(secret, "{") {
+		// An API key found without an application key.
+		verified, err := s.verifyMatch(ctx, client, "", secret)
+		return detectors.NewVerificationOutcome(verified, err, secret)
 	}
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartToken, detectors.CredentialPartKey}
+}
 
-	verified, _ := s.verifyMatch(ctx, client, appMatch, apiMatch)
-	return verified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	verified, err := s.verifyMatch(ctx, client, cred.Get(detectors.CredentialPartToken), cred.Get(detectors.CredentialPartKey))
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
+}
+
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "api.datadoghq.com"}},
+		ReadOnly: true,
+	}
 }
 
 func (s Scanner) Type() detectorspb.DetectorType {
@@ -26,8 +26,10 @@ type Detector interface {
 	Type() detectorspb.DetectorType
 	// Description returns a description for the result being detected
 	Description() string
-
-	Verify(ctx context.Context, secret string) bool
+	// Verify verifies a previously detected secret and reports the outcome.
+	// The parts of multi-part secrets are joined with CredentialSeparator;
+	// see CredentialVerifier for verifying them by name.
+	Verify(ctx context.Context, secret string) VerificationOutcome
 }
 
 // CustomResultsCleaner is an optional interface that a detector can implement to customize how its generated results
@@ -97,6 +99,9 @@ type Result struct {
 	// RawV2 contains the raw secret identifier that is a combination of both the ID and the secret.
 	// This is used for secrets that are multi part and could have the same ID. Ex: AWS credentials
 	RawV2 []byte
+	// Credential contains the named parts of a multi-part secret. It is only
+	// set by detectors that implement CredentialVerifier.
+	Credential *Credential
 	// Redacted contains the redacted version of the raw secret identification data for display purposes.
 	// A secret ID should be used if available.
 	Redacted       string
@@ -113,6 +118,25 @@ type Result struct {
 	AnalysisInfo map[string]string
 }
 
+// SetCredential sets the named parts of a multi-part secret.
+func (r *Result) SetCredential(parts ...CredentialPart) {
+	cred := NewCredential(parts...)
+	r.Credential = &cred
+}
+
+// VerificationSecret returns the form of the secret that is verified: the
+// legacy form of its credential if it has one, otherwise RawV2 or Raw.
+func (r *Result) VerificationSecret() string {
+	switch {
+	case r.Credential != nil:
+		return r.Credential.String()
+	case len(r.RawV2) > 0:
+		return string(r.RawV2)
+	default:
+		return string(r.Raw)
+	}
+}
+
 // SetVerificationError is the only way to set a verification error. Any sensitive values should be passed-in as secrets to be redacted.
 func (r *Result) SetVerificationError(err error, secrets ...string) {
 	if err != nil {
@@ -130,6 +154,9 @@ func redactSecrets(err error, secrets ...string) error {
 	lastErr := unwrapToLast(err)
 	errStr := lastErr.Error()
 	for _, secret := range secrets {
+		if secret == "" {
+			continue
+		}
 		errStr = strings.Replace(errStr, secret, "[REDACTED]", -1)
 	}
 	return errors.New(errStr)
@@ -174,6 +201,13 @@ type ResultWithMetadata struct {
 	DecoderType detectorspb.DecoderType
 }
 
+// IsGeneric returns true if results of the detector type are found by a generic detector, one that recognizes
+// secrets by their context, such as the key they're assigned to, rather than by the format of a vendor's secrets.
+// Their results are reported apart from those of vendor detectors.
+func IsGeneric(detectorType detectorspb.DetectorType) bool {
+	return detectorType == detectorspb.DetectorType_Generic
+}
+
 // CopyMetadata returns a detector result with included metadata from the source chunk.
 func CopyMetadata(chunk *sources.Chunk, result Result) ResultWithMetadata {
 	return ResultWithMetadata{
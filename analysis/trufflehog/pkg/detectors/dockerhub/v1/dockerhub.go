@@ -71,6 +71,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 
 		for username := range usernames {
 			s1.RawV2 = []byte(fmt.Sprintf("%s:%s", token, username))
+			s1.SetCredential(
+				detectors.CredentialPart{Name: detectors.CredentialPartToken, Value: token},
+				detectors.CredentialPart{Name: detectors.CredentialPartUsername, Value: username},
+			)
 
 			if verify {
 				if s.client == nil {
@@ -98,17 +102,21 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 2 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartToken, detectors.CredentialPartUsername}
+}
+
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
 	if s.client == nil {
 		s.client = common.SaneHttpClient()
 	}
 
-	verified, _, _ := s.verifyMatch(ctx, parts[1], parts[0])
-	return verified
+	verified, extraData, err := s.verifyMatch(ctx, cred.Get(detectors.CredentialPartUsername), cred.Get(detectors.CredentialPartToken))
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...).WithExtraData(extraData)
 }
 
 func (s Scanner) verifyMatch(ctx context.Context, username string, password string) (bool, map[string]string, error) {
@@ -187,6 +195,13 @@ type mfaRequiredResponse struct {
 	MfaToken string `json:"login_2fa_token"`
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "POST", Host: "hub.docker.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_Dockerhub
 }
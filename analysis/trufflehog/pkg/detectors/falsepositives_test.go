@@ -18,8 +18,8 @@ func (d fakeDetector) FromData(ctx context.Context, verify bool, data []byte) ([
 	return nil, nil
 }
 
-func (s fakeDetector) Verify(ctx context.Context, secret string) bool {
-	return false
+func (s fakeDetector) Verify(ctx context.Context, secret string) VerificationOutcome {
+	return VerificationOutcome{Status: VerificationStatusInvalid}
 }
 
 func (d fakeDetector) Keywords() []string {
@@ -63,6 +63,11 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 					Raw:          []byte(resMatch),
 					RawV2:        []byte(resMatch + ";-|" + resServerMatch + ";-|" + resUserMatch),
 				}
+				s1.SetCredential(
+					detectors.CredentialPart{Name: detectors.CredentialPartToken, Value: resMatch},
+					detectors.CredentialPart{Name: detectors.CredentialPartDomain, Value: resServerMatch},
+					detectors.CredentialPart{Name: detectors.CredentialPartUsername, Value: resUserMatch},
+				)
 
 				if verify {
 					verified, err := verifyMatch(ctx, client, resMatch, resServerMatch, resUserMatch)
@@ -82,17 +87,33 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "*.formsite.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_Formsite
 }
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 3 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartToken, detectors.CredentialPartDomain, detectors.CredentialPartUsername}
+}
 
-	verified, _ := verifyMatch(ctx, client, parts[0], parts[1], parts[2])
-	return verified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	verified, err := verifyMatch(
+		ctx,
+		client,
+		cred.Get(detectors.CredentialPartToken),
+		cred.Get(detectors.CredentialPartDomain),
+		cred.Get(detectors.CredentialPartUsername),
+	)
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
 }
 
 func verifyMatch(ctx context.Context, client *http.Client, resMatch, resServerMatch, resUserMatch string) (bool, error) {
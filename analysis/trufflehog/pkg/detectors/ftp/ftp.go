@@ -131,11 +131,18 @@ func verifyFTP(timeout time.Duration, u *url.URL) error {
 	return c.Login(u.User.Username(), password)
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "ftp", Host: "*", HostFromData: true}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_FTP
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
 	timeout := s.verificationTimeout
 	if timeout == 0 {
 		timeout = defaultVerificationTimeout
@@ -143,11 +150,15 @@ func (s Scanner) Verify(ctx context.Context, secret string) bool {
 
 	parsedURL, err := url.Parse(secret)
 	if err != nil {
-		return false
+		return detectors.MalformedSecretOutcome()
 	}
 	verificationErr := verifyFTP(timeout, parsedURL)
+	if isErrDeterminate(verificationErr) {
+		return detectors.NewVerificationOutcome(false, nil)
+	}
 
-	return verificationErr == nil
+	password, _ := parsedURL.User.Password()
+	return detectors.NewVerificationOutcome(verificationErr == nil, verificationErr, password)
 }
 
 func (s Scanner) Description() string {
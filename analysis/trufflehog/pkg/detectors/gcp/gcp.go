@@ -4,19 +4,26 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
+	"errors"
 	"strconv"
 	"strings"
 
 	regexp "github.com/wasilibs/go-re2"
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
 )
 
 type Scanner struct{}
 
+// tokenClient fetches access tokens for the keys being verified. Unlike the
+// oauth2 package's default client, it is rate limited and recorded in the
+// verification audit log.
+var tokenClient = common.SaneHttpClient()
+
 // Ensure the Scanner satisfies the interface at compile time.
 var _ interface {
 	detectors.Detector
@@ -58,22 +65,34 @@ const startOffset = 4096
 // StartOffset returns the start offset for the secret this detector finds.
 func (Scanner) StartOffset() int64 { return startOffset }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "POST", Host: "oauth2.googleapis.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_GCP
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	credentials, err := google.CredentialsFromJSON(ctx, []byte(secret), "https://www.googleapis.com/auth/cloud-platform")
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	credentials, err := google.CredentialsFromJSON(context.WithValue(ctx, oauth2.HTTPClient, tokenClient), []byte(secret), "https://www.googleapis.com/auth/cloud-platform")
 	if err != nil {
-		fmt.Println(err)
-		return false
+		return detectors.MalformedSecretOutcome()
 	}
 
 	if _, err = credentials.TokenSource.Token(); err != nil {
-		fmt.Println(err)
-		return false
+		// The token endpoint rejects revoked or deleted keys with a 4xx response.
+		var retrieveErr *oauth2.RetrieveError
+		if errors.As(err, &retrieveErr) && retrieveErr.Response != nil && retrieveErr.Response.StatusCode < 500 {
+			outcome := detectors.NewVerificationOutcome(false, nil)
+			outcome.HTTPStatus = retrieveErr.Response.StatusCode
+			return outcome
+		}
+		return detectors.NewVerificationOutcome(false, err)
 	}
-	return true
+	return detectors.NewVerificationOutcome(true, nil)
 
 }
 
@@ -141,7 +160,7 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 
 		if verify {
 
-			credentials, err := google.CredentialsFromJSON(ctx, credBytes, "https://www.googleapis.com/auth/cloud-platform")
+			credentials, err := google.CredentialsFromJSON(context.WithValue(ctx, oauth2.HTTPClient, tokenClient), credBytes, "https://www.googleapis.com/auth/cloud-platform")
 			if err != nil {
 				continue
 			}
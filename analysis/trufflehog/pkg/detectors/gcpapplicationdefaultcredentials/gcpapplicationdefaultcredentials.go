@@ -107,8 +107,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 }
 
 func verifyMatch(ctx context.Context, client *http.Client, token string) (bool, map[string]string, error) {
-	// First load the credential from the found key
-	credentials, err := google.CredentialsFromJSON(ctx, []byte(token), "https://www.googleapis.com/auth/cloud-platform")
+	// First load the credential from the found key. The token is fetched with
+	// defaultClient so the request is rate limited and audited.
+	tokenCtx := context.WithValue(ctx, oauth2.HTTPClient, defaultClient)
+	credentials, err := google.CredentialsFromJSON(tokenCtx, []byte(token), "https://www.googleapis.com/auth/cloud-platform")
 	if err != nil {
 		return false, nil, err
 	}
@@ -146,8 +148,12 @@ func verifyMatch(ctx context.Context, client *http.Client, token string) (bool,
 
 	// If we are not using a faketransport, leave it as is because the test wants to modify the response. Otherwise, set the retrieved token to the client.
 	if _, ok := client.Transport.(common.FakeTransport); !ok {
-		client.Transport = &oauth2.Transport{
-			Source: credentials.TokenSource,
+		client = &http.Client{
+			Transport: &oauth2.Transport{
+				Source: credentials.TokenSource,
+				Base:   client.Transport,
+			},
+			Timeout: client.Timeout,
 		}
 	}
 
@@ -172,13 +178,20 @@ func verifyMatch(ctx context.Context, client *http.Client, token string) (bool,
 	}
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "iam.googleapis.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_GCPApplicationDefaultCredentials
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	isVerified, _, _ := verifyMatch(ctx, defaultClient, string(secret))
-	return isVerified
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	isVerified, extraData, err := verifyMatch(ctx, defaultClient, string(secret))
+	return detectors.NewVerificationOutcome(isVerified, err, secret).WithExtraData(extraData)
 }
 
 func (s Scanner) Description() string {
@@ -114,8 +114,8 @@ func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_Generic
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	return false
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerificationNotSupportedOutcome()
 }
 
 func (s Scanner) Description() string {
@@ -20,6 +20,7 @@ var _ detectors.Detector = (*Scanner)(nil)
 var _ detectors.Versioner = (*Scanner)(nil)
 var _ detectors.EndpointCustomizer = (*Scanner)(nil)
 var _ detectors.CloudProvider = (*Scanner)(nil)
+var _ detectors.ResultVerifier = (*Scanner)(nil)
 
 func (Scanner) Version() int          { return 1 }
 func (Scanner) CloudEndpoint() string { return "https://api.github.com" }
@@ -113,10 +114,24 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
 	client := common.SaneHttpClient()
-	verified, _, _, _ := s.VerifyGithub(ctx, client, secret)
-	return verified
+	verified, userResponse, headers, err := s.VerifyGithub(ctx, client, secret)
+
+	r := detectors.Result{ExtraData: map[string]string{}}
+	if userResponse != nil {
+		SetUserResponse(userResponse, &r)
+	}
+	if headers != nil {
+		SetHeaderInfo(headers, &r)
+	}
+	return detectors.NewVerificationOutcome(verified, err, secret).WithExtraData(r.ExtraData)
+}
+
+// VerifiesResults returns true because Verify sends the same request for a
+// token as FromData.
+func (s Scanner) VerifiesResults() bool {
+	return true
 }
 
 func (s Scanner) VerifyGithub(ctx context.Context, client *http.Client, token string) (bool, *UserRes, *HeaderInfo, error) {
@@ -189,6 +204,13 @@ func SetHeaderInfo(headers *HeaderInfo, s1 *detectors.Result) {
 	}
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "api.github.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_Github
 }
@@ -21,6 +21,7 @@ var _ detectors.Detector = (*Scanner)(nil)
 var _ detectors.Versioner = (*Scanner)(nil)
 var _ detectors.EndpointCustomizer = (*Scanner)(nil)
 var _ detectors.CloudProvider = (*Scanner)(nil)
+var _ detectors.ResultVerifier = (*Scanner)(nil)
 
 func (s Scanner) Version() int {
 	return 2
@@ -90,10 +91,31 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
 	client := common.SaneHttpClient()
-	verified, _, _, _ := s.VerifyGithub(ctx, client, secret)
-	return verified
+	verified, userResponse, headers, err := s.VerifyGithub(ctx, client, secret)
+
+	r := detectors.Result{ExtraData: map[string]string{}}
+	if userResponse != nil {
+		v1.SetUserResponse(userResponse, &r)
+	}
+	if headers != nil {
+		v1.SetHeaderInfo(headers, &r)
+	}
+	return detectors.NewVerificationOutcome(verified, err, secret).WithExtraData(r.ExtraData)
+}
+
+// VerifiesResults returns true because Verify sends the same request for a
+// token as FromData.
+func (s Scanner) VerifiesResults() bool {
+	return true
+}
+
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "api.github.com"}},
+		ReadOnly: true,
+	}
 }
 
 func (s Scanner) Type() detectorspb.DetectorType {
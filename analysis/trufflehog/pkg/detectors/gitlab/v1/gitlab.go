@@ -88,9 +88,9 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	verified, _, _ := s.verifyGitlab(ctx, secret)
-	return verified
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	verified, extraData, err := s.verifyGitlab(ctx, secret)
+	return detectors.NewVerificationOutcome(verified, err, secret).WithExtraData(extraData)
 }
 
 func (s Scanner) verifyGitlab(ctx context.Context, resMatch string) (bool, map[string]string, error) {
@@ -152,6 +152,13 @@ func (s Scanner) verifyGitlab(ctx context.Context, resMatch string) (bool, map[s
 	return false, nil, nil
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "gitlab.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_Gitlab
 }
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"strings"
 
 	regexp "github.com/wasilibs/go-re2"
 
@@ -50,13 +49,22 @@ func (s Scanner) Keywords() []string {
 	return []string{"godaddy", "ote"}
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 2 {
-		return false
-	}
-	isVerified, _ := VerifyGoDaddySecret(ctx, s.getClient(), ote, MakeAuthHeaderValue(parts[0], parts[1]))
-	return isVerified
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartID, detectors.CredentialPartSecret}
+}
+
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	isVerified, err := VerifyGoDaddySecret(
+		ctx,
+		s.getClient(),
+		ote,
+		MakeAuthHeaderValue(cred.Get(detectors.CredentialPartID), cred.Get(detectors.CredentialPartSecret)),
+	)
+	return detectors.NewVerificationOutcome(isVerified, err, cred.Values()...)
 }
 
 func (s Scanner) Description() string {
@@ -65,6 +73,13 @@ func (s Scanner) Description() string {
 		"GoDaddy provides applications and access to relevant third-party products and platforms to connect their customers"
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "api.ote-godaddy.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_GoDaddy
 }
@@ -93,6 +108,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 				RawV2:        []byte(key + secret),
 				ExtraData:    make(map[string]string),
 			}
+			result.SetCredential(
+				detectors.CredentialPart{Name: detectors.CredentialPartID, Value: key},
+				detectors.CredentialPart{Name: detectors.CredentialPartSecret, Value: secret},
+			)
 
 			if verify {
 
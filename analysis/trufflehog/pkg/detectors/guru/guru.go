@@ -61,6 +61,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 				Raw:          []byte(unameMatch),
 				RawV2:        []byte(unameMatch + keyMatch),
 			}
+			s1.SetCredential(
+				detectors.CredentialPart{Name: detectors.CredentialPartUsername, Value: unameMatch},
+				detectors.CredentialPart{Name: detectors.CredentialPartToken, Value: keyMatch},
+			)
 
 			if verify {
 				verified, err := verifyMatch(ctx, client, unameMatch, keyMatch)
@@ -82,14 +86,22 @@ func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_Guru
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 2 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartUsername, detectors.CredentialPartToken}
+}
 
-	verified, _ := verifyMatch(ctx, client, parts[0], parts[1])
-	return verified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	verified, err := verifyMatch(
+		ctx,
+		client,
+		cred.Get(detectors.CredentialPartUsername),
+		cred.Get(detectors.CredentialPartToken),
+	)
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
 }
 
 func verifyMatch(ctx context.Context, client *http.Client, unameMatch, keyMatch string) (bool, error) {
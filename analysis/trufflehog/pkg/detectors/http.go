@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/feature"
 )
 
@@ -54,7 +55,44 @@ type detectorTransport struct {
 
 func (t *detectorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	req.Header.Add("User-Agent", userAgent())
-	return t.T.RoundTrip(req)
+	return rateLimiter.Load().roundTrip(req, func(req *http.Request) (*http.Response, error) {
+		start := time.Now()
+		res, err := t.T.RoundTrip(req)
+		common.RecordResponse(req, res)
+		recordAudit(req, res, err, start)
+		return res, err
+	})
+}
+
+// InstallCommonClientHook makes the clients of the common package, which some
+// detectors verify secrets with, send their verification requests through the
+// RateLimiter and record them in the audit log of their context. The hook is
+// global and also sees the requests of sources, so it's only installed by
+// programs that rate limit or audit verification. The returned function
+// removes it.
+func InstallCommonClientHook() (uninstall func()) {
+	common.SetRoundTripHook(verificationRoundTrip)
+	return func() { common.SetRoundTripHook(nil) }
+}
+
+// verificationRoundTrip schedules the requests that the clients of the common
+// package send to verify secrets with the RateLimiter, and records them in the
+// audit log of their context. It fires for every request of those clients.
+// Verification requests are the ones sent with a RateLimitScope; sources use
+// the same clients and aren't held back, and their requests are only recorded
+// if their context carries an audit log, which it doesn't outside of
+// verification.
+func verificationRoundTrip(req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	l := rateLimiter.Load()
+	if _, ok := req.Context().Value(rateLimitScopeKey{}).(*RateLimitScope); !ok {
+		l = nil
+	}
+	return l.roundTrip(req, func(req *http.Request) (*http.Response, error) {
+		start := time.Now()
+		res, err := send(req)
+		recordAudit(req, res, err, start)
+		return res, err
+	})
 }
 
 var defaultDialer = &net.Dialer{
@@ -267,15 +267,22 @@ func pingErr(ctx context.Context, driverName, conn string) error {
 	return nil
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "jdbc", Host: "*", HostFromData: true}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_JDBC
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
 	logCtx := logContext.AddLogger(ctx)
 
-	verified, _ := verifyMatch(ctx, logCtx, secret)
-	return verified
+	verified, err := verifyMatch(ctx, logCtx, secret)
+	return detectors.NewVerificationOutcome(verified, err, secret)
 }
 
 func verifyMatch(ctx context.Context, logCtx logContext.Context, resMatch string) (bool, error) {
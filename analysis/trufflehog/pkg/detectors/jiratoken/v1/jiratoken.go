@@ -78,6 +78,11 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 						"version":        fmt.Sprintf("%d", s.Version()),
 					},
 				}
+				s1.SetCredential(
+					detectors.CredentialPart{Name: detectors.CredentialPartEmail, Value: resEmail},
+					detectors.CredentialPart{Name: detectors.CredentialPartToken, Value: resToken},
+					detectors.CredentialPart{Name: detectors.CredentialPartDomain, Value: resDomain},
+				)
 
 				if verify {
 
@@ -131,18 +136,34 @@ func verifyJiratoken(ctx context.Context, client *http.Client, email, domain, to
 	return false, nil
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "*", HostFromData: true}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_JiraToken
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 2 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartEmail, detectors.CredentialPartToken, detectors.CredentialPartDomain}
+}
 
-	isVerified, _ := verifyJiratoken(ctx, defaultClient, parts[0], parts[1], parts[2])
-	return isVerified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	isVerified, err := verifyJiratoken(
+		ctx,
+		defaultClient,
+		cred.Get(detectors.CredentialPartEmail),
+		cred.Get(detectors.CredentialPartDomain),
+		cred.Get(detectors.CredentialPartToken),
+	)
+	return detectors.NewVerificationOutcome(isVerified, err, cred.Values()...)
 }
 
 func (s Scanner) Description() string {
@@ -70,6 +70,11 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 					Raw:          []byte(resKeyMatch),
 					RawV2:        []byte(resKeyMatch + ";-|" + resPassphraseMatch + ";-|" + resSecretMatch),
 				}
+				s1.SetCredential(
+					detectors.CredentialPart{Name: detectors.CredentialPartKey, Value: resKeyMatch},
+					detectors.CredentialPart{Name: detectors.CredentialPartPassphrase, Value: resPassphraseMatch},
+					detectors.CredentialPart{Name: detectors.CredentialPartSecret, Value: resSecretMatch},
+				)
 
 				if verify {
 					verified, err := verifyMatch(ctx, client, resKeyMatch, resPassphraseMatch, resSecretMatch)
@@ -105,18 +110,34 @@ func getKucoinSignature(apiSecret string, timestamp string, method string, endpo
 	return base64.StdEncoding.EncodeToString(macsum)
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "api.kucoin.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_KuCoin
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 3 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartKey, detectors.CredentialPartPassphrase, detectors.CredentialPartSecret}
+}
 
-	verified, _ := verifyMatch(ctx, client, parts[0], parts[1], parts[2])
-	return verified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	verified, err := verifyMatch(
+		ctx,
+		client,
+		cred.Get(detectors.CredentialPartKey),
+		cred.Get(detectors.CredentialPartPassphrase),
+		cred.Get(detectors.CredentialPartSecret),
+	)
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
 }
 
 func verifyMatch(ctx context.Context, client *http.Client, resKeyMatch, resPassphraseMatch, resSecretMatch string) (bool, error) {
@@ -3,6 +3,7 @@ package larksuite
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -93,19 +94,30 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "open.larksuite.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_LarkSuite
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	var errs []error
 	for _, url := range verificationUrls {
-		isVerified, _ := verifyAccessToken(ctx, defaultClient, url, secret)
+		isVerified, err := verifyAccessToken(ctx, defaultClient, url, secret)
 		if isVerified {
-			return true
+			return detectors.NewVerificationOutcome(true, nil)
+		}
+		if err != nil {
+			errs = append(errs, err)
 		}
 	}
 
-	return false
+	return detectors.NewVerificationOutcome(false, errors.Join(errs...), secret)
 }
 
 func (s Scanner) Description() string {
@@ -71,6 +71,11 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 					Raw:          []byte(strings.Join([]string{ldapURL.String(), username[1], password[1]}, "\t")),
 					RawV2:        []byte(strings.Join([]string{username[1], password[1], ldapURL.String()}, ";-|")),
 				}
+				s1.SetCredential(
+					detectors.CredentialPart{Name: detectors.CredentialPartUsername, Value: username[1]},
+					detectors.CredentialPart{Name: detectors.CredentialPartPassword, Value: password[1]},
+					detectors.CredentialPart{Name: detectors.CredentialPartEndpoint, Value: ldapURL.String()},
+				)
 
 				if verify {
 
@@ -103,6 +108,11 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 			Raw:          []byte(strings.Join([]string{ldapURL.String(), username, password}, "\t")),
 			RawV2:        []byte(strings.Join([]string{username, password, ldapURL.String()}, ";-|")),
 		}
+		s1.SetCredential(
+			detectors.CredentialPart{Name: detectors.CredentialPartUsername, Value: username},
+			detectors.CredentialPart{Name: detectors.CredentialPartPassword, Value: password},
+			detectors.CredentialPart{Name: detectors.CredentialPartEndpoint, Value: ldapURL.String()},
+		)
 
 		if verify {
 
@@ -173,23 +183,37 @@ func verifyLDAP(username, password string, ldapURL *url.URL) error {
 
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "ldap", Host: "*", HostFromData: true}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_LDAP
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 3 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartUsername, detectors.CredentialPartPassword, detectors.CredentialPartEndpoint}
+}
 
-	ldapURL, err := url.Parse(parts[2])
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	ldapURL, err := url.Parse(cred.Get(detectors.CredentialPartEndpoint))
 	if err != nil {
-		return false
+		return detectors.MalformedSecretOutcome()
 	}
 
-	verificationErr := verifyLDAP(parts[0], parts[1], ldapURL)
-	return verificationErr == nil
+	password := cred.Get(detectors.CredentialPartPassword)
+	verificationErr := verifyLDAP(cred.Get(detectors.CredentialPartUsername), password, ldapURL)
+	if isErrDeterminate(verificationErr) {
+		return detectors.NewVerificationOutcome(verificationErr == nil, nil)
+	}
+	return detectors.NewVerificationOutcome(false, verificationErr, password)
 }
 
 func (s Scanner) Description() string {
@@ -104,13 +104,20 @@ func (s Scanner) verify(ctx context.Context, client *http.Client, key string) (b
 	}
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "POST", Host: "secret-scanning.maxmind.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_MaxMindLicense
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	verified, _ := s.verify(ctx, defaultClient, secret)
-	return verified
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	verified, err := s.verify(ctx, defaultClient, secret)
+	return detectors.NewVerificationOutcome(verified, err, secret)
 }
 
 func (s Scanner) Description() string {
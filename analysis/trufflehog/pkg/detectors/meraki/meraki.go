@@ -48,9 +48,9 @@ func (s Scanner) Keywords() []string {
 	return []string{"meraki"}
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	_, isVerified, _ := verifyMerakiApiKey(ctx, s.getClient(), secret)
-	return isVerified
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	_, isVerified, err := verifyMerakiApiKey(ctx, s.getClient(), secret)
+	return detectors.NewVerificationOutcome(isVerified, err, secret)
 }
 
 func (s Scanner) Description() string {
@@ -100,6 +100,13 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "api.meraki.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_Meraki
 }
@@ -104,13 +104,21 @@ func verifyWebhook(ctx context.Context, client *http.Client, webhookURL string)
 	}
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		// The empty message that is sent is rejected without being posted.
+		Requests: []detectors.VerificationRequest{{Method: "POST", Host: "*.webhook.office.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_MicrosoftTeamsWebhook
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	isVerified, _ := verifyWebhook(ctx, defaultClient, secret)
-	return isVerified
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	isVerified, err := verifyWebhook(ctx, defaultClient, secret)
+	return detectors.NewVerificationOutcome(isVerified, err, secret)
 }
 
 func (s Scanner) Description() string {
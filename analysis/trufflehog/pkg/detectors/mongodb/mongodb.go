@@ -114,14 +114,14 @@ func (s Scanner) IsFalsePositive(_ detectors.Result) (bool, string) {
 	return false, ""
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
 	timeout := s.timeout
 	if timeout == 0 {
 		timeout = defaultTimeout
 	}
 
-	isVerified, _ := verifyUri(ctx, secret, timeout)
-	return isVerified
+	isVerified, err := verifyUri(ctx, secret, timeout)
+	return detectors.NewVerificationOutcome(isVerified, err, secret)
 }
 
 func verifyMatch(ctx context.Context, client *http.Client, resMatch string) (bool, error) {
@@ -159,6 +159,13 @@ func verifyUri(ctx context.Context, connStr string, timeout time.Duration) (bool
 	return err == nil, err
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "mongodb", Host: "*", HostFromData: true}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_MongoDB
 }
@@ -90,6 +90,13 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 							Raw:          []byte(consumerKey),
 							RawV2:        []byte(consumerKey + ";-|" + consumerSecret + ";-|" + tokenKey + ";-|" + tokenSecret + ";-|" + accountID),
 						}
+						s1.SetCredential(
+							detectors.CredentialPart{Name: detectors.CredentialPartKey, Value: consumerKey},
+							detectors.CredentialPart{Name: detectors.CredentialPartSecret, Value: consumerSecret},
+							detectors.CredentialPart{Name: detectors.CredentialPartToken, Value: tokenKey},
+							detectors.CredentialPart{Name: detectors.CredentialPartTokenSecret, Value: tokenSecret},
+							detectors.CredentialPart{Name: detectors.CredentialPartAccount, Value: accountID},
+						)
 
 						if verify {
 
@@ -114,25 +121,35 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "*.suitetalk.api.netsuite.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_Netsuite
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 5 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartKey, detectors.CredentialPartSecret, detectors.CredentialPartToken, detectors.CredentialPartTokenSecret, detectors.CredentialPartAccount}
+}
 
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
 	cs := credentialSet{
-		consumerKey:    parts[0],
-		consumerSecret: parts[1],
-		tokenKey:       parts[2],
-		tokenSecret:    parts[3],
-		accountID:      parts[4],
+		consumerKey:    cred.Get(detectors.CredentialPartKey),
+		consumerSecret: cred.Get(detectors.CredentialPartSecret),
+		tokenKey:       cred.Get(detectors.CredentialPartToken),
+		tokenSecret:    cred.Get(detectors.CredentialPartTokenSecret),
+		accountID:      cred.Get(detectors.CredentialPartAccount),
 	}
-	verified, _ := verifyCredentials(ctx, defaultClient, cs)
-	return verified
+	verified, err := verifyCredentials(ctx, defaultClient, cs)
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
 }
 
 func (s Scanner) Description() string {
@@ -100,13 +100,20 @@ func verifyMatch(ctx context.Context, client *http.Client, token string) (bool,
 	}
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "POST", Host: "api.ngc.nvidia.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_NVAPI
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	isVerified, _, _ := verifyMatch(ctx, defaultClient, secret)
-	return isVerified
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	isVerified, extraData, err := verifyMatch(ctx, defaultClient, secret)
+	return detectors.NewVerificationOutcome(isVerified, err, secret).WithExtraData(extraData)
 }
 
 func (s Scanner) Description() string {
@@ -45,6 +45,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 				Raw:          []byte(token),
 				RawV2:        []byte(fmt.Sprintf("%s%s", token, domain)),
 			}
+			result.SetCredential(
+				detectors.CredentialPart{Name: detectors.CredentialPartToken, Value: token},
+				detectors.CredentialPart{Name: detectors.CredentialPartDomain, Value: domain},
+			)
 
 			if verify {
 				verified, err := verifyMatch(ctx, token, domain)
@@ -62,18 +66,32 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{
+			{Method: "GET", Host: "*.okta.com"},
+			{Method: "GET", Host: "*.oktapreview.com"},
+			{Method: "GET", Host: "*.okta-emea.com"},
+		},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_Okta
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 2 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartToken, detectors.CredentialPartDomain}
+}
 
-	verified, _ := verifyMatch(ctx, parts[0], parts[1])
-	return verified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	verified, err := verifyMatch(ctx, cred.Get(detectors.CredentialPartToken), cred.Get(detectors.CredentialPartDomain))
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
 }
 
 func verifyMatch(ctx context.Context, token, domain string) (bool, error) {
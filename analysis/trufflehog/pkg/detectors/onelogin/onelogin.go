@@ -56,6 +56,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 				RawV2:        []byte(fmt.Sprintf("%s;-|%s", clientID[1], clientSecret[1])),
 				Redacted:     clientID[1],
 			}
+			result.SetCredential(
+				detectors.CredentialPart{Name: detectors.CredentialPartID, Value: clientID[1]},
+				detectors.CredentialPart{Name: detectors.CredentialPartSecret, Value: clientSecret[1]},
+			)
 
 			if verify {
 				verified, err := verifyMatch(ctx, clientID[1], clientSecret[1])
@@ -73,18 +77,31 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{
+			{Method: "POST", Host: "api.us.onelogin.com"},
+			{Method: "POST", Host: "api.eu.onelogin.com"},
+		},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_OneLogin
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 2 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartID, detectors.CredentialPartSecret}
+}
 
-	verified, _ := verifyMatch(ctx, parts[0], parts[1])
-	return verified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	verified, err := verifyMatch(ctx, cred.Get(detectors.CredentialPartID), cred.Get(detectors.CredentialPartSecret))
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
 }
 func verifyMatch(ctx context.Context, clientID, clientSecret string) (bool, error) {
 	for _, domain := range apiDomains {
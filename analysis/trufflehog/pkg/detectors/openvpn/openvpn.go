@@ -55,6 +55,11 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 					Raw:          []byte(clientSecretRes),
 					RawV2:        []byte(clientSecretRes + ";-|" + clientIDRes + ";-|" + domainRes),
 				}
+				s1.SetCredential(
+					detectors.CredentialPart{Name: detectors.CredentialPartSecret, Value: clientSecretRes},
+					detectors.CredentialPart{Name: detectors.CredentialPartID, Value: clientIDRes},
+					detectors.CredentialPart{Name: detectors.CredentialPartDomain, Value: domainRes},
+				)
 
 				if verify {
 
@@ -80,18 +85,34 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "POST", Host: "*.api.openvpn.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_OpenVpn
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 3 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartSecret, detectors.CredentialPartID, detectors.CredentialPartDomain}
+}
 
-	verified, _ := verifyMatch(ctx, defaultClient, parts[0], parts[1], parts[2])
-	return verified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	verified, err := verifyMatch(
+		ctx,
+		defaultClient,
+		cred.Get(detectors.CredentialPartSecret),
+		cred.Get(detectors.CredentialPartID),
+		cred.Get(detectors.CredentialPartDomain),
+	)
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
 }
 
 func verifyMatch(ctx context.Context, client *http.Client, clientSecretRes, clientIDRes, domainRes string) (bool, error) {
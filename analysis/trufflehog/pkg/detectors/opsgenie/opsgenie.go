@@ -28,6 +28,13 @@ var (
 	keyPat = regexp.MustCompile(detectors.PrefixRegex([]string{"opsgenie"}) + `\b([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})\b`)
 )
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "api.opsgenie.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_Opsgenie
 }
@@ -40,9 +47,9 @@ func (s Scanner) Keywords() []string {
 
 // Description returns a description for the result being detected
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	isVerified, _, _ := verifyMatch(ctx, defaultClient, secret)
-	return isVerified
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	isVerified, extraData, err := verifyMatch(ctx, defaultClient, secret)
+	return detectors.NewVerificationOutcome(isVerified, err, secret).WithExtraData(extraData)
 }
 
 func (s Scanner) Description() string {
@@ -47,6 +47,11 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 					Raw:          []byte(strings.Join([]string{username[0], password[0], host}, "\t")),
 					RawV2:        []byte(strings.Join([]string{username[0], password[0], host}, ";-|")),
 				}
+				s1.SetCredential(
+					detectors.CredentialPart{Name: detectors.CredentialPartUsername, Value: username[0]},
+					detectors.CredentialPart{Name: detectors.CredentialPartPassword, Value: password[0]},
+					detectors.CredentialPart{Name: detectors.CredentialPartDomain, Value: host},
+				)
 
 				if verify {
 					verified, err := verifyMatch(ctx, username[0], password[0], host)
@@ -65,18 +70,33 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "mysql", Host: "*.connect.psdb.cloud"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_PlanetScaleDb
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 3 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartUsername, detectors.CredentialPartPassword, detectors.CredentialPartDomain}
+}
 
-	verified, _ := verifyMatch(ctx, parts[0], parts[1], parts[3])
-	return verified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	verified, err := verifyMatch(
+		ctx,
+		cred.Get(detectors.CredentialPartUsername),
+		cred.Get(detectors.CredentialPartPassword),
+		cred.Get(detectors.CredentialPartDomain),
+	)
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
 }
 func verifyMatch(ctx context.Context, username, password, host string) (bool, error) {
 	cfg := mysql.Config{
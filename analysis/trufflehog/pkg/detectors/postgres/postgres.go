@@ -254,21 +254,36 @@ func verifyPostgres(params map[string]string) (bool, error) {
 	}
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "postgres", Host: "*", HostFromData: true}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_Postgres
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	var (
+		errs      []error
+		passwords []string
+	)
 	matching_canidats := findUriMatches([]byte(secret))
 	for _, params := range matching_canidats {
 
-		isVerified, _ := verifyPostgres(params)
+		isVerified, err := verifyPostgres(params)
 		if isVerified {
-			return true
+			return detectors.NewVerificationOutcome(true, nil)
+		}
+		if err != nil {
+			errs = append(errs, err)
+			passwords = append(passwords, params[pgPassword])
 		}
 	}
 
-	return false
+	return detectors.NewVerificationOutcome(false, errors.Join(errs...), passwords...)
 }
 
 func (s Scanner) Description() string {
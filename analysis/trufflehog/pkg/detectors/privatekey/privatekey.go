@@ -104,16 +104,16 @@ func (s Scanner) IsFalsePositive(_ detectors.Result) (bool, string) {
 	return false, ""
 }
 
-func (s Scanner) Verify(ctx context.Context, token string) bool {
+func (s Scanner) Verify(ctx context.Context, token string) detectors.VerificationOutcome {
 	parsedKey, err := ssh.ParseRawPrivateKey([]byte(token))
 	if err != nil && strings.Contains(err.Error(), "private key is passphrase protected") {
 		parsedKey, _, err = crack([]byte(token))
-		if err != nil {
-			return false
-		}
 	}
-	verified, _, _ := s.verifyMatch(ctx, client, parsedKey)
-	return verified
+	if err != nil {
+		return detectors.MalformedSecretOutcome()
+	}
+	verified, err, extra := s.verifyMatch(ctx, client, parsedKey)
+	return detectors.NewVerificationOutcome(verified, err, token).WithExtraData(extra)
 }
 
 func (s Scanner) verifyMatch(ctx context.Context, client *http.Client, parsedKey interface{}) (bool, error, map[string]string) {
@@ -273,6 +273,17 @@ func (e *verificationErrors) Add(err error) {
 	e.mutex.Unlock()
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{
+			{Method: "GET", Host: "keychecker.trufflesecurity.com"},
+			{Method: "ssh", Host: "github.com"},
+			{Method: "ssh", Host: "gitlab.com"},
+		},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_PrivateKey
 }
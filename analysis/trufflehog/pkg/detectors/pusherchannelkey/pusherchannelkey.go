@@ -77,6 +77,11 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 					Raw:          []byte(resappMatch),
 					RawV2:        []byte(resappMatch + ";-|" + reskeyMatch + ";-|" + ressecretMatch),
 				}
+				s1.SetCredential(
+					detectors.CredentialPart{Name: detectors.CredentialPartID, Value: resappMatch},
+					detectors.CredentialPart{Name: detectors.CredentialPartKey, Value: reskeyMatch},
+					detectors.CredentialPart{Name: detectors.CredentialPartSecret, Value: ressecretMatch},
+				)
 
 				if verify {
 					verified, err := verifyMatch(ctx, client, resappMatch, reskeyMatch, ressecretMatch)
@@ -102,18 +107,34 @@ func hmacBytes(toSign, secret []byte) []byte {
 	return _authSignature.Sum(nil)
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		// Verifying triggers an event on a channel.
+		Requests: []detectors.VerificationRequest{{Method: "POST", Host: "api-ap1.pusher.com"}},
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_PusherChannelKey
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 3 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartID, detectors.CredentialPartKey, detectors.CredentialPartSecret}
+}
 
-	verified, _ := verifyMatch(ctx, client, parts[0], parts[1], parts[2])
-	return verified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	verified, err := verifyMatch(
+		ctx,
+		client,
+		cred.Get(detectors.CredentialPartID),
+		cred.Get(detectors.CredentialPartKey),
+		cred.Get(detectors.CredentialPartSecret),
+	)
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
 }
 func verifyMatch(ctx context.Context, client *http.Client, resappMatch, reskeyMatch, ressecretMatch string) (bool, error) {
 
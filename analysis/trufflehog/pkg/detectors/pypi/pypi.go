@@ -121,13 +121,21 @@ func verifyMatch(ctx context.Context, client *http.Client, token string) (bool,
 	return false, nil, fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		// The upload that is sent isn't a distribution, so it's rejected.
+		Requests: []detectors.VerificationRequest{{Method: "POST", Host: "upload.pypi.org"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_PyPI
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	verified, _, _ := verifyMatch(ctx, defaultClient, secret)
-	return verified
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	verified, extraData, err := verifyMatch(ctx, defaultClient, secret)
+	return detectors.NewVerificationOutcome(verified, err, secret).WithExtraData(extraData)
 }
 
 func (s Scanner) Description() string {
@@ -0,0 +1,285 @@
+package detectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/roundtripper"
+)
+
+// RateLimit is a token bucket for the verification requests sent to a host.
+type RateLimit struct {
+	// Rate is the sustained number of requests per second. Zero means no
+	// limit.
+	Rate float64
+	// Burst is the number of requests that may be sent at once. It defaults to
+	// the rate, rounded up.
+	Burst int
+}
+
+// ParseRateLimit parses a rate limit of the form RATE[:BURST], where RATE is
+// the number of requests per second.
+func ParseRateLimit(s string) (RateLimit, error) {
+	rateStr, burstStr, hasBurst := strings.Cut(strings.TrimSpace(s), ":")
+	var limit RateLimit
+	var err error
+	if limit.Rate, err = strconv.ParseFloat(rateStr, 64); err != nil || limit.Rate < 0 {
+		return RateLimit{}, fmt.Errorf("invalid rate limit %q: rate must be a non-negative number", s)
+	}
+	if hasBurst {
+		if limit.Burst, err = strconv.Atoi(burstStr); err != nil || limit.Burst < 1 {
+			return RateLimit{}, fmt.Errorf("invalid rate limit %q: burst must be a positive integer", s)
+		}
+	}
+	return limit, nil
+}
+
+func (l RateLimit) limiter() *rate.Limiter {
+	if l.Rate == 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+	burst := l.Burst
+	if burst < 1 {
+		burst = int(math.Ceil(l.Rate))
+	}
+	return rate.NewLimiter(rate.Limit(l.Rate), burst)
+}
+
+// ErrRateLimited is returned for verification requests that can't be sent
+// before their deadline because of a rate limit.
+var ErrRateLimited = errors.New("verification request rate limited")
+
+const (
+	// defaultRetryAfter is how long a host that answers 429 or 503 without a
+	// Retry-After header is left alone.
+	defaultRetryAfter = 10 * time.Second
+	// maxRetryWait is the longest a request waits to be retried. Candidates
+	// whose requests would wait longer are verified again later.
+	maxRetryWait = 10 * time.Second
+)
+
+// RateLimiter schedules the verification requests of every detector HTTP
+// client. It keeps a token bucket per destination host, and holds requests to
+// a host back for as long as the host asks with 429 and 503 responses.
+type RateLimiter struct {
+	defaultLimit   RateLimit
+	detectorLimits map[detectorspb.DetectorType]RateLimit
+	retry          *roundtripper.RetryableRoundtripper
+
+	mu sync.Mutex
+	// buckets are keyed by host, or by detector and host for detectors with
+	// their own limit.
+	buckets map[string]*rate.Limiter
+	// blocked holds the time each rate limited host accepts requests again.
+	blocked map[string]time.Time
+}
+
+// RateLimiterOption configures a RateLimiter.
+type RateLimiterOption func(*RateLimiter)
+
+// WithDefaultRateLimit sets the limit for each host.
+func WithDefaultRateLimit(limit RateLimit) RateLimiterOption {
+	return func(l *RateLimiter) { l.defaultLimit = limit }
+}
+
+// WithDetectorRateLimit sets the limit for each host that detectors of type t
+// send requests to. Their requests don't count against the default limit.
+func WithDetectorRateLimit(t detectorspb.DetectorType, limit RateLimit) RateLimiterOption {
+	return func(l *RateLimiter) { l.detectorLimits[t] = limit }
+}
+
+// NewRateLimiter creates a RateLimiter. Without options, requests are only
+// held back by hosts that asked to retry later.
+func NewRateLimiter(opts ...RateLimiterOption) *RateLimiter {
+	l := &RateLimiter{
+		detectorLimits: make(map[detectorspb.DetectorType]RateLimit),
+		// Only rate limited requests are retried; other failures are
+		// reported by the detectors.
+		retry: roundtripper.NewRetryableRoundtripper(
+			roundtripper.WithShouldRetryError(false),
+			roundtripper.WithShouldRetry5XX(false),
+			roundtripper.WithDefault429RetryDuration(defaultRetryAfter),
+		),
+		buckets: make(map[string]*rate.Limiter),
+		blocked: make(map[string]time.Time),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+var rateLimiter atomic.Pointer[RateLimiter]
+
+// SetRateLimiter sets the RateLimiter shared by the HTTP clients of all
+// detectors, and by the verification requests of the common package's clients
+// once InstallCommonClientHook is called. Without one, requests are sent right
+// away.
+func SetRateLimiter(l *RateLimiter) {
+	rateLimiter.Store(l)
+}
+
+type rateLimitScopeKey struct{}
+
+// RateLimitScope identifies the detector that sends verification requests and
+// records whether they were rate limited.
+type RateLimitScope struct {
+	detector detectorspb.DetectorType
+	// retryAt is the latest time, in Unix nanoseconds, at which a host that
+	// rate limited a request accepts requests again.
+	retryAt atomic.Int64
+}
+
+// NewRateLimitScope creates a RateLimitScope for requests sent by detectors of
+// type t.
+func NewRateLimitScope(t detectorspb.DetectorType) *RateLimitScope {
+	return &RateLimitScope{detector: t}
+}
+
+// WithRateLimitScope returns a copy of ctx whose requests are rate limited as
+// sent by the detector of scope, and recorded on it if they were rate limited.
+func WithRateLimitScope(ctx context.Context, scope *RateLimitScope) context.Context {
+	return context.WithValue(ctx, rateLimitScopeKey{}, scope)
+}
+
+// RateLimited returns true if a request was rate limited, along with the time
+// its host accepts requests again.
+func (s *RateLimitScope) RateLimited() (time.Time, bool) {
+	retryAt := s.retryAt.Load()
+	if retryAt == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(0, retryAt), true
+}
+
+func (s *RateLimitScope) setRateLimited(retryAt time.Time) {
+	if s == nil {
+		return
+	}
+	for {
+		old := s.retryAt.Load()
+		if old >= retryAt.UnixNano() || s.retryAt.CompareAndSwap(old, retryAt.UnixNano()) {
+			return
+		}
+	}
+}
+
+// roundTrip sends req with send once the rate limits allow it, retrying it
+// while its host rate limits it and the retry fits within its deadline.
+func (l *RateLimiter) roundTrip(req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	if l == nil {
+		return send(req)
+	}
+	ctx := req.Context()
+	scope, _ := ctx.Value(rateLimitScopeKey{}).(*RateLimitScope)
+	host := req.URL.Host
+
+	for retries := uint(0); ; retries++ {
+		if err := l.wait(ctx, scope, host); err != nil {
+			return nil, err
+		}
+		res, err := send(req)
+		_, retry, after := l.retry.ShouldRetryRequest(res, err)
+		if !retry {
+			return res, err
+		}
+
+		retryAt := time.Now().Add(after)
+		l.block(host, retryAt)
+		rewindable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+		if retries >= l.retry.MaxRetries() || !rewindable || !canWaitUntil(ctx, retryAt) {
+			scope.setRateLimited(retryAt)
+			return res, err
+		}
+
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+		req = req.Clone(ctx)
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// wait blocks until a request to host may be sent. It fails right away if
+// that is after the deadline of ctx.
+func (l *RateLimiter) wait(ctx context.Context, scope *RateLimitScope, host string) error {
+	l.mu.Lock()
+	retryAt := l.blocked[host]
+	l.mu.Unlock()
+	if delay := time.Until(retryAt); delay > 0 {
+		if !canWaitUntil(ctx, retryAt) {
+			scope.setRateLimited(retryAt)
+			return ErrRateLimited
+		}
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	b := l.bucket(scope, host)
+	if err := b.Wait(ctx); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// The bucket refills too slowly for the deadline of ctx; try again
+		// once it has a token to spare.
+		scope.setRateLimited(time.Now().Add(time.Duration(float64(time.Second) / float64(b.Limit()))))
+		return fmt.Errorf("%w: %v", ErrRateLimited, err)
+	}
+	return nil
+}
+
+func (l *RateLimiter) bucket(scope *RateLimitScope, host string) *rate.Limiter {
+	key, limit := host, l.defaultLimit
+	if scope != nil {
+		if detectorLimit, ok := l.detectorLimits[scope.detector]; ok {
+			key, limit = scope.detector.String()+"|"+host, detectorLimit
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b, ok := l.buckets[key]
+	if !ok {
+		b = limit.limiter()
+		l.buckets[key] = b
+	}
+	return b
+}
+
+// block holds requests to host back until retryAt.
+func (l *RateLimiter) block(host string, retryAt time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if retryAt.After(l.blocked[host]) {
+		l.blocked[host] = retryAt
+	}
+}
+
+// canWaitUntil returns true if a request may wait until t before being sent.
+func canWaitUntil(ctx context.Context, t time.Time) bool {
+	if time.Until(t) > maxRetryWait {
+		return false
+	}
+	deadline, ok := ctx.Deadline()
+	return !ok || t.Before(deadline)
+}
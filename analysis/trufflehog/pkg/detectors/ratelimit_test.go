@@ -0,0 +1,184 @@
+package detectors
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+func setRateLimiter(t *testing.T, l *RateLimiter) {
+	t.Helper()
+	SetRateLimiter(l)
+	t.Cleanup(func() { SetRateLimiter(nil) })
+}
+
+func installCommonClientHook(t *testing.T) {
+	t.Helper()
+	t.Cleanup(InstallCommonClientHook())
+}
+
+func rateLimitedGet(t *testing.T, ctx context.Context, url string) (*http.Response, error) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	res, err := NewDetectorHttpClient().Do(req)
+	if err == nil {
+		_ = res.Body.Close()
+	}
+	return res, err
+}
+
+func TestRateLimiter_RetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	setRateLimiter(t, NewRateLimiter())
+
+	// The request is retried once the host accepts requests again.
+	scope := NewRateLimitScope(detectorspb.DetectorType_Github)
+	start := time.Now()
+	res, err := rateLimitedGet(t, WithRateLimitScope(context.Background(), scope), server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, int32(2), calls.Load())
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+
+	_, limited := scope.RateLimited()
+	assert.False(t, limited)
+}
+
+func TestRateLimiter_RetryAfterDeadline(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+	setRateLimiter(t, NewRateLimiter())
+
+	// Waiting for the host would take too long, so the response is returned
+	// and the scope records when to try again.
+	scope := NewRateLimitScope(detectorspb.DetectorType_Github)
+	ctx := WithRateLimitScope(context.Background(), scope)
+	res, err := rateLimitedGet(t, ctx, server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+	retryAt, limited := scope.RateLimited()
+	require.True(t, limited)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), retryAt, 5*time.Second)
+
+	// Later requests to the host aren't sent until then.
+	scope = NewRateLimitScope(detectorspb.DetectorType_Slack)
+	_, err = rateLimitedGet(t, WithRateLimitScope(context.Background(), scope), server.URL)
+	assert.ErrorIs(t, err, ErrRateLimited)
+	_, limited = scope.RateLimited()
+	assert.True(t, limited)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestRateLimiter_CommonClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	setRateLimiter(t, NewRateLimiter(WithDefaultRateLimit(RateLimit{Rate: 0.1, Burst: 1})))
+	installCommonClientHook(t)
+
+	get := func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		res, err := common.SaneHttpClient().Do(req)
+		if err == nil {
+			_ = res.Body.Close()
+		}
+		return err
+	}
+
+	// Verification requests share the buckets of the detector clients.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	scope := NewRateLimitScope(detectorspb.DetectorType_Github)
+	ctx = WithRateLimitScope(ctx, scope)
+	_, err := rateLimitedGet(t, ctx, server.URL)
+	require.NoError(t, err)
+	assert.ErrorIs(t, get(ctx), ErrRateLimited)
+	_, limited := scope.RateLimited()
+	assert.True(t, limited)
+
+	// Other requests aren't held back.
+	require.NoError(t, get(context.Background()))
+}
+
+func TestRateLimiter_TokenBucket(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	setRateLimiter(t, NewRateLimiter(
+		WithDefaultRateLimit(RateLimit{Rate: 0.1, Burst: 1}),
+		WithDetectorRateLimit(detectorspb.DetectorType_Slack, RateLimit{Rate: 0}),
+	))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	github := NewRateLimitScope(detectorspb.DetectorType_Github)
+	githubCtx := WithRateLimitScope(ctx, github)
+	_, err := rateLimitedGet(t, githubCtx, server.URL)
+	require.NoError(t, err)
+
+	// The bucket is empty and won't refill before the deadline.
+	_, err = rateLimitedGet(t, githubCtx, server.URL)
+	assert.ErrorIs(t, err, ErrRateLimited)
+	_, limited := github.RateLimited()
+	assert.True(t, limited)
+
+	// Detectors with their own limit have their own bucket.
+	slackCtx := WithRateLimitScope(ctx, NewRateLimitScope(detectorspb.DetectorType_Slack))
+	for i := 0; i < 3; i++ {
+		_, err = rateLimitedGet(t, slackCtx, server.URL)
+		require.NoError(t, err)
+	}
+}
+
+func TestParseRateLimit(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    RateLimit
+		wantErr bool
+	}{
+		{input: "10", want: RateLimit{Rate: 10}},
+		{input: "0.5:2", want: RateLimit{Rate: 0.5, Burst: 2}},
+		{input: "0", want: RateLimit{}},
+		{input: "-1", wantErr: true},
+		{input: "fast", wantErr: true},
+		{input: "1:0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseRateLimit(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -67,7 +67,9 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 		}
 
 		if verify {
-			s.Verified = verifyRedis(ctx, parsedURL)
+			isVerified, verificationErr := verifyRedis(ctx, parsedURL)
+			s.Verified = isVerified
+			s.SetVerificationError(verificationErr, password)
 		}
 
 		if !s.Verified {
@@ -106,8 +108,9 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 		}
 
 		if verify {
-
-			s.Verified = verifyRedis(ctx, parsedURL)
+			isVerified, verificationErr := verifyRedis(ctx, parsedURL)
+			s.Verified = isVerified
+			s.SetVerificationError(verificationErr, password)
 		}
 
 		if !s.Verified {
@@ -123,33 +126,55 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
-func verifyRedis(ctx context.Context, u *url.URL) bool {
+// authErrPrefixes are the prefixes of the errors Redis servers reply to rejected credentials with.
+var authErrPrefixes = []string{
+	"NOAUTH", "WRONGPASS", "ERR invalid password", "ERR invalid username-password pair", "ERR AUTH", "ERR Client sent AUTH",
+}
+
+func verifyRedis(ctx context.Context, u *url.URL) (bool, error) {
 	opt, err := redis.ParseURL(u.String())
 	if err != nil {
-		return false
+		return false, err
 	}
 
-	client := redis.NewClient(opt)
+	client := redis.NewClient(opt).WithContext(ctx)
+	defer client.Close()
 
 	status, err := client.Ping().Result()
-	if err == nil && status == "PONG" {
-		return true
+	if err != nil {
+		for _, prefix := range authErrPrefixes {
+			if strings.HasPrefix(err.Error(), prefix) {
+				// The server rejected the credentials.
+				return false, nil
+			}
+		}
+		return false, err
+	}
+	if status != "PONG" {
+		return false, fmt.Errorf("unexpected PING reply %q", status)
 	}
+	return true, nil
+}
 
-	return false
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "redis", Host: "*", HostFromData: true}},
+		ReadOnly: true,
+	}
 }
 
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_Redis
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
 	url, err := url.Parse(secret)
 	if err != nil {
-		verified := verifyRedis(ctx, url)
-		return verified
+		return detectors.MalformedSecretOutcome()
 	}
-	return false
+	verified, err := verifyRedis(ctx, url)
+	password, _ := url.User.Password()
+	return detectors.NewVerificationOutcome(verified, err, password)
 }
 
 func verifyMatch(ctx context.Context, client *http.Client, resMatch string) (bool, error) {
@@ -0,0 +1,89 @@
+package redis
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+)
+
+// fakeRedis serves the replies of a Redis server with the given password.
+func fakeRedis(t *testing.T, password string) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				for {
+					// Commands are arrays of bulk strings.
+					var n int
+					if _, err := fmt.Fscanf(r, "*%d\r\n", &n); err != nil {
+						return
+					}
+					args := make([]string, n)
+					for i := range args {
+						var size int
+						if _, err := fmt.Fscanf(r, "$%d\r\n", &size); err != nil {
+							return
+						}
+						line, err := r.ReadString('\n')
+						if err != nil {
+							return
+						}
+						args[i] = strings.TrimSuffix(line, "\r\n")
+					}
+
+					reply := "-ERR unknown command\r\n"
+					switch strings.ToUpper(args[0]) {
+					case "AUTH":
+						reply = "-WRONGPASS invalid username-password pair\r\n"
+						if args[len(args)-1] == password {
+							reply = "+OK\r\n"
+						}
+					case "PING":
+						reply = "+PONG\r\n"
+					}
+					if _, err := conn.Write([]byte(reply)); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func TestRedis_Verify(t *testing.T) {
+	addr := fakeRedis(t, "correct-password")
+
+	tests := []struct {
+		name   string
+		secret string
+		want   detectors.VerificationStatus
+	}{
+		{name: "verified", secret: "redis://:correct-password@" + addr, want: detectors.VerificationStatusVerified},
+		{name: "invalid", secret: "redis://:wrong-password@" + addr, want: detectors.VerificationStatusInvalid},
+		{name: "unreachable", secret: "redis://:correct-password@127.0.0.1:1", want: detectors.VerificationStatusIndeterminate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Scanner{}.Verify(context.Background(), tt.secret).Status)
+		})
+	}
+}
@@ -67,6 +67,11 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 					Raw:          []byte(projectID),
 					RawV2:        []byte(projectID + ";-|" + writeKey + ";-|" + token),
 				}
+				s1.SetCredential(
+					detectors.CredentialPart{Name: detectors.CredentialPartID, Value: projectID},
+					detectors.CredentialPart{Name: detectors.CredentialPartKey, Value: writeKey},
+					detectors.CredentialPart{Name: detectors.CredentialPartToken, Value: token},
+				)
 
 				if verify {
 
@@ -83,18 +88,34 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		// Verifying tracks an event for a user.
+		Requests: []detectors.VerificationRequest{{Method: "POST", Host: "app.satismeter.com"}},
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_SatismeterWritekey
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 3 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartID, detectors.CredentialPartKey, detectors.CredentialPartToken}
+}
 
-	verified, _ := verifySatisMeterWriteKey(ctx, client, parts[0], parts[1], parts[2])
-	return verified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	verified, err := verifySatisMeterWriteKey(
+		ctx,
+		client,
+		cred.Get(detectors.CredentialPartID),
+		cred.Get(detectors.CredentialPartKey),
+		cred.Get(detectors.CredentialPartToken),
+	)
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
 }
 
 func (s Scanner) Description() string {
@@ -3,6 +3,7 @@ package shodankey
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"strings"
@@ -50,8 +51,9 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 		}
 
 		if verify {
-
-			s1.Verified = verifyToken(ctx, client, resMatch)
+			isVerified, verificationErr := verifyToken(ctx, client, resMatch)
+			s1.Verified = isVerified
+			s1.SetVerificationError(verificationErr, resMatch)
 		}
 		results = append(results, s1)
 	}
@@ -75,38 +77,52 @@ type shodanInfoRes struct {
 	Telnet       bool   `json:"telnet"`
 }
 
-func verifyToken(ctx context.Context, client *http.Client, token string) bool {
+func verifyToken(ctx context.Context, client *http.Client, token string) (bool, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.shodan.io/api-info?key="+token, nil)
 	if err != nil {
-		return false
+		return false, err
 	}
 
 	res, err := client.Do(req)
 	if err != nil {
-		return false
+		return false, err
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode < 200 || res.StatusCode >= 300 {
-		return false
-	}
+	switch res.StatusCode {
+	case http.StatusOK:
+		bytes, err := io.ReadAll(res.Body)
+		if err != nil {
+			return false, err
+		}
 
-	bytes, err := io.ReadAll(res.Body)
-	if err != nil {
-		return false
+		var info shodanInfoRes
+		if err := json.Unmarshal(bytes, &info); err != nil {
+			return false, err
+		}
+		return true, nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		// The key is invalid or has been revoked.
+		return false, nil
+	default:
+		return false, fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
 	}
+}
 
-	var info shodanInfoRes
-	return json.Unmarshal(bytes, &info) == nil
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "api.shodan.io"}},
+		ReadOnly: true,
+	}
 }
 
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_ShodanKey
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	verified := verifyToken(ctx, client, secret)
-	return verified
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	verified, err := verifyToken(ctx, client, secret)
+	return detectors.NewVerificationOutcome(verified, err, secret)
 }
 
 func (s Scanner) Description() string {
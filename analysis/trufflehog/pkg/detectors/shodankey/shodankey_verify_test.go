@@ -0,0 +1,35 @@
+package shodankey
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+)
+
+func TestShodanKey_Verify(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		want       detectors.VerificationStatus
+	}{
+		{name: "verified", statusCode: http.StatusOK, body: `{"plan": "dev", "query_credits": 100}`, want: detectors.VerificationStatusVerified},
+		{name: "invalid", statusCode: http.StatusUnauthorized, want: detectors.VerificationStatusInvalid},
+		{name: "rate limited", statusCode: http.StatusTooManyRequests, want: detectors.VerificationStatusIndeterminate},
+		{name: "server error", statusCode: http.StatusBadGateway, want: detectors.VerificationStatusIndeterminate},
+		{name: "unexpected body", statusCode: http.StatusOK, body: "<html>", want: detectors.VerificationStatusIndeterminate},
+	}
+	defaultClient := client
+	t.Cleanup(func() { client = defaultClient })
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client = common.ConstantResponseHttpClient(tt.statusCode, tt.body)
+			assert.Equal(t, tt.want, Scanner{}.Verify(context.Background(), "abcdefghijklmnopqrstuvwxyz012345").Status)
+		})
+	}
+}
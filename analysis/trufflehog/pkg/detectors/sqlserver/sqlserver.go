@@ -107,19 +107,30 @@ var ping = func(config msdsn.Config) (bool, error) {
 	return true, nil
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "sqlserver", Host: "*", HostFromData: true}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_SQLServer
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
 	paramsUnsafe, err := msdsn.Parse(secret)
 	if err != nil {
-		return false
+		return detectors.MalformedSecretOutcome()
 	}
 
-	verified, _ := ping(paramsUnsafe)
+	verified, err := ping(paramsUnsafe)
+	if mssqlErr, isMssqlErr := err.(mssql.Error); isMssqlErr && mssqlErr.Number == 18456 {
+		// Login failed; determinate failure to verify.
+		return detectors.NewVerificationOutcome(false, nil)
+	}
 
-	return verified
+	return detectors.NewVerificationOutcome(verified, err, paramsUnsafe.Password)
 }
 
 func verifyMatch(ctx context.Context, client *http.Client, resMatch string) (bool, error) {
@@ -149,6 +149,11 @@ func createResult(accessId string, accessKey string, endpoint string, verified b
 			"rotation_guide": "https://howtorotate.com/docs/tutorials/sumologic/",
 		},
 	}
+	r.SetCredential(
+		detectors.CredentialPart{Name: detectors.CredentialPartEndpoint, Value: endpoint},
+		detectors.CredentialPart{Name: detectors.CredentialPartID, Value: accessId},
+		detectors.CredentialPart{Name: detectors.CredentialPartSecret, Value: accessKey},
+	)
 	r.SetVerificationError(err, accessKey)
 
 	// |endpoint| and |accessId| won't be specified unless there's a confident match.
@@ -167,19 +172,34 @@ func createResult(accessId string, accessKey string, endpoint string, verified b
 	return r
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "api.*.sumologic.com"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_SumoLogicKey
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
 
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 3 {
-		return false
-	}
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartEndpoint, detectors.CredentialPartID, detectors.CredentialPartSecret}
+}
 
-	verified, _ := verifyMatch(ctx, defaultClient, parts[0], parts[1], parts[2])
-	return verified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	verified, err := verifyMatch(
+		ctx,
+		defaultClient,
+		cred.Get(detectors.CredentialPartEndpoint),
+		cred.Get(detectors.CredentialPartID),
+		cred.Get(detectors.CredentialPartSecret),
+	)
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
 }
 
 func (s Scanner) Description() string {
@@ -0,0 +1,56 @@
+package twilio
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+)
+
+func TestTwilio_VerifyCassettes(t *testing.T) {
+	// The credentials are scrubbed before requests are matched, so any values
+	// replay the cassettes. To re-record them, replace these with real
+	// credentials and set TRUFFLEHOG_RECORD_CASSETTES=1.
+	const (
+		sid = "AC0123456789abcdef0123456789abcdef"
+		key = "0123456789abcdef0123456789abcdef"
+	)
+	tests := []struct {
+		cassette      string
+		wantStatus    detectors.VerificationStatus
+		wantHTTP      int
+		wantExtraData map[string]string
+	}{
+		{
+			cassette:      "verified",
+			wantStatus:    detectors.VerificationStatusVerified,
+			wantHTTP:      200,
+			wantExtraData: map[string]string{"friendly_name": "Verify Demo", "account_sid": detectors.ScrubbedPlaceholder},
+		},
+		{cassette: "unverified", wantStatus: detectors.VerificationStatusInvalid, wantHTTP: 401},
+		{cassette: "server_error", wantStatus: detectors.VerificationStatusIndeterminate, wantHTTP: 503},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cassette, func(t *testing.T) {
+			client, cassette, err := detectors.NewCassetteClient(
+				filepath.Join("testdata", tt.cassette+".json"),
+				detectors.WithCassetteMode(detectors.CassetteModeFromEnv()),
+				detectors.WithScrubbedSecrets(sid, key),
+			)
+			require.NoError(t, err)
+			t.Cleanup(func() { require.NoError(t, cassette.Save()) })
+
+			outcome := detectors.VerifySecret(context.Background(), Scanner{client: client}, sid+detectors.CredentialSeparator+key)
+			assert.Equal(t, tt.wantStatus, outcome.Status)
+			assert.Equal(t, tt.wantHTTP, outcome.HTTPStatus)
+			if tt.wantExtraData != nil {
+				assert.Equal(t, tt.wantExtraData, outcome.ExtraData)
+			}
+			assert.Empty(t, cassette.Unused())
+		})
+	}
+}
@@ -61,6 +61,10 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 				Raw:          []byte(key),
 				RawV2:        []byte(key + secret),
 			}
+			s1.SetCredential(
+				detectors.CredentialPart{Name: detectors.CredentialPartID, Value: key},
+				detectors.CredentialPart{Name: detectors.CredentialPartSecret, Value: secret},
+			)
 
 			if verify {
 
@@ -85,29 +89,46 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{
+			{Method: "GET", Host: "api.twitter.com"},
+			{Method: "POST", Host: "api.twitter.com"},
+		},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_TwitterConsumerkey
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 2 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
 
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartID, detectors.CredentialPartSecret}
+}
+
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
 	client := s.client
 	if client == nil {
 		client = defaultClient
 	}
 
-	bearerToken, err := fetchBearerToken(ctx, client, parts[0], parts[1])
-	fmt.Println(bearerToken)
-
-	if err == nil {
-		isVerified, _ := verifyBearerToken(ctx, client, bearerToken)
-		return isVerified
+	bearerToken, err := fetchBearerToken(
+		ctx,
+		client,
+		cred.Get(detectors.CredentialPartID),
+		cred.Get(detectors.CredentialPartSecret),
+	)
+	if err != nil {
+		return detectors.NewVerificationOutcome(false, err, cred.Values()...)
 	}
-	return false
+
+	isVerified, err := verifyBearerToken(ctx, client, bearerToken)
+	return detectors.NewVerificationOutcome(isVerified, err, append(cred.Values(), bearerToken)...)
 }
 
 func (s Scanner) Description() string {
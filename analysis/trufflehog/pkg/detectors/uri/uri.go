@@ -157,18 +157,26 @@ func verifyURL(ctx context.Context, client *http.Client, u *url.URL) (bool, erro
 	return false, nil
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "*", HostFromData: true}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_URI
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
 	parsedURL, err := url.Parse(secret)
 	if err != nil {
-		return false
+		return detectors.MalformedSecretOutcome()
 	}
 
-	verified, _ := verifyURL(ctx, defaultClient, parsedURL)
-	return verified
+	verified, err := verifyURL(ctx, defaultClient, parsedURL)
+	password, _ := parsedURL.User.Password()
+	return detectors.NewVerificationOutcome(verified, err, password)
 }
 
 func (s Scanner) Description() string {
@@ -0,0 +1,121 @@
+package detectors
+
+import (
+	"context"
+	"errors"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+)
+
+// VerificationStatus is the result of verifying a single candidate secret.
+type VerificationStatus string
+
+const (
+	// VerificationStatusVerified means the provider confirmed the secret is live.
+	VerificationStatusVerified VerificationStatus = "verified"
+	// VerificationStatusInvalid means the provider rejected the secret, or the
+	// secret is malformed. Retrying won't change the outcome.
+	VerificationStatusInvalid VerificationStatus = "invalid"
+	// VerificationStatusIndeterminate means verification failed in a way that
+	// says nothing about the secret, e.g. a timeout, a 5xx or a rate limit.
+	// Retrying may produce a different outcome.
+	VerificationStatusIndeterminate VerificationStatus = "indeterminate"
+	// VerificationStatusUnsupported means the secret wasn't verified, because
+	// the detector has no way to verify it or the verification policy doesn't
+	// allow it to. Retrying won't change the outcome.
+	VerificationStatusUnsupported VerificationStatus = "unsupported"
+)
+
+// ErrMalformedSecret is reported for secrets that don't have the shape a
+// detector's Verify expects, such as a multi-part secret with missing parts.
+var ErrMalformedSecret = errors.New("malformed secret")
+
+// ErrVerificationNotSupported is reported by detectors that have no way to
+// verify their secrets.
+var ErrVerificationNotSupported = errors.New("verification is not supported by this detector")
+
+// VerificationOutcome is returned by Detector.Verify and carries everything
+// that is known about a single verification attempt.
+type VerificationOutcome struct {
+	Status VerificationStatus
+	// Error is the verification error with all secrets redacted. It is always
+	// set for indeterminate and unsupported outcomes and may explain invalid
+	// ones.
+	Error error
+	// HTTPStatus is the status code of the last HTTP response received while
+	// verifying, or 0 if none was observed.
+	HTTPStatus int
+	// ExtraData contains additional information the verifier learned about
+	// the secret, such as the account it belongs to.
+	ExtraData map[string]string
+}
+
+// NewVerificationOutcome creates a VerificationOutcome from the values returned
+// by a detector's verification helper. Any sensitive values in err should be
+// passed in as secrets to be redacted.
+func NewVerificationOutcome(verified bool, err error, secrets ...string) VerificationOutcome {
+	switch {
+	case verified:
+		return VerificationOutcome{Status: VerificationStatusVerified}
+	case err != nil:
+		return VerificationOutcome{Status: VerificationStatusIndeterminate, Error: redactSecrets(err, secrets...)}
+	default:
+		return VerificationOutcome{Status: VerificationStatusInvalid}
+	}
+}
+
+// MalformedSecretOutcome is the outcome for a secret that can't be verified
+// because it is malformed.
+func MalformedSecretOutcome() VerificationOutcome {
+	return VerificationOutcome{Status: VerificationStatusInvalid, Error: ErrMalformedSecret}
+}
+
+// VerificationNotSupportedOutcome is the outcome for detectors that can't
+// verify their secrets.
+func VerificationNotSupportedOutcome() VerificationOutcome {
+	return VerificationOutcome{Status: VerificationStatusUnsupported, Error: ErrVerificationNotSupported}
+}
+
+// WithExtraData returns a copy of the outcome with the given extra data.
+func (o VerificationOutcome) WithExtraData(extraData map[string]string) VerificationOutcome {
+	if len(extraData) > 0 {
+		o.ExtraData = extraData
+	}
+	return o
+}
+
+// Verified returns true if the secret was confirmed to be live.
+func (o VerificationOutcome) Verified() bool {
+	return o.Status == VerificationStatusVerified
+}
+
+// VerifySecret verifies secret using d and fills in the HTTP status of the
+// outcome if the detector didn't provide one itself. The status is observed on
+// the HTTP clients of the common and detectors packages; detectors that verify
+// over other protocols or SDKs report 0.
+func VerifySecret(ctx context.Context, d Detector, secret string) VerificationOutcome {
+	ctx, recorder := common.WithResponseRecorder(ctx)
+	outcome := d.Verify(ctx, secret)
+	if outcome.HTTPStatus == 0 {
+		outcome.HTTPStatus = recorder.LastStatus()
+	}
+	return outcome
+}
+
+// ResultVerifier is an optional interface for detectors whose Verify reaches
+// the same outcome for the VerificationSecret or Credential of a result as
+// FromData does when it verifies that result. Other detectors may verify with
+// context that never reaches their results, like a host or ID found next to
+// the secret, so they only verify in FromData.
+type ResultVerifier interface {
+	// VerifiesResults returns true if Verify reproduces the verification of
+	// FromData.
+	VerifiesResults() bool
+}
+
+// VerifiesResults returns true if the results of d can be verified one by one
+// with Verify instead of FromData.
+func VerifiesResults(d Detector) bool {
+	v, ok := d.(ResultVerifier)
+	return ok && v.VerifiesResults()
+}
@@ -0,0 +1,101 @@
+package detectors
+
+import (
+	"errors"
+	"fmt"
+)
+
+// VerificationRequest describes a request a detector sends to verify a secret.
+type VerificationRequest struct {
+	// Method is the HTTP method, or the name of the SDK call or protocol for
+	// detectors that don't verify over HTTP.
+	Method string
+	// Host is the destination host. Hosts that depend on the secret, such as a
+	// tenant's own domain, use a wildcard, e.g. "*.myshopify.com". A request
+	// that goes to one of several hosts is listed once per host.
+	Host string
+	// HostFromData is true if the host is taken from the scanned data, such as
+	// the host of a connection string, and can be any host. Host is then "*".
+	HostFromData bool
+}
+
+// VerificationMetadata describes the side effects of verifying a detector's
+// secrets, so verification can be limited to detectors that are safe to use on
+// credentials that belong to someone else.
+type VerificationMetadata struct {
+	// Requests are the requests sent to verify a secret, in order. Detectors
+	// that can't verify their secrets send none.
+	Requests []VerificationRequest
+	// ReadOnly is true if verifying never creates, changes or deletes
+	// resources, or sends messages.
+	ReadOnly bool
+	// Billable is true if verifying consumes paid quota of the secret's owner.
+	Billable bool
+}
+
+// Safe returns true if verifying has no side effects and costs nothing.
+func (m VerificationMetadata) Safe() bool {
+	return m.ReadOnly && !m.Billable
+}
+
+// VerificationMetadataProvider is an optional interface that a detector can
+// implement to declare how it verifies secrets. Detectors that don't implement
+// it are treated as unsafe to verify.
+type VerificationMetadataProvider interface {
+	VerificationMetadata() VerificationMetadata
+}
+
+// GetVerificationMetadata returns the verification metadata of d, if it
+// declares any.
+func GetVerificationMetadata(d Detector) (VerificationMetadata, bool) {
+	p, ok := d.(VerificationMetadataProvider)
+	if !ok {
+		return VerificationMetadata{}, false
+	}
+	return p.VerificationMetadata(), true
+}
+
+// VerificationPolicy selects which detectors may verify secrets.
+type VerificationPolicy string
+
+const (
+	// VerificationPolicyAll lets every detector verify secrets.
+	VerificationPolicyAll VerificationPolicy = "all"
+	// VerificationPolicySafeOnly only lets detectors verify secrets if they
+	// declare that verifying is read-only and costs nothing.
+	VerificationPolicySafeOnly VerificationPolicy = "safe-only"
+	// VerificationPolicyNone disables verification.
+	VerificationPolicyNone VerificationPolicy = "none"
+)
+
+// ErrVerificationNotAllowed is reported for secrets that weren't verified
+// because the verification policy doesn't allow their detector to verify.
+var ErrVerificationNotAllowed = errors.New("verification is not allowed by the verification policy")
+
+// ParseVerificationPolicy parses a verification policy. An empty string is
+// VerificationPolicyAll.
+func ParseVerificationPolicy(s string) (VerificationPolicy, error) {
+	switch p := VerificationPolicy(s); p {
+	case "":
+		return VerificationPolicyAll, nil
+	case VerificationPolicyAll, VerificationPolicySafeOnly, VerificationPolicyNone:
+		return p, nil
+	default:
+		return "", fmt.Errorf("invalid verification policy %q: must be one of %q, %q or %q",
+			s, VerificationPolicySafeOnly, VerificationPolicyAll, VerificationPolicyNone)
+	}
+}
+
+// Allows returns true if the policy lets d verify secrets. The zero value
+// allows every detector, like VerificationPolicyAll.
+func (p VerificationPolicy) Allows(d Detector) bool {
+	switch p {
+	case VerificationPolicyNone:
+		return false
+	case VerificationPolicySafeOnly:
+		m, ok := GetVerificationMetadata(d)
+		return ok && m.Safe()
+	default:
+		return true
+	}
+}
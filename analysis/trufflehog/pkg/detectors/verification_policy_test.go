@@ -0,0 +1,61 @@
+package detectors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type metadataDetector struct {
+	fakeDetector
+	metadata VerificationMetadata
+}
+
+func (d metadataDetector) VerificationMetadata() VerificationMetadata { return d.metadata }
+
+func TestVerificationPolicy_Allows(t *testing.T) {
+	safe := metadataDetector{metadata: VerificationMetadata{
+		Requests: []VerificationRequest{{Method: "GET", Host: "api.example.com"}},
+		ReadOnly: true,
+	}}
+	billable := metadataDetector{metadata: VerificationMetadata{
+		Requests: []VerificationRequest{{Method: "GET", Host: "api.example.com"}},
+		ReadOnly: true,
+		Billable: true,
+	}}
+	writes := metadataDetector{metadata: VerificationMetadata{
+		Requests: []VerificationRequest{{Method: "POST", Host: "api.example.com"}},
+	}}
+	undeclared := fakeDetector{}
+
+	tests := []struct {
+		policy VerificationPolicy
+		want   map[string]bool
+	}{
+		{policy: "", want: map[string]bool{"safe": true, "billable": true, "writes": true, "undeclared": true}},
+		{policy: VerificationPolicyAll, want: map[string]bool{"safe": true, "billable": true, "writes": true, "undeclared": true}},
+		{policy: VerificationPolicySafeOnly, want: map[string]bool{"safe": true}},
+		{policy: VerificationPolicyNone, want: map[string]bool{}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			assert.Equal(t, tt.want["safe"], tt.policy.Allows(safe))
+			assert.Equal(t, tt.want["billable"], tt.policy.Allows(billable))
+			assert.Equal(t, tt.want["writes"], tt.policy.Allows(writes))
+			assert.Equal(t, tt.want["undeclared"], tt.policy.Allows(undeclared))
+		})
+	}
+}
+
+func TestParseVerificationPolicy(t *testing.T) {
+	for _, s := range []string{"", "all", "safe-only", "none"} {
+		_, err := ParseVerificationPolicy(s)
+		require.NoError(t, err, s)
+	}
+	p, _ := ParseVerificationPolicy("")
+	assert.Equal(t, VerificationPolicyAll, p)
+
+	_, err := ParseVerificationPolicy("safe")
+	assert.Error(t, err)
+}
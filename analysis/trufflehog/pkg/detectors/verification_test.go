@@ -0,0 +1,93 @@
+package detectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+func TestNewVerificationOutcome(t *testing.T) {
+	tests := []struct {
+		name      string
+		verified  bool
+		err       error
+		secrets   []string
+		want      VerificationStatus
+		wantError string
+	}{
+		{name: "verified", verified: true, want: VerificationStatusVerified},
+		{name: "invalid", want: VerificationStatusInvalid},
+		{
+			name:      "indeterminate",
+			err:       errors.New("Get https://api.example.com/?key=abc123: timeout"),
+			secrets:   []string{"abc123", ""},
+			want:      VerificationStatusIndeterminate,
+			wantError: "Get https://api.example.com/?key=[REDACTED]: timeout",
+		},
+		{
+			name:     "verified despite error",
+			verified: true,
+			err:      errors.New("unexpected error"),
+			want:     VerificationStatusVerified,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewVerificationOutcome(tt.verified, tt.err, tt.secrets...)
+			assert.Equal(t, tt.want, got.Status)
+			assert.Equal(t, tt.verified, got.Verified())
+			if tt.wantError == "" {
+				assert.NoError(t, got.Error)
+				return
+			}
+			require.Error(t, got.Error)
+			assert.Equal(t, tt.wantError, got.Error.Error())
+		})
+	}
+}
+
+type httpVerifyDetector struct {
+	fakeDetector
+	client *http.Client
+}
+
+func (d httpVerifyDetector) Verify(ctx context.Context, secret string) VerificationOutcome {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.example.com", nil)
+	if err != nil {
+		return NewVerificationOutcome(false, err)
+	}
+	res, err := d.client.Do(req)
+	if err != nil {
+		return NewVerificationOutcome(false, err, secret)
+	}
+	_ = res.Body.Close()
+
+	extraData := map[string]string{"status": http.StatusText(res.StatusCode)}
+	switch res.StatusCode {
+	case http.StatusOK:
+		return NewVerificationOutcome(true, nil).WithExtraData(extraData)
+	case http.StatusUnauthorized:
+		return NewVerificationOutcome(false, nil).WithExtraData(extraData)
+	default:
+		err = fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
+		return NewVerificationOutcome(false, err).WithExtraData(extraData)
+	}
+}
+
+func (httpVerifyDetector) Type() detectorspb.DetectorType { return detectorspb.DetectorType_Generic }
+
+func TestVerifySecret_RecordsHTTPStatus(t *testing.T) {
+	d := httpVerifyDetector{client: common.ConstantResponseHttpClient(http.StatusTooManyRequests, "")}
+	outcome := VerifySecret(context.Background(), d, "secret")
+	assert.Equal(t, VerificationStatusIndeterminate, outcome.Status)
+	assert.Equal(t, http.StatusTooManyRequests, outcome.HTTPStatus)
+	assert.Equal(t, "Too Many Requests", outcome.ExtraData["status"])
+}
@@ -0,0 +1,35 @@
+package virustotal
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+)
+
+func TestVirusTotal_Verify(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		want       detectors.VerificationStatus
+	}{
+		{name: "verified", statusCode: http.StatusOK, want: detectors.VerificationStatusVerified},
+		{name: "invalid", statusCode: http.StatusUnauthorized, want: detectors.VerificationStatusInvalid},
+		{name: "forbidden", statusCode: http.StatusForbidden, want: detectors.VerificationStatusInvalid},
+		{name: "rate limited", statusCode: http.StatusTooManyRequests, want: detectors.VerificationStatusIndeterminate},
+		{name: "server error", statusCode: http.StatusServiceUnavailable, want: detectors.VerificationStatusIndeterminate},
+	}
+	defaultClient := client
+	t.Cleanup(func() { client = defaultClient })
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client = common.ConstantResponseHttpClient(tt.statusCode, "")
+			secret := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+			assert.Equal(t, tt.want, Scanner{}.Verify(context.Background(), secret).Status)
+		})
+	}
+}
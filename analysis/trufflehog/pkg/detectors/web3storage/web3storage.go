@@ -68,13 +68,20 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "api.web3.storage"}},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_Web3Storage
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	verified, _ := verifyMatch(ctx, defaultClient, secret)
-	return verified
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	verified, err := verifyMatch(ctx, defaultClient, secret)
+	return detectors.NewVerificationOutcome(verified, err, secret)
 }
 
 func verifyMatch(ctx context.Context, client *http.Client, resMatch string) (bool, error) {
@@ -84,17 +91,20 @@ func verifyMatch(ctx context.Context, client *http.Client, resMatch string) (boo
 	}
 	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", resMatch))
 	res, err := client.Do(req)
-	if err == nil {
-		defer res.Body.Close()
-		if res.StatusCode >= 200 && res.StatusCode < 300 {
-			return true, nil
-		} else if res.StatusCode == 401 {
-			// The secret is determinately not verified (nothing to do)
-		} else {
-			fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
-		}
+	if err != nil {
+		return false, err
+	}
+	defer res.Body.Close()
+
+	switch {
+	case res.StatusCode >= 200 && res.StatusCode < 300:
+		return true, nil
+	case res.StatusCode == 401:
+		// The secret is determinately not verified (nothing to do)
+		return false, nil
+	default:
+		return false, fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
 	}
-	return false, nil
 }
 
 func (s Scanner) Description() string {
@@ -59,8 +59,13 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 				s1 := detectors.Result{
 					DetectorType: detectorspb.DetectorType_ZipAPI,
 					Raw:          []byte(keyMatch),
-					RawV2:        []byte(keyMatch + ";-|" + emailMatch + ";-|" + passMatch),
+					RawV2:        []byte(keyMatch + detectors.CredentialSeparator + emailMatch + detectors.CredentialSeparator + passMatch),
 				}
+				s1.SetCredential(
+					detectors.CredentialPart{Name: detectors.CredentialPartToken, Value: keyMatch},
+					detectors.CredentialPart{Name: detectors.CredentialPartEmail, Value: emailMatch},
+					detectors.CredentialPart{Name: detectors.CredentialPartPassword, Value: passMatch},
+				)
 
 				if verify {
 					verified, err := verifyMatch(ctx, client, keyMatch, emailMatch, passMatch)
@@ -79,18 +84,34 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		// Every lookup counts against the paid quota of the key.
+		Requests: []detectors.VerificationRequest{{Method: "GET", Host: "service.zipapi.us"}},
+		ReadOnly: true,
+		Billable: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_ZipAPI
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 3 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartToken, detectors.CredentialPartEmail, detectors.CredentialPartPassword}
+}
 
-	verified, _ := verifyMatch(ctx, client, parts[0], parts[1], parts[2])
-	return verified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	verified, err := verifyMatch(ctx, client,
+		cred.Get(detectors.CredentialPartToken),
+		cred.Get(detectors.CredentialPartEmail),
+		cred.Get(detectors.CredentialPartPassword),
+	)
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
 }
 func verifyMatch(ctx context.Context, client *http.Client, keyMatch, emailMatch, passMatch string) (bool, error) {
 	data := fmt.Sprintf("%s:%s", emailMatch, passMatch)
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"strings"
 
 	regexp "github.com/wasilibs/go-re2"
 
@@ -67,6 +66,11 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 						"Id":     id,
 					},
 				}
+				s1.SetCredential(
+					detectors.CredentialPart{Name: detectors.CredentialPartToken, Value: key},
+					detectors.CredentialPart{Name: detectors.CredentialPartEmail, Value: id},
+					detectors.CredentialPart{Name: detectors.CredentialPartDomain, Value: domain},
+				)
 
 				if verify {
 
@@ -130,18 +134,38 @@ type member struct {
 	Email    string `json:"email"`
 }
 
+func (s Scanner) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{
+		Requests: []detectors.VerificationRequest{
+			{Method: "GET", Host: "*.zulipchat.com"},
+			{Method: "GET", Host: "*.zulip.com"},
+			{Method: "GET", Host: "chat.zulip.org"},
+		},
+		ReadOnly: true,
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_ZulipChat
 }
 
-func (s Scanner) Verify(ctx context.Context, secret string) bool {
-	parts := strings.Split(secret, ";-|")
-	if len(parts) != 2 {
-		return false
-	}
+func (s Scanner) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerifyLegacyCredential(ctx, s, secret)
+}
+
+func (s Scanner) CredentialParts() []detectors.CredentialPartName {
+	return []detectors.CredentialPartName{detectors.CredentialPartToken, detectors.CredentialPartEmail, detectors.CredentialPartDomain}
+}
 
-	verified, _ := verifyResult(ctx, defaultClient, parts[0], parts[1], parts[2])
-	return verified
+func (s Scanner) VerifyCredential(ctx context.Context, cred detectors.Credential) detectors.VerificationOutcome {
+	verified, err := verifyResult(
+		ctx,
+		defaultClient,
+		cred.Get(detectors.CredentialPartDomain),
+		cred.Get(detectors.CredentialPartEmail),
+		cred.Get(detectors.CredentialPartToken),
+	)
+	return detectors.NewVerificationOutcome(verified, err, cred.Values()...)
 }
 func (s Scanner) Description() string {
 
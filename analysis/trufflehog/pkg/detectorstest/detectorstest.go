@@ -0,0 +1,90 @@
+package detectorstest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+)
+
+// VerifierTest tests the verification of a detector that sends a single HTTP
+// request to verify a secret: the request it sends, and how it maps responses
+// to outcomes in both Verify and FromData.
+type VerifierTest struct {
+	// NewDetector returns the detector under test, sending its requests with
+	// client.
+	NewDetector func(client *http.Client) detectors.Detector
+	// Secret is the secret to verify. Data contains it exactly once.
+	Secret string
+	Data   string
+
+	// Method, URL, Header and Body describe the request expected for Secret.
+	// Only the listed headers are checked.
+	Method string
+	URL    string
+	Header map[string]string
+	Body   string
+
+	// Responses are the responses of the provider and their expected outcomes.
+	Responses []VerifierResponse
+}
+
+// VerifierResponse is a response of a provider and the verification status it
+// should produce.
+type VerifierResponse struct {
+	StatusCode int
+	Body       string
+	Want       detectors.VerificationStatus
+}
+
+// Run runs a subtest per response.
+func (vt VerifierTest) Run(t *testing.T) {
+	t.Helper()
+	for _, res := range vt.Responses {
+		t.Run(fmt.Sprintf("%d %s", res.StatusCode, res.Want), func(t *testing.T) {
+			var got *http.Request
+			var gotBody []byte
+			client := &http.Client{Transport: common.FakeTransport{
+				CreateResponse: func(req *http.Request) (*http.Response, error) {
+					got = req
+					if req.Body != nil {
+						var err error
+						if gotBody, err = io.ReadAll(req.Body); err != nil {
+							return nil, err
+						}
+					}
+					return &http.Response{
+						Request:    req,
+						StatusCode: res.StatusCode,
+						Body:       io.NopCloser(strings.NewReader(res.Body)),
+					}, nil
+				},
+			}}
+			d := vt.NewDetector(client)
+
+			assert.Equal(t, res.Want, d.Verify(context.Background(), vt.Secret).Status)
+			require.NotNil(t, got, "no request was sent")
+			assert.Equal(t, vt.Method, got.Method)
+			assert.Equal(t, vt.URL, got.URL.String())
+			for name, want := range vt.Header {
+				assert.Equal(t, want, got.Header.Get(name), "header %s", name)
+			}
+			assert.Equal(t, vt.Body, string(gotBody))
+
+			results, err := d.FromData(context.Background(), true, []byte(vt.Data))
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+			assert.Equal(t, vt.Secret, string(results[0].Raw))
+			assert.Equal(t, res.Want == detectors.VerificationStatusVerified, results[0].Verified)
+			assert.Equal(t, res.Want == detectors.VerificationStatusIndeterminate, results[0].VerificationError() != nil)
+		})
+	}
+}
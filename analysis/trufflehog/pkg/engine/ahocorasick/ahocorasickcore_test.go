@@ -31,8 +31,8 @@ func (testDetectorV1) Version() int { return 1 }
 
 func (testDetectorV1) Description() string { return "" }
 
-func (testDetectorV1) Verify(ctx context.Context, secret string) bool {
-	return false
+func (testDetectorV1) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerificationOutcome{Status: detectors.VerificationStatusInvalid}
 }
 
 type testDetectorV2 struct {
@@ -53,8 +53,8 @@ func (testDetectorV2) Type() detectorspb.DetectorType {
 func (testDetectorV2) Version() int { return 2 }
 
 func (testDetectorV2) Description() string { return "" }
-func (testDetectorV2) Verify(ctx context.Context, secret string) bool {
-	return false
+func (testDetectorV2) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerificationOutcome{Status: detectors.VerificationStatusInvalid}
 }
 
 type testDetectorV3 struct {
@@ -76,8 +76,8 @@ func (testDetectorV3) Version() int { return 1 }
 
 func (testDetectorV3) Description() string { return "" }
 
-func (testDetectorV3) Verify(ctx context.Context, secret string) bool {
-	return false
+func (testDetectorV3) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerificationOutcome{Status: detectors.VerificationStatusInvalid}
 }
 
 var _ detectors.Detector = (*testDetectorV4)(nil)
@@ -102,8 +102,8 @@ func (testDetectorV4) MaxCredentialSpan() int64 { return 15 }
 
 func (testDetectorV4) StartOffset() int64 { return 5 }
 
-func (testDetectorV4) Verify(ctx context.Context, secret string) bool {
-	return false
+func (testDetectorV4) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerificationOutcome{Status: detectors.VerificationStatusInvalid}
 }
 
 var _ detectors.Detector = (*testDetectorV5)(nil)
@@ -127,8 +127,8 @@ func (testDetectorV5) Description() string { return "" }
 func (testDetectorV5) MaxSecretSize() int64 { return 10 }
 
 func (testDetectorV5) StartOffset() int64 { return 3 }
-func (testDetectorV5) Verify(ctx context.Context, secret string) bool {
-	return false
+func (testDetectorV5) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerificationOutcome{Status: detectors.VerificationStatusInvalid}
 }
 
 var _ detectors.Detector = (*testDetectorV6)(nil)
@@ -151,8 +151,8 @@ func (testDetectorV6) Description() string { return "" }
 
 func (testDetectorV6) StartOffset() int64 { return 1 }
 
-func (testDetectorV6) Verify(ctx context.Context, secret string) bool {
-	return false
+func (testDetectorV6) Verify(ctx context.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerificationOutcome{Status: detectors.VerificationStatusInvalid}
 }
 
 var _ detectors.Detector = (*testDetectorV1)(nil)
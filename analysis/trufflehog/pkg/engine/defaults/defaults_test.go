@@ -1,6 +1,7 @@
 package defaults
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -65,3 +66,54 @@ func TestDefaultVersionerDetectorsHaveNonZeroVersions(t *testing.T) {
 		)
 	}
 }
+
+func TestDefaultDetectorsDeclareVerificationMetadata(t *testing.T) {
+	// The safe-only verification policy never verifies the results of
+	// detectors that don't declare how they verify secrets.
+	for _, detector := range DefaultDetectors() {
+		name := detectorspb.DetectorType_name[int32(detector.Type())]
+		if v, ok := detector.(detectors.Versioner); ok {
+			name = fmt.Sprintf("%s (v%d)", name, v.Version())
+		}
+		metadata, ok := detectors.GetVerificationMetadata(detector)
+		if !ok {
+			t.Errorf("detector %q doesn't implement detectors.VerificationMetadataProvider", name)
+			continue
+		}
+		// A wildcard for the whole host hides where secrets are sent, so it's
+		// only allowed when the host comes from the scanned data.
+		for _, req := range metadata.Requests {
+			switch {
+			case req.Host == "":
+				t.Errorf("detector %q declares a %s request without a host", name, req.Method)
+			case req.Host == "*" && !req.HostFromData:
+				t.Errorf("detector %q declares a %s request to any host, but the host isn't taken from the data", name, req.Method)
+			case req.Host != "*" && req.HostFromData:
+				t.Errorf("detector %q declares a %s request to %q with a host taken from the data", name, req.Method, req.Host)
+			}
+		}
+	}
+}
+
+func TestDefaultCredentialVerifiersHaveUniqueParts(t *testing.T) {
+	// Credentials are looked up by part name, so a name used twice would
+	// hide one of the parts.
+	for _, detector := range DefaultDetectors() {
+		v, ok := detector.(detectors.CredentialVerifier)
+		if !ok {
+			continue
+		}
+		name := detectorspb.DetectorType_name[int32(detector.Type())]
+		parts := v.CredentialParts()
+		if len(parts) < 2 {
+			t.Errorf("detector %q has %d credential parts, want at least 2", name, len(parts))
+		}
+		seen := make(map[detectors.CredentialPartName]struct{}, len(parts))
+		for _, part := range parts {
+			if _, ok := seen[part]; ok {
+				t.Errorf("detector %q has duplicate credential part %q", name, part)
+			}
+			seen[part] = struct{}{}
+		}
+	}
+}
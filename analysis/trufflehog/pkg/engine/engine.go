@@ -23,15 +23,21 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/defaults"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/giturl"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/handlers"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/output"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/verificationcache"
 )
 
 const detectionTimeout = 10 * time.Second
 
+// maxVerificationRequeues is how often a match whose verification was rate
+// limited is detected again before its results are reported as they are.
+const maxVerificationRequeues = 5
+
 var errOverlap = errors.New(
 	"More than one detector has found this result. For your safety, verification has been disabled." +
 		"You can override this behavior by using the --allow-verification-overlap flag.",
@@ -105,6 +111,15 @@ type Config struct {
 
 	// Verify determines whether the scanner will verify candidate secrets.
 	Verify bool
+	// VerificationPolicy limits which detectors may verify candidate secrets.
+	// It takes precedence over per-detector verification overrides.
+	VerificationPolicy detectors.VerificationPolicy
+	// VerificationCache, if set, is consulted before verifying a candidate
+	// secret and stores the outcomes of new verifications.
+	VerificationCache *verificationcache.Cache
+	// VerificationAuditLog, if set, records every request sent to verify a
+	// candidate secret.
+	VerificationAuditLog *detectors.AuditLog
 
 	// Defines which results will be notified by the engine
 	// (e.g., verified, unverified, unknown)
@@ -187,6 +202,9 @@ type Engine struct {
 	verificationOverlapWg         sync.WaitGroup
 	wgDetectorWorkers             sync.WaitGroup
 	WgNotifier                    sync.WaitGroup
+	// wgRequeued tracks the matches that were requeued because their
+	// verification was rate limited.
+	wgRequeued sync.WaitGroup
 
 	// Runtime information.
 	metrics runtimeMetrics
@@ -202,6 +220,12 @@ type Engine struct {
 
 	// verify determines whether the scanner will attempt to verify candidate secrets.
 	verify bool
+	// verificationCache remembers verification outcomes across chunks and runs.
+	verificationCache *verificationcache.Cache
+	// verificationPolicy limits which detectors may verify candidate secrets.
+	verificationPolicy detectors.VerificationPolicy
+	// verificationAuditLog records the requests sent to verify candidate secrets.
+	verificationAuditLog *detectors.AuditLog
 
 	// Note: bad hack only used for testing.
 	verificationOverlapTracker *verificationOverlapTracker
@@ -222,6 +246,9 @@ func NewEngine(ctx context.Context, cfg *Config) (*Engine, error) {
 		detectors:                           cfg.Detectors,
 		dispatcher:                          cfg.Dispatcher,
 		verify:                              cfg.Verify,
+		verificationCache:                   cfg.VerificationCache,
+		verificationPolicy:                  cfg.VerificationPolicy,
+		verificationAuditLog:                cfg.VerificationAuditLog,
 		filterUnverified:                    cfg.FilterUnverified,
 		filterEntropy:                       cfg.FilterEntropy,
 		printAvgDetectorTime:                cfg.PrintAvgDetectorTime,
@@ -716,6 +743,7 @@ func (e *Engine) Finish(ctx context.Context) error {
 	close(e.verificationOverlapChunksChan)
 	e.verificationOverlapWg.Wait()
 
+	e.wgRequeued.Wait() // Wait for rate limited matches to be verified again.
 	close(e.detectableChunksChan)
 	e.wgDetectorWorkers.Wait() // Wait for the detector workers to finish detecting chunks.
 
@@ -748,6 +776,12 @@ type detectableChunk struct {
 	chunk    sources.Chunk
 	decoder  detectorspb.DecoderType
 	wgDoneFn func()
+
+	// matches, if set, replaces the matches of the detector. It is set for
+	// requeued matches.
+	matches [][]byte
+	// requeues counts how often the matches were requeued.
+	requeues int
 }
 
 // verificationOverlapChunk is a decoded chunk that has multiple detectors that match it.
@@ -835,6 +869,14 @@ func (e *Engine) shouldVerifyChunk(
 	if !e.verify {
 		return false
 	}
+	// Matches embed the detector as an interface, which hides its optional interfaces.
+	if match, ok := detector.(*ahocorasick.DetectorMatch); ok {
+		detector = match.Detector
+	}
+	// So does the verification policy, which can't be overridden per detector.
+	if !e.verificationPolicy.Allows(detector) {
+		return false
+	}
 
 	detectorId := config.DetectorID{ID: detector.Type(), Version: 0}
 
@@ -939,6 +981,11 @@ func (e *Engine) verificationOverlapWorker(ctx context.Context) {
 				if _, ok := detectorKeysWithResults[detector.Key]; !ok {
 					detectorKeysWithResults[detector.Key] = detector
 				}
+				// Generic detectors find secrets by the key they're assigned to, so they find those of vendor
+				// detectors too. Their results are reported apart and never keep vendor results from being verified.
+				if detector.Key.Type() == detectorspb.DetectorType_Generic {
+					continue
+				}
 
 				// If results filtration eliminates a rotated secret, then that rotation will never be reported. This
 				// problem can theoretically occur for any scan, but we've only actually seen it in practice during
@@ -997,9 +1044,10 @@ func (e *Engine) verificationOverlapWorker(ctx context.Context) {
 			}
 		}
 
+		sourceVerify := chunk.chunk.Verify
 		for _, detector := range detectorKeysWithResults {
 			wgDetect.Add(1)
-			chunk.chunk.Verify = e.shouldVerifyChunk(chunk.chunk.Verify, detector, e.detectorVerificationOverrides)
+			chunk.chunk.Verify = e.shouldVerifyChunk(sourceVerify, detector, e.detectorVerificationOverrides)
 			e.detectableChunksChan <- detectableChunk{
 				chunk:    chunk.chunk,
 				detector: detector,
@@ -1048,6 +1096,9 @@ func (e *Engine) detectChunk(ctx context.Context, data detectableChunk) {
 	// relevant portions of the chunk data that were matched.
 	// This avoids the need for additional regex processing on the entire chunk data.
 	matches := data.detector.Matches()
+	if data.matches != nil {
+		matches = data.matches
+	}
 	for _, matchBytes := range matches {
 		matchCount++
 		detectBytesPerMatch.Observe(float64(len(matchBytes)))
@@ -1056,7 +1107,8 @@ func (e *Engine) detectChunk(ctx context.Context, data detectableChunk) {
 		t := time.AfterFunc(detectionTimeout+1*time.Second, func() {
 			ctx.Logger().Error(nil, "a detector ignored the context timeout")
 		})
-		results, err := data.detector.Detector.FromData(ctx, data.chunk.Verify, matchBytes)
+		rateLimits := detectors.NewRateLimitScope(data.detector.Type())
+		results, err := e.fromData(ctx, data.detector.Detector, data.chunk.Verify, matchBytes, rateLimits)
 		t.Stop()
 		cancel()
 		if err != nil {
@@ -1064,6 +1116,11 @@ func (e *Engine) detectChunk(ctx context.Context, data detectableChunk) {
 			continue
 		}
 
+		if retryAt, ok := rateLimits.RateLimited(); ok && data.requeues < maxVerificationRequeues && hasVerificationError(results) {
+			e.requeueMatch(ctx, data, matchBytes, retryAt)
+			continue
+		}
+
 		detectorExecutionCount.WithLabelValues(
 			data.detector.Type().String(),
 			strconv.Itoa(int(data.chunk.JobID)),
@@ -1107,6 +1164,90 @@ func (e *Engine) detectChunk(ctx context.Context, data detectableChunk) {
 	data.wgDoneFn()
 }
 
+// fromData runs the detector on data. When verifying with a verification
+// cache or audit log, the results are first found without verifying. The
+// results of detectors that implement detectors.ResultVerifier are then
+// verified one by one with Detector.Verify, unless the cache already has their
+// outcome. Every other detector verifies in FromData, unless the cache has the
+// outcome of each of its results. The requests sent to verify them are rate
+// limited as part of rateLimits and recorded in the verification audit log.
+func (e *Engine) fromData(
+	ctx context.Context,
+	detector detectors.Detector,
+	verify bool,
+	data []byte,
+	rateLimits *detectors.RateLimitScope,
+) ([]detectors.Result, error) {
+	if !verify {
+		return detector.FromData(ctx, false, data)
+	}
+	verifyCtx := detectors.WithRateLimitScope(ctx, rateLimits)
+	if e.verificationCache == nil && e.verificationAuditLog == nil {
+		return detector.FromData(verifyCtx, true, data)
+	}
+	results, err := detector.FromData(ctx, false, data)
+	if err != nil {
+		return nil, err
+	}
+
+	detectorID := config.GetDetectorID(detector).String()
+	if detectors.VerifiesResults(detector) {
+		for i := range results {
+			auditCtx := detectors.WithAuditLog(verifyCtx, e.verificationAuditLog, detectorID, detectors.ResultAuditSecret(&results[i]))
+			e.verificationCache.VerifyResult(auditCtx, detector, &results[i])
+		}
+		return results, nil
+	}
+	if len(results) == 0 || e.verificationCache.ApplyResults(detector, results) {
+		return results, nil
+	}
+
+	// The results found without verifying identify the secrets that the
+	// requests sent while verifying belong to.
+	secrets := make([]detectors.AuditSecret, len(results))
+	for i := range results {
+		secrets[i] = detectors.ResultAuditSecret(&results[i])
+	}
+	verifyCtx = detectors.WithAuditLog(verifyCtx, e.verificationAuditLog, detectorID, secrets...)
+
+	results, err = detector.FromData(verifyCtx, true, data)
+	if err != nil {
+		return nil, err
+	}
+	e.verificationCache.SetResults(detector, results)
+	return results, nil
+}
+
+// hasVerificationError returns true if any of results couldn't be verified.
+func hasVerificationError(results []detectors.Result) bool {
+	for i := range results {
+		if results[i].VerificationError() != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// requeueMatch detects a match of data again once the host that rate limited
+// its verification accepts requests again, instead of reporting results that
+// couldn't be verified.
+func (e *Engine) requeueMatch(ctx context.Context, data detectableChunk, match []byte, retryAt time.Time) {
+	requeued := detectableChunk{
+		detector: data.detector,
+		chunk:    data.chunk,
+		decoder:  data.decoder,
+		wgDoneFn: e.wgRequeued.Done,
+		matches:  [][]byte{match},
+		requeues: data.requeues + 1,
+	}
+	ctx.Logger().V(3).Info("verification rate limited, requeueing match",
+		"retry_at", retryAt,
+		"requeues", requeued.requeues,
+	)
+	e.wgRequeued.Add(1)
+	time.AfterFunc(time.Until(retryAt), func() { e.detectableChunksChan <- requeued })
+}
+
 func (e *Engine) filterResults(
 	ctx context.Context,
 	detector *ahocorasick.DetectorMatch,
@@ -1157,6 +1298,12 @@ func (e *Engine) processResult(
 	if ignoreLinePresent {
 		return
 	}
+	if data.chunk.SourceMetadata.GetExtraction() != nil {
+		copyChunk := data.chunk
+		copyChunk.SourceMetadata = proto.Clone(data.chunk.SourceMetadata).(*source_metadatapb.MetaData)
+		SetResultExtraction(&copyChunk, &res)
+		data.chunk = copyChunk
+	}
 
 	secret := detectors.CopyMetadata(&data.chunk, res)
 	secret.DecoderType = data.decoder
@@ -1254,6 +1401,68 @@ func FragmentLineOffset(chunk *sources.Chunk, result *detectors.Result) (int64,
 	return lineNumber, false
 }
 
+// SetResultExtraction records where a result was found within the data a handler extracted from a file: the
+// address of its string for the string sections of executables, and otherwise its line within the extracted file
+// along with, for data decoded from structured formats, its key path. The chunk's metadata is modified in place.
+func SetResultExtraction(chunk *sources.Chunk, result *detectors.Result) {
+	extraction := chunk.SourceMetadata.GetExtraction()
+	if extraction.GetSection() != "" {
+		if address, ok := FragmentAddress(chunk, result); ok {
+			extraction.Address = address
+		}
+		extraction.LineAddresses = nil
+		return
+	}
+	// Only the text decoded from structured data is written on "key.path = value" lines; raw strings, literals and
+	// original sources are scanned as they are.
+	if handlers.HasKeyPaths(extraction.GetStage()) && extraction.GetSourcePath() == "" {
+		if keyPath, ok := FragmentKeyPath(chunk, result); ok {
+			extraction.KeyPath = keyPath
+		}
+	}
+	// The line of the chunk's first byte is offset by the line of the result within the chunk.
+	if extraction.GetLine() > 0 {
+		if offset, _ := FragmentLineOffset(chunk, result); offset > 0 {
+			extraction.Line += offset
+		}
+	}
+}
+
+// FragmentKeyPath returns the key path of the line a result was found on, for chunks that handlers decoded
+// from structured data into "key.path = value" lines.
+func FragmentKeyPath(chunk *sources.Chunk, result *detectors.Result) (string, bool) {
+	before, _, found := bytes.Cut(chunk.Data, result.Raw)
+	if !found {
+		return "", false
+	}
+	line := before[bytes.LastIndexByte(before, '\n')+1:]
+	keyPath, _, found := bytes.Cut(line, []byte(" = "))
+	if !found || len(keyPath) == 0 {
+		return "", false
+	}
+	return string(keyPath), true
+}
+
+// FragmentAddress returns the address of the string a result was found in, for chunks that handlers extracted
+// from the string sections of executables. These chunks hold one string per line, and are tagged with the address
+// of their first byte, or, for strings that aren't laid out as they are in the section, the address of each line.
+func FragmentAddress(chunk *sources.Chunk, result *detectors.Result) (uint64, bool) {
+	before, _, found := bytes.Cut(chunk.Data, result.Raw)
+	if !found {
+		return 0, false
+	}
+	extraction := chunk.SourceMetadata.GetExtraction()
+	if lineAddresses := extraction.GetLineAddresses(); len(lineAddresses) > 0 {
+		line := bytes.Count(before, []byte("\n"))
+		if line >= len(lineAddresses) {
+			return 0, false
+		}
+		return lineAddresses[line], true
+	}
+	lineStart := bytes.LastIndexByte(before, '\n') + 1
+	return extraction.GetAddress() + uint64(lineStart), true
+}
+
 // FragmentFirstLineAndLink extracts the first line number and the link from the chunk metadata.
 // It returns:
 //   - The first line number of the fragment.
@@ -8,16 +8,22 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 
+	"github.com/trufflesecurity/trufflehog/v3/pkg/cache/simple"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/config"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/custom_detectors"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/decoders"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/configsecret"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/gitlab/v2"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/defaults"
@@ -26,6 +32,7 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/verificationcache"
 )
 
 const fakeDetectorKeyword = "fakedetector"
@@ -54,8 +61,8 @@ func (f fakeDetectorV1) Version() int                   { return 1 }
 
 func (f fakeDetectorV1) Description() string { return "" }
 
-func (s fakeDetectorV1) Verify(ctx aCtx.Context, secret string) bool {
-	return false
+func (s fakeDetectorV1) Verify(ctx aCtx.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerificationOutcome{Status: detectors.VerificationStatusInvalid}
 }
 
 func (f fakeDetectorV2) FromData(_ aCtx.Context, _ bool, _ []byte) ([]detectors.Result, error) {
@@ -73,8 +80,8 @@ func (f fakeDetectorV2) Type() detectorspb.DetectorType { return detectorspb.Det
 func (f fakeDetectorV2) Version() int                   { return 2 }
 
 func (f fakeDetectorV2) Description() string { return "" }
-func (f fakeDetectorV2) Verify(ctx aCtx.Context, secret string) bool {
-	return false
+func (f fakeDetectorV2) Verify(ctx aCtx.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerificationOutcome{Status: detectors.VerificationStatusInvalid}
 }
 func TestFragmentLineOffset(t *testing.T) {
 	tests := []struct {
@@ -165,6 +172,185 @@ func TestFragmentLineOffset(t *testing.T) {
 	}
 }
 
+func TestFragmentKeyPath(t *testing.T) {
+	tests := []struct {
+		name            string
+		data            string
+		raw             string
+		expectedKeyPath string
+		found           bool
+	}{
+		{
+			name:            "key path on result line",
+			data:            "Enabled = true\nServers[0].ApiKey = prefix-secret\nPort = 443",
+			raw:             "secret",
+			expectedKeyPath: "Servers[0].ApiKey",
+			found:           true,
+		},
+		{
+			name:            "first line",
+			data:            "ApiKey = secret",
+			raw:             "secret",
+			expectedKeyPath: "ApiKey",
+			found:           true,
+		},
+		{
+			name:  "line without key path",
+			data:  "ApiKey = value\ncontinued secret",
+			raw:   "secret",
+			found: false,
+		},
+		{
+			name:  "result not found",
+			data:  "ApiKey = value",
+			raw:   "secret",
+			found: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunk := &sources.Chunk{Data: []byte(tt.data)}
+			keyPath, found := FragmentKeyPath(chunk, &detectors.Result{Raw: []byte(tt.raw)})
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.expectedKeyPath, keyPath)
+		})
+	}
+}
+
+func TestFragmentAddress(t *testing.T) {
+	tests := []struct {
+		name            string
+		data            string
+		raw             string
+		lineAddresses   []uint64
+		expectedAddress uint64
+		found           bool
+	}{
+		{
+			name:            "first string",
+			data:            "prefix-secret\nother",
+			raw:             "secret",
+			expectedAddress: 0x1000,
+			found:           true,
+		},
+		{
+			name:            "later string",
+			data:            "first\n\nprefix-secret\nother",
+			raw:             "secret",
+			expectedAddress: 0x1007,
+			found:           true,
+		},
+		{
+			name:            "decoded strings",
+			data:            "héllo\nprefix-secret\n",
+			raw:             "secret",
+			lineAddresses:   []uint64{0x500, 0x50c},
+			expectedAddress: 0x50c,
+			found:           true,
+		},
+		{
+			name:            "decoded string spanning lines",
+			data:            "first\nmulti\nline-secret\n",
+			raw:             "secret",
+			lineAddresses:   []uint64{0x500, 0x510, 0x510},
+			expectedAddress: 0x510,
+			found:           true,
+		},
+		{
+			name:  "result not found",
+			data:  "first\nsecond",
+			raw:   "secret",
+			found: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunk := &sources.Chunk{
+				Data: []byte(tt.data),
+				SourceMetadata: &source_metadatapb.MetaData{
+					Extraction: &source_metadatapb.Extraction{
+						Section:       "__TEXT,__cstring",
+						Address:       0x1000,
+						LineAddresses: tt.lineAddresses,
+					},
+				},
+			}
+			address, found := FragmentAddress(chunk, &detectors.Result{Raw: []byte(tt.raw)})
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.expectedAddress, address)
+		})
+	}
+}
+
+func TestSetResultExtraction(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		extraction *source_metadatapb.Extraction
+		expected   *source_metadatapb.Extraction
+	}{
+		{
+			name:       "section address",
+			data:       "first\nprefix-secret",
+			extraction: &source_metadatapb.Extraction{Section: ".rodata", Address: 0x1000},
+			expected:   &source_metadatapb.Extraction{Section: ".rodata", Address: 0x1006},
+		},
+		{
+			name:       "decoded key path and line",
+			data:       "Config.Name = app\nConfig.ApiKey = prefix-secret",
+			extraction: &source_metadatapb.Extraction{Stage: "plist", EntryPath: "Payload/App.app/Info.plist", Line: 1},
+			expected: &source_metadatapb.Extraction{
+				Stage:     "plist",
+				EntryPath: "Payload/App.app/Info.plist",
+				Line:      2,
+				KeyPath:   "Config.ApiKey",
+			},
+		},
+		{
+			name:       "dex string pool key path",
+			data:       "com.example.Keys.load = prefix-secret",
+			extraction: &source_metadatapb.Extraction{Stage: "dexstrings", EntryPath: "classes.dex"},
+			expected: &source_metadatapb.Extraction{
+				Stage:     "dexstrings",
+				EntryPath: "classes.dex",
+				KeyPath:   "com.example.Keys.load",
+			},
+		},
+		{
+			name:       "raw literal",
+			data:       "var config = prefix-secret",
+			extraction: &source_metadatapb.Extraction{Stage: "hermes", EntryPath: "assets/index.android.bundle"},
+			expected:   &source_metadatapb.Extraction{Stage: "hermes", EntryPath: "assets/index.android.bundle"},
+		},
+		{
+			name:       "archive entry line",
+			data:       "first\nsecond\napi_key = prefix-secret",
+			extraction: &source_metadatapb.Extraction{EntryPath: "config/app.ini", Line: 41},
+			expected:   &source_metadatapb.Extraction{EntryPath: "config/app.ini", Line: 43},
+		},
+		{
+			name:       "original source line",
+			data:       "import x from 'y';\nconst apiKey = \"prefix-secret\";",
+			extraction: &source_metadatapb.Extraction{Stage: "sourcemap", SourcePath: "src/config.ts", Line: 1},
+			expected:   &source_metadatapb.Extraction{Stage: "sourcemap", SourcePath: "src/config.ts", Line: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunk := &sources.Chunk{
+				Data:           []byte(tt.data),
+				SourceMetadata: &source_metadatapb.MetaData{Extraction: tt.extraction},
+			}
+			SetResultExtraction(chunk, &detectors.Result{Raw: []byte("secret")})
+			assert.True(t, proto.Equal(tt.expected, chunk.SourceMetadata.GetExtraction()),
+				"got %v", chunk.SourceMetadata.GetExtraction())
+		})
+	}
+}
+
 func setupFragmentLineOffsetBench(totalLines, needleLine int) (*sources.Chunk, *detectors.Result) {
 	data := make([]byte, 0, 4096)
 	needle := []byte("needle")
@@ -584,8 +770,8 @@ func (testDetectorV1) Type() detectorspb.DetectorType { return TestDetectorType
 
 func (testDetectorV1) Description() string { return "" }
 
-func (testDetectorV1) Verify(ctx aCtx.Context, secret string) bool {
-	return false
+func (testDetectorV1) Verify(ctx aCtx.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerificationOutcome{Status: detectors.VerificationStatusInvalid}
 }
 
 var _ detectors.Detector = (*testDetectorV2)(nil)
@@ -606,8 +792,8 @@ func (testDetectorV2) Type() detectorspb.DetectorType { return TestDetectorType2
 
 func (testDetectorV2) Description() string { return "" }
 
-func (testDetectorV2) Verify(ctx aCtx.Context, secret string) bool {
-	return false
+func (testDetectorV2) Verify(ctx aCtx.Context, secret string) detectors.VerificationOutcome {
+	return detectors.VerificationOutcome{Status: detectors.VerificationStatusInvalid}
 }
 
 func TestVerificationOverlapChunkFalsePositive(t *testing.T) {
@@ -654,6 +840,152 @@ func TestVerificationOverlapChunkFalsePositive(t *testing.T) {
 	assert.Equal(t, want, e.GetMetrics().UnverifiedSecretsFound)
 }
 
+// overlapDetector finds a secret after its keyword, like other detectors sharing the keyword.
+type overlapDetector struct {
+	detectorType detectorspb.DetectorType
+	secret       string
+}
+
+func (d overlapDetector) FromData(_ aCtx.Context, _ bool, _ []byte) ([]detectors.Result, error) {
+	return []detectors.Result{{DetectorType: d.detectorType, Raw: []byte(d.secret)}}, nil
+}
+
+func (d overlapDetector) Keywords() []string { return []string{"overlap"} }
+
+func (d overlapDetector) Type() detectorspb.DetectorType { return d.detectorType }
+
+func (d overlapDetector) Description() string { return "" }
+
+func (d overlapDetector) Verify(aCtx.Context, string) detectors.VerificationOutcome {
+	return detectors.VerificationOutcome{Status: detectors.VerificationStatusVerified}
+}
+
+// safeOverlapDetector declares read-only verification metadata.
+type safeOverlapDetector struct{ overlapDetector }
+
+func (safeOverlapDetector) VerificationMetadata() detectors.VerificationMetadata {
+	return detectors.VerificationMetadata{ReadOnly: true}
+}
+
+func TestVerificationOverlapWorker_SafeOnlyPolicy(t *testing.T) {
+	ctx := context.Background()
+	allDetectors := []detectors.Detector{
+		safeOverlapDetector{overlapDetector{detectorType: -10, secret: "Xq3vR8mTz1LpW6nY"}},
+		overlapDetector{detectorType: -11, secret: "b7KdJ2sHf9GcA4eU"},
+		overlapDetector{detectorType: -12, secret: "N5tPy0wQm8ZrC3xV"},
+	}
+	core := ahocorasick.NewAhoCorasickCore(allDetectors)
+	data := []byte("overlap secrets")
+
+	// Which detector is handed the chunk first depends on map order, so the chunk is sent many times.
+	const chunks = 20
+	e := &Engine{
+		verify:                        true,
+		verificationPolicy:            detectors.VerificationPolicySafeOnly,
+		retainFalsePositives:          true,
+		detectableChunksChan:          make(chan detectableChunk, chunks*len(allDetectors)),
+		verificationOverlapChunksChan: make(chan verificationOverlapChunk, chunks),
+	}
+	for i := 0; i < chunks; i++ {
+		e.verificationOverlapChunksChan <- verificationOverlapChunk{
+			chunk:                       sources.Chunk{Data: data, Verify: true},
+			detectors:                   core.FindDetectorMatches(data),
+			verificationOverlapWgDoneFn: func() {},
+		}
+	}
+	close(e.verificationOverlapChunksChan)
+
+	done := make(chan struct{})
+	go func() {
+		e.verificationOverlapWorker(ctx)
+		close(done)
+	}()
+	for i := 0; i < chunks*len(allDetectors); i++ {
+		detectable := <-e.detectableChunksChan
+		detectable.wgDoneFn()
+		_, safe := detectable.detector.Detector.(safeOverlapDetector)
+		assert.Equal(t, safe, detectable.chunk.Verify, "detector %s", detectable.detector.Type())
+	}
+	<-done
+}
+
+// vendorKeyDetector finds the keys of a vendor by their format and verifies them without sending requests.
+type vendorKeyDetector struct{}
+
+var vendorKeyPat = regexp.MustCompile(`\bvk_live_[a-zA-Z0-9]{24}\b`)
+
+func (vendorKeyDetector) FromData(_ aCtx.Context, verify bool, data []byte) ([]detectors.Result, error) {
+	var results []detectors.Result
+	for _, match := range vendorKeyPat.FindAll(data, -1) {
+		results = append(results, detectors.Result{DetectorType: -20, Raw: match, Verified: verify})
+	}
+	return results, nil
+}
+
+func (vendorKeyDetector) Keywords() []string { return []string{"vk_live_"} }
+
+func (vendorKeyDetector) Type() detectorspb.DetectorType { return -20 }
+
+func (vendorKeyDetector) Description() string { return "" }
+
+func (vendorKeyDetector) Verify(aCtx.Context, string) detectors.VerificationOutcome {
+	return detectors.VerificationOutcome{Status: detectors.VerificationStatusVerified}
+}
+
+// resultsCaptureDispatcher is a test dispatcher that captures the results it's given.
+type resultsCaptureDispatcher struct {
+	mu      sync.Mutex
+	results []detectors.ResultWithMetadata
+}
+
+func (d *resultsCaptureDispatcher) Dispatch(_ context.Context, result detectors.ResultWithMetadata) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.results = append(d.results, result)
+	return nil
+}
+
+func TestVerificationOverlapChunk_Generic(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	const secret = "vk_live_Q8zT2vK9xLm4PwN7sYbR3dF6"
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("payments:\n  vendor_key: "+secret+"\n"), 0o644))
+
+	dispatcher := new(resultsCaptureDispatcher)
+	c := Config{
+		Concurrency:   1,
+		Decoders:      decoders.DefaultDecoders(),
+		Detectors:     []detectors.Detector{vendorKeyDetector{}, configsecret.Scanner{}},
+		Verify:        true,
+		SourceManager: sources.NewManager(sources.WithSourceUnits(), sources.WithBufferedOutput(64)),
+		Dispatcher:    dispatcher,
+	}
+	e, err := NewEngine(ctx, &c)
+	assert.NoError(t, err)
+	e.verificationOverlapTracker = new(verificationOverlapTracker)
+	e.Start(ctx)
+
+	_, err = e.ScanFileSystem(ctx, sources.FilesystemConfig{Paths: []string{path}})
+	assert.NoError(t, err)
+	assert.NoError(t, e.Finish(ctx))
+
+	// Both detectors report the secret, and the vendor detector still verifies it.
+	assert.Zero(t, e.verificationOverlapTracker.verificationOverlapDuplicateCount)
+	types := make(map[detectorspb.DetectorType]detectors.ResultWithMetadata)
+	for _, res := range dispatcher.results {
+		assert.Equal(t, secret, string(res.Raw))
+		types[res.DetectorType] = res
+	}
+	assert.Len(t, types, 2)
+	vendor, ok := types[-20]
+	assert.True(t, ok)
+	assert.True(t, vendor.Verified)
+	assert.NoError(t, vendor.VerificationError())
+	assert.Contains(t, types, detectorspb.DetectorType_Generic)
+}
+
 func TestRetainFalsePositives(t *testing.T) {
 	ctx := context.Background()
 
@@ -993,7 +1325,9 @@ func (c customCleaner) FromData(aCtx.Context, bool, []byte) ([]detectors.Result,
 func (c customCleaner) Keywords() []string             { return []string{} }
 func (c customCleaner) Type() detectorspb.DetectorType { return detectorspb.DetectorType(-1) }
 
-func (c customCleaner) Verify(aCtx.Context, string) bool { return false }
+func (c customCleaner) Verify(aCtx.Context, string) detectors.VerificationOutcome {
+	return detectors.VerificationOutcome{Status: detectors.VerificationStatusInvalid}
+}
 
 func (customCleaner) Description() string { return "" }
 
@@ -1283,3 +1617,123 @@ def test_something():
 		})
 	}
 }
+
+// countingVerifyDetector finds a single secret and counts how it is verified.
+// Its Verify reproduces the verification of FromData.
+type countingVerifyDetector struct {
+	fromDataCalls  int
+	verifyingCalls int
+	verifyCalls    int
+}
+
+var _ detectors.Detector = (*countingVerifyDetector)(nil)
+var _ detectors.ResultVerifier = (*countingVerifyDetector)(nil)
+
+func (d *countingVerifyDetector) FromData(_ aCtx.Context, verify bool, _ []byte) ([]detectors.Result, error) {
+	d.fromDataCalls++
+	if verify {
+		d.verifyingCalls++
+	}
+	return []detectors.Result{{DetectorType: detectorspb.DetectorType_AWS, Raw: []byte("valid")}}, nil
+}
+func (d *countingVerifyDetector) Keywords() []string             { return nil }
+func (d *countingVerifyDetector) Type() detectorspb.DetectorType { return detectorspb.DetectorType_AWS }
+func (d *countingVerifyDetector) Description() string            { return "" }
+func (d *countingVerifyDetector) Verify(_ aCtx.Context, secret string) detectors.VerificationOutcome {
+	d.verifyCalls++
+	return detectors.NewVerificationOutcome(secret == "valid", nil)
+}
+func (d *countingVerifyDetector) VerifiesResults() bool { return true }
+
+func TestEngine_FromDataVerificationCache(t *testing.T) {
+	ctx := context.Background()
+	d := &countingVerifyDetector{}
+	e := &Engine{verificationCache: verificationcache.New(simple.NewCache[verificationcache.Entry]())}
+
+	for i := 0; i < 2; i++ {
+		results, err := e.fromData(ctx, d, true, nil, detectors.NewRateLimitScope(d.Type()))
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+		assert.True(t, results[0].Verified)
+	}
+	// The detector finds the secret once per call, never verifies it itself,
+	// and the second call is answered from the cache.
+	assert.Equal(t, 2, d.fromDataCalls)
+	assert.Equal(t, 0, d.verifyingCalls)
+	assert.Equal(t, 1, d.verifyCalls)
+}
+
+// hostVerifyDetector finds an ID and a secret, and verifies them against a host
+// found next to them that never reaches its results, so its Verify can't
+// reproduce the verification of FromData.
+type hostVerifyDetector struct {
+	verifyingCalls int
+	verifyCalls    int
+}
+
+var _ detectors.Detector = (*hostVerifyDetector)(nil)
+
+func (d *hostVerifyDetector) FromData(_ aCtx.Context, verify bool, data []byte) ([]detectors.Result, error) {
+	var results []detectors.Result
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			continue
+		}
+		host, id, secret := fields[0], fields[1], fields[2]
+		r := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Twilio,
+			Raw:          []byte(id),
+			RawV2:        []byte(id + detectors.CredentialSeparator + secret),
+		}
+		r.SetCredential(
+			detectors.CredentialPart{Name: detectors.CredentialPartID, Value: id},
+			detectors.CredentialPart{Name: detectors.CredentialPartSecret, Value: secret},
+		)
+		if verify {
+			d.verifyingCalls++
+			r.Verified = host == "good.example.com" && secret == "valid"
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+func (d *hostVerifyDetector) Keywords() []string             { return nil }
+func (d *hostVerifyDetector) Type() detectorspb.DetectorType { return detectorspb.DetectorType_Twilio }
+func (d *hostVerifyDetector) Description() string            { return "" }
+func (d *hostVerifyDetector) Verify(aCtx.Context, string) detectors.VerificationOutcome {
+	// Without the host, no secret can be verified.
+	d.verifyCalls++
+	return detectors.NewVerificationOutcome(false, nil)
+}
+
+func TestEngine_FromDataVerificationCacheOutcomes(t *testing.T) {
+	ctx := context.Background()
+	data := []byte("good.example.com id1 valid\nbad.example.com id2 valid\ngood.example.com id3 invalid")
+
+	verifiedByID := func(results []detectors.Result) map[string]bool {
+		verified := make(map[string]bool, len(results))
+		for _, r := range results {
+			verified[string(r.Raw)] = r.Verified
+		}
+		return verified
+	}
+
+	uncached := &hostVerifyDetector{}
+	results, err := (&Engine{}).fromData(ctx, uncached, true, data, detectors.NewRateLimitScope(uncached.Type()))
+	assert.NoError(t, err)
+	want := verifiedByID(results)
+	assert.Equal(t, map[string]bool{"id1": true, "id2": false, "id3": false}, want)
+
+	d := &hostVerifyDetector{}
+	e := &Engine{verificationCache: verificationcache.New(simple.NewCache[verificationcache.Entry]())}
+	for i := 0; i < 2; i++ {
+		results, err := e.fromData(ctx, d, true, data, detectors.NewRateLimitScope(d.Type()))
+		assert.NoError(t, err)
+		assert.Equal(t, want, verifiedByID(results))
+	}
+	// The detector verifies in FromData, and the second call is answered from
+	// the cache.
+	assert.Equal(t, 3, d.verifyingCalls)
+	assert.Equal(t, 0, d.verifyCalls)
+}
@@ -18,6 +18,8 @@ func (e *Engine) ScanFileSystem(ctx context.Context, c sources.FilesystemConfig)
 		Paths:            c.Paths,
 		IncludePathsFile: c.IncludePathsFile,
 		ExcludePathsFile: c.ExcludePathsFile,
+		IncludeClasses:   c.IncludeClasses,
+		ExcludeClasses:   c.ExcludeClasses,
 	}
 	var conn anypb.Any
 	err := anypb.MarshalFrom(&conn, connection, proto.MarshalOptions{})
@@ -1,71 +1,537 @@
 package engine
 
 import (
-	"context"
+	"bufio"
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
 
-	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/defaults"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/config"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/verificationcache"
 )
 
+// DetectedSecret is a previously detected candidate secret that should be
+// (re-)verified by the `verify` command.
 type DetectedSecret struct {
-	Detector    string `json:"detector"`
-	Secret      string `json:"secret"`
-	Verified    bool   `json:"verified"`
-	Reason      string `json:"reason"`
-	Llama3_done bool   `json:"llama3_done"`
+	// Detector is the detector type number or name, optionally followed by a
+	// version, e.g. "8", "Github" or "github.v2".
+	Detector string `json:"detector"`
+	// DetectorName is the detector type name as found in the DetectorName
+	// field of the JSON output. If both Detector and DetectorName are set, they
+	// must refer to the same detector type.
+	DetectorName string `json:"detector_name,omitempty"`
+	// DetectorVersion selects a single version of a versioned detector. If it
+	// is 0, the secret is verified with every version of the detector type.
+	DetectorVersion int    `json:"detector_version,omitempty"`
+	Secret          string `json:"secret"`
+	// Credential contains the named parts of a multi-part secret, as found in
+	// the Credential field of the JSON output. If it is set, it is verified
+	// instead of Secret.
+	Credential  *detectors.Credential `json:"credential,omitempty"`
+	Verified    bool                  `json:"verified"`
+	Reason      string                `json:"reason"`
+	Llama3_done bool                  `json:"llama3_done"`
+
+	// Status distinguishes invalid secrets from ones whose verification
+	// failed, which should be retried, and from ones that weren't verified at
+	// all, which shouldn't. It summarizes Outcomes: a secret is verified if
+	// any detector version verified it, indeterminate if none did but at least
+	// one couldn't decide, and unsupported if none of them verified it.
+	Status detectors.VerificationStatus `json:"status,omitempty"`
+	// VerificationError is the redacted error of an indeterminate, invalid or
+	// unsupported verification.
+	VerificationError string `json:"verification_error,omitempty"`
+	// HTTPStatus is the status code of the last response received while
+	// verifying, if any.
+	HTTPStatus int               `json:"http_status,omitempty"`
+	ExtraData  map[string]string `json:"extra_data,omitempty"`
+	// Outcomes holds one outcome per detector version the secret was
+	// verified with.
+	Outcomes []DetectorOutcome `json:"outcomes,omitempty"`
 }
 
-func read_secret_file(filename string) DetectedSecret {
-	file, err := os.Open(filename)
-	if err != nil {
-		return DetectedSecret{}
+// DetectorOutcome is the outcome of verifying a DetectedSecret with a single
+// detector version.
+type DetectorOutcome struct {
+	// Detector is the name of the detector, including its version if it has
+	// one, e.g. "Github.v2".
+	Detector          string                       `json:"detector"`
+	DetectorVersion   int                          `json:"detector_version,omitempty"`
+	Status            detectors.VerificationStatus `json:"status"`
+	VerificationError string                       `json:"verification_error,omitempty"`
+	HTTPStatus        int                          `json:"http_status,omitempty"`
+	ExtraData         map[string]string            `json:"extra_data,omitempty"`
+}
+
+func newDetectorOutcome(id config.DetectorID, outcome detectors.VerificationOutcome) DetectorOutcome {
+	o := DetectorOutcome{
+		Detector:        id.String(),
+		DetectorVersion: id.Version,
+		Status:          outcome.Status,
+		HTTPStatus:      outcome.HTTPStatus,
+		ExtraData:       outcome.ExtraData,
+	}
+	if outcome.Error != nil {
+		o.VerificationError = outcome.Error.Error()
 	}
-	defer file.Close()
+	return o
+}
 
-	secret := DetectedSecret{}
-	decoder := json.NewDecoder(file)
-	err = decoder.Decode(&secret)
-	if err != nil {
-		return DetectedSecret{}
+// setOutcomes records the outcomes of a verification and summarizes them in
+// the top-level fields of the secret.
+func (s *DetectedSecret) setOutcomes(outcomes []DetectorOutcome) {
+	s.Outcomes = outcomes
+	s.Reason = "trufflehog"
+
+	// Pick the most conclusive outcome: verified beats indeterminate, which
+	// beats invalid, which beats unsupported.
+	summary := outcomes[0]
+	for _, o := range outcomes[1:] {
+		if statusRank(o.Status) > statusRank(summary.Status) {
+			summary = o
+		}
 	}
+	s.Verified = summary.Status == detectors.VerificationStatusVerified
+	s.Status = summary.Status
+	s.VerificationError = summary.VerificationError
+	s.HTTPStatus = summary.HTTPStatus
+	s.ExtraData = summary.ExtraData
+}
 
-	return secret
+func statusRank(status detectors.VerificationStatus) int {
+	switch status {
+	case detectors.VerificationStatusVerified:
+		return 3
+	case detectors.VerificationStatusIndeterminate:
+		return 2
+	case detectors.VerificationStatusInvalid:
+		return 1
+	default:
+		return 0
+	}
 }
 
-func (e *Engine) ScanVerify(ctx context.Context, filename string) (sources.JobProgressRef, error) {
-	fmt.Println("Scanning and verifying secrets")
-	secret := read_secret_file(filename)
+// detectorID resolves the detector type and version the secret should be
+// verified with from Detector, DetectorName and DetectorVersion.
+func (s *DetectedSecret) detectorID() (config.DetectorID, error) {
+	var id config.DetectorID
+	for _, input := range []string{s.Detector, s.DetectorName} {
+		if input == "" {
+			continue
+		}
+		parsed, err := config.ParseDetector(input)
+		if err != nil {
+			return config.DetectorID{}, err
+		}
+		if id.ID != 0 && parsed.ID != id.ID {
+			return config.DetectorID{}, fmt.Errorf("detector %q and detector name %q refer to different detectors", s.Detector, s.DetectorName)
+		}
+		id.ID = parsed.ID
+		if id.Version, err = mergeVersion(id.Version, parsed.Version); err != nil {
+			return config.DetectorID{}, err
+		}
+	}
+	if id.ID == 0 {
+		return config.DetectorID{}, errors.New("secret does not name a detector")
+	}
+	var err error
+	id.Version, err = mergeVersion(id.Version, s.DetectorVersion)
+	return id, err
+}
+
+// mergeVersion combines two detector versions of which either may be unset.
+func mergeVersion(a, b int) (int, error) {
+	switch {
+	case a == 0:
+		return b, nil
+	case b == 0 || a == b:
+		return a, nil
+	default:
+		return 0, fmt.Errorf("conflicting detector versions %d and %d", a, b)
+	}
+}
 
-	detectors := defaults.DefaultDetectors()
-	//TODO: find the right detctor
-	fmt.Println("verifying secret: ", secret.Secret)
+// VerifyConfig defines the candidates the `verify` command should verify.
+type VerifyConfig struct {
+	// Files are paths to files containing a single DetectedSecret JSON object.
+	Files []string
+	// Directories are walked recursively and every regular file in them is
+	// treated as a single DetectedSecret JSON file.
+	Directories []string
+	// JSONL are paths to files containing one DetectedSecret JSON object per
+	// line. The path "-" reads from stdin and writes the results to stdout.
+	JSONL []string
+	// Concurrency is the maximum number of candidates verified at once.
+	Concurrency int
+}
 
-	// Use the config as needed
-	for _, c := range detectors {
-		// Do something with c
-		if fmt.Sprintf("%d", c.Type().Number()) == secret.Detector {
-			fmt.Println("Found detector: ", c.Type())
-			verified := c.Verify(context.Background(), secret.Secret)
-			secret.Verified = verified
-			fmt.Println("Secret verified: ", verified)
-			secret.Reason = "trufflehog"
+// stdioPath is the JSONL path that denotes stdin / stdout.
+const stdioPath = "-"
 
-			file, err := os.Create(filename)
-			if err != nil {
-				return sources.JobProgressRef{}, err
+// verifyStats keeps track of the outcome of a batch verification.
+type verifyStats struct {
+	candidates    atomic.Uint64
+	verified      atomic.Uint64
+	indeterminate atomic.Uint64
+	unsupported   atomic.Uint64
+	noDetector    atomic.Uint64
+	failedFiles   atomic.Uint64
+	failedLines   atomic.Uint64
+}
+
+// verifier verifies DetectedSecrets against a fixed set of detectors.
+type verifier struct {
+	// detectorsByID is built once per batch. Versioned detectors are listed
+	// both under their own version and under version 0, which selects every
+	// version of a detector type.
+	detectorsByID map[config.DetectorID][]detectors.Detector
+	cache         *verificationcache.Cache
+	policy        detectors.VerificationPolicy
+	auditLog      *detectors.AuditLog
+	concurrency   int
+	stats         verifyStats
+}
+
+func newVerifier(dets []detectors.Detector, cache *verificationcache.Cache, policy detectors.VerificationPolicy, auditLog *detectors.AuditLog, concurrency int) *verifier {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	byID := make(map[config.DetectorID][]detectors.Detector, len(dets))
+	for _, d := range dets {
+		id := config.GetDetectorID(d)
+		byID[id] = append(byID[id], d)
+		if id.Version != 0 {
+			allVersions := config.DetectorID{ID: id.ID}
+			byID[allVersions] = append(byID[allVersions], d)
+		}
+	}
+	return &verifier{detectorsByID: byID, cache: cache, policy: policy, auditLog: auditLog, concurrency: concurrency}
+}
+
+// ScanVerify verifies the candidate secrets described by cfg using the
+// engine's detectors and writes the results back to where they were read from.
+// Candidates are verified concurrently, bounded by cfg.Concurrency, and every
+// output file is replaced atomically so an interrupted run never leaves a
+// partially written result behind.
+func (e *Engine) ScanVerify(ctx context.Context, cfg VerifyConfig) (sources.JobProgressRef, error) {
+	if len(cfg.Files) == 0 && len(cfg.Directories) == 0 && len(cfg.JSONL) == 0 {
+		return sources.JobProgressRef{}, errors.New("no candidates to verify: provide a file, directory or JSONL input")
+	}
+	if cfg.Concurrency == 0 {
+		cfg.Concurrency = e.concurrency
+	}
+	v := newVerifier(e.detectors, e.verificationCache, e.verificationPolicy, e.verificationAuditLog, cfg.Concurrency)
+
+	files := append([]string(nil), cfg.Files...)
+	for _, dir := range cfg.Directories {
+		found, err := secretFilesInDir(dir)
+		if err != nil {
+			return sources.JobProgressRef{}, fmt.Errorf("unable to list secret files in %q: %w", dir, err)
+		}
+		files = append(files, found...)
+	}
+	ctx.Logger().Info("verifying secrets", "files", len(files), "jsonl", len(cfg.JSONL), "concurrency", v.concurrency)
+
+	v.verifyFiles(ctx, files)
+	for _, path := range cfg.JSONL {
+		if err := v.verifyJSONL(ctx, path); err != nil {
+			return sources.JobProgressRef{}, fmt.Errorf("unable to verify JSONL input %q: %w", path, err)
+		}
+	}
+
+	ctx.Logger().Info("finished verifying secrets",
+		"candidates", v.stats.candidates.Load(),
+		"verified", v.stats.verified.Load(),
+		"indeterminate", v.stats.indeterminate.Load(),
+		"unsupported", v.stats.unsupported.Load(),
+		"no_detector", v.stats.noDetector.Load(),
+		"failed_files", v.stats.failedFiles.Load(),
+		"failed_lines", v.stats.failedLines.Load(),
+	)
+	if n := v.stats.noDetector.Load(); n > 0 {
+		return sources.JobProgressRef{}, fmt.Errorf("%d candidate(s) did not match any detector", n)
+	}
+	// Failed candidates are left as they were, so the run must not look like
+	// it verified them.
+	if files, lines := v.stats.failedFiles.Load(), v.stats.failedLines.Load(); files > 0 || lines > 0 {
+		return sources.JobProgressRef{}, fmt.Errorf("unable to verify %d file(s) and %d JSONL line(s)", files, lines)
+	}
+	return sources.JobProgressRef{}, nil
+}
+
+// secretFilesInDir returns every regular, non-hidden file below dir.
+func secretFilesInDir(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		// Hidden files include the temporary files written by writeFileAtomic.
+		if path != dir && len(d.Name()) > 0 && d.Name()[0] == '.' {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Type().IsRegular() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// verifyFiles verifies each single-secret file and rewrites it in place.
+func (v *verifier) verifyFiles(ctx context.Context, files []string) {
+	workerPool := new(errgroup.Group)
+	workerPool.SetLimit(v.concurrency)
+	for _, path := range files {
+		if ctx.Err() != nil {
+			break
+		}
+		workerPool.Go(func() error {
+			if err := v.verifyFile(ctx, path); err != nil {
+				v.stats.failedFiles.Add(1)
+				ctx.Logger().Error(err, "error verifying secret file", "path", path)
 			}
-			defer file.Close()
+			return nil
+		})
+	}
+	_ = workerPool.Wait()
+}
+
+func (v *verifier) verifyFile(ctx context.Context, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var secret DetectedSecret
+	if err := json.Unmarshal(data, &secret); err != nil {
+		return fmt.Errorf("unable to decode secret: %w", err)
+	}
+	if err := v.verify(ctx, &secret); err != nil {
+		return err
+	}
+
+	out, err := json.Marshal(&secret)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, append(out, '\n'))
+}
 
-			encoder := json.NewEncoder(file)
-			err = encoder.Encode(&secret)
+// verifyJSONL verifies every candidate of a JSONL input and replaces the input
+// with the results. Lines that can't be decoded, verified or encoded are
+// preserved as they are and counted as failed.
+func (v *verifier) verifyJSONL(ctx context.Context, path string) error {
+	var lines [][]byte
+	var err error
+	if path == stdioPath {
+		lines, err = readLines(os.Stdin)
+	} else {
+		var f *os.File
+		if f, err = os.Open(path); err != nil {
+			return err
+		}
+		lines, err = readLines(f)
+		_ = f.Close()
+	}
+	if err != nil {
+		return err
+	}
+
+	workerPool := new(errgroup.Group)
+	workerPool.SetLimit(v.concurrency)
+	for i, line := range lines {
+		if ctx.Err() != nil {
+			break
+		}
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		workerPool.Go(func() error {
+			var secret DetectedSecret
+			if err := json.Unmarshal(line, &secret); err != nil {
+				v.stats.failedLines.Add(1)
+				ctx.Logger().Error(err, "unable to decode secret", "path", path, "line", i+1)
+				return nil
+			}
+			if err := v.verify(ctx, &secret); err != nil {
+				v.stats.failedLines.Add(1)
+				ctx.Logger().Error(err, "error verifying secret", "path", path, "line", i+1)
+				return nil
+			}
+			out, err := json.Marshal(&secret)
 			if err != nil {
-				return sources.JobProgressRef{}, err
+				v.stats.failedLines.Add(1)
+				ctx.Logger().Error(err, "unable to encode secret", "path", path, "line", i+1)
+				return nil
 			}
+			// Each worker owns exactly one index, so no locking is required.
+			lines[i] = out
+			return nil
+		})
+	}
+	_ = workerPool.Wait()
+
+	var buf bytes.Buffer
+	for _, line := range lines {
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	if path == stdioPath {
+		_, err = os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	return writeFileAtomic(path, buf.Bytes())
+}
 
+// verify verifies secret with the detector it names. If it names a detector
+// type without a version, every version of that type verifies the secret.
+func (v *verifier) verify(ctx context.Context, secret *DetectedSecret) error {
+	v.stats.candidates.Add(1)
+	id, err := secret.detectorID()
+	if err != nil {
+		v.stats.noDetector.Add(1)
+		return fmt.Errorf("unable to resolve detector: %w", err)
+	}
+	dets, ok := v.detectorsByID[id]
+	if !ok {
+		v.stats.noDetector.Add(1)
+		return fmt.Errorf("no detector found for %s", id)
+	}
+
+	outcomes := make([]DetectorOutcome, 0, len(dets))
+	for _, d := range dets {
+		detectorID := config.GetDetectorID(d)
+		outcome := v.verifyWith(ctx, d, secret)
+		outcomes = append(outcomes, newDetectorOutcome(detectorID, outcome))
+		ctx.Logger().V(3).Info("verified secret",
+			"detector", detectorID.String(),
+			"status", outcome.Status,
+			"http_status", outcome.HTTPStatus,
+			"error", outcome.Error,
+		)
+	}
+	secret.setOutcomes(outcomes)
+
+	switch secret.Status {
+	case detectors.VerificationStatusVerified:
+		v.stats.verified.Add(1)
+	case detectors.VerificationStatusIndeterminate:
+		v.stats.indeterminate.Add(1)
+	case detectors.VerificationStatusUnsupported:
+		v.stats.unsupported.Add(1)
+	}
+	return nil
+}
+
+// verifyWith verifies secret with d. Verifications that were rate limited are
+// retried once the host accepts requests again, up to
+// maxVerificationRequeues times.
+func (v *verifier) verifyWith(ctx context.Context, d detectors.Detector, secret *DetectedSecret) detectors.VerificationOutcome {
+	if !v.policy.Allows(d) {
+		return detectors.VerificationOutcome{
+			Status: detectors.VerificationStatusUnsupported,
+			Error:  detectors.ErrVerificationNotAllowed,
 		}
 	}
-	return sources.JobProgressRef{}, nil
+
+	detectorID := config.GetDetectorID(d)
+	auditSecret := detectors.NewAuditSecret(secret.Secret)
+	if secret.Credential != nil {
+		auditSecret = detectors.NewAuditSecret(secret.Credential.String(), secret.Credential.Values()...)
+	}
+	for requeues := 0; ; requeues++ {
+		rateLimits := detectors.NewRateLimitScope(d.Type())
+		verifyCtx := detectors.WithRateLimitScope(ctx, rateLimits)
+		verifyCtx = detectors.WithAuditLog(verifyCtx, v.auditLog, detectorID.String(), auditSecret)
+
+		var outcome detectors.VerificationOutcome
+		if secret.Credential != nil {
+			outcome = v.cache.VerifyCredential(verifyCtx, d, *secret.Credential)
+		} else {
+			outcome = v.cache.VerifySecret(verifyCtx, d, secret.Secret)
+		}
+
+		retryAt, limited := rateLimits.RateLimited()
+		if !limited || outcome.Status != detectors.VerificationStatusIndeterminate || requeues >= maxVerificationRequeues {
+			return outcome
+		}
+		ctx.Logger().V(3).Info("verification rate limited, retrying",
+			"detector", detectorID.String(),
+			"retry_at", retryAt,
+		)
+		select {
+		case <-ctx.Done():
+			return outcome
+		case <-time.After(time.Until(retryAt)):
+		}
+	}
+}
+
+// readLines reads all newline separated lines of r. Lines may be arbitrarily
+// long.
+func readLines(r io.Reader) ([][]byte, error) {
+	var lines [][]byte
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			lines = append(lines, bytes.TrimRight(line, "\r\n"))
+		}
+		if errors.Is(err, io.EOF) {
+			return lines, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it
+// over path, so readers only ever observe the old or the new content.
+func writeFileAtomic(path string, data []byte) error {
+	dir, base := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+	tmp, err := os.CreateTemp(dir, "."+base+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	defer func() { _ = os.Remove(tmpName) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	// Preserve the original permissions instead of the restrictive default of
+	// os.CreateTemp.
+	if info, err := os.Stat(path); err == nil {
+		if err := os.Chmod(tmpName, info.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+	return os.Rename(tmpName, path)
 }
@@ -0,0 +1,274 @@
+package engine
+
+import (
+	aCtx "context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/config"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+type verifyTestDetector struct{}
+
+var _ detectors.Detector = (*verifyTestDetector)(nil)
+
+func (verifyTestDetector) FromData(aCtx.Context, bool, []byte) ([]detectors.Result, error) {
+	return nil, nil
+}
+func (verifyTestDetector) Keywords() []string             { return []string{"verifytest"} }
+func (verifyTestDetector) Type() detectorspb.DetectorType { return detectorspb.DetectorType_AWS }
+func (verifyTestDetector) Description() string            { return "" }
+func (verifyTestDetector) Verify(_ aCtx.Context, secret string) detectors.VerificationOutcome {
+	switch secret {
+	case "valid":
+		return detectors.NewVerificationOutcome(true, nil)
+	case "timeout":
+		return detectors.NewVerificationOutcome(false, errors.New("request for timeout timed out"), secret)
+	case "unsupported":
+		return detectors.VerificationNotSupportedOutcome()
+	default:
+		return detectors.NewVerificationOutcome(false, nil)
+	}
+}
+
+// versionedVerifyTestDetector only verifies secrets that carry its version.
+type versionedVerifyTestDetector struct {
+	version int
+}
+
+var _ detectors.Detector = (*versionedVerifyTestDetector)(nil)
+var _ detectors.Versioner = (*versionedVerifyTestDetector)(nil)
+
+func (versionedVerifyTestDetector) FromData(aCtx.Context, bool, []byte) ([]detectors.Result, error) {
+	return nil, nil
+}
+func (versionedVerifyTestDetector) Keywords() []string { return []string{"verifytest"} }
+func (versionedVerifyTestDetector) Type() detectorspb.DetectorType {
+	return detectorspb.DetectorType_Github
+}
+func (versionedVerifyTestDetector) Description() string { return "" }
+func (d versionedVerifyTestDetector) Version() int      { return d.version }
+func (d versionedVerifyTestDetector) Verify(_ aCtx.Context, secret string) detectors.VerificationOutcome {
+	return detectors.NewVerificationOutcome(secret == fmt.Sprintf("v%d", d.version), nil)
+}
+
+func newVerifyTestEngine() *Engine {
+	return &Engine{detectors: []detectors.Detector{verifyTestDetector{}}, concurrency: 4}
+}
+
+func readDetectedSecret(t *testing.T, path string) DetectedSecret {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var secret DetectedSecret
+	require.NoError(t, json.Unmarshal(data, &secret))
+	return secret
+}
+
+func writeDetectedSecret(t *testing.T, path string, secret DetectedSecret) {
+	t.Helper()
+	data, err := json.Marshal(secret)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0644))
+}
+
+func TestScanVerify_Directory(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	awsType := "2"
+
+	writeDetectedSecret(t, filepath.Join(dir, "a.txt"), DetectedSecret{Detector: awsType, Secret: "valid"})
+	writeDetectedSecret(t, filepath.Join(dir, "b.txt"), DetectedSecret{Detector: awsType, Secret: "invalid"})
+	writeDetectedSecret(t, filepath.Join(dir, "c.txt"), DetectedSecret{Detector: "999999", Secret: "valid"})
+	writeDetectedSecret(t, filepath.Join(dir, "d.txt"), DetectedSecret{Detector: awsType, Secret: "timeout"})
+	writeDetectedSecret(t, filepath.Join(dir, "e.txt"), DetectedSecret{Detector: awsType, Secret: "unsupported"})
+
+	// Candidates without a matching detector fail the run.
+	_, err := newVerifyTestEngine().ScanVerify(ctx, VerifyConfig{Directories: []string{dir}})
+	require.Error(t, err)
+
+	a := readDetectedSecret(t, filepath.Join(dir, "a.txt"))
+	assert.True(t, a.Verified)
+	assert.Equal(t, "trufflehog", a.Reason)
+	assert.Equal(t, detectors.VerificationStatusVerified, a.Status)
+	require.Len(t, a.Outcomes, 1)
+	assert.Equal(t, "AWS", a.Outcomes[0].Detector)
+
+	b := readDetectedSecret(t, filepath.Join(dir, "b.txt"))
+	assert.False(t, b.Verified)
+	assert.Equal(t, "trufflehog", b.Reason)
+	assert.Equal(t, detectors.VerificationStatusInvalid, b.Status)
+	assert.Empty(t, b.VerificationError)
+
+	d := readDetectedSecret(t, filepath.Join(dir, "d.txt"))
+	assert.False(t, d.Verified)
+	assert.Equal(t, detectors.VerificationStatusIndeterminate, d.Status)
+	assert.Equal(t, "request for [REDACTED] timed out", d.VerificationError)
+
+	e := readDetectedSecret(t, filepath.Join(dir, "e.txt"))
+	assert.False(t, e.Verified)
+	assert.Equal(t, detectors.VerificationStatusUnsupported, e.Status)
+	assert.Equal(t, detectors.ErrVerificationNotSupported.Error(), e.VerificationError)
+
+	// Candidates without a matching detector are left untouched.
+	c := readDetectedSecret(t, filepath.Join(dir, "c.txt"))
+	assert.Empty(t, c.Reason)
+
+	// No temporary files may be left behind.
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 5)
+}
+
+func TestScanVerify_JSONL(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "candidates.jsonl")
+	input := strings.Join([]string{
+		`{"detector":"2","secret":"valid"}`,
+		`not json`,
+		``,
+		`{"detector":"2","secret":"invalid"}`,
+	}, "\n")
+	require.NoError(t, os.WriteFile(path, []byte(input), 0644))
+
+	// Lines that can't be decoded fail the run but are kept in the output.
+	_, err := newVerifyTestEngine().ScanVerify(ctx, VerifyConfig{JSONL: []string{path}})
+	require.Error(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	require.Len(t, lines, 4)
+
+	var first, last DetectedSecret
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	require.NoError(t, json.Unmarshal([]byte(lines[3]), &last))
+	assert.True(t, first.Verified)
+	assert.False(t, last.Verified)
+	assert.Equal(t, "trufflehog", last.Reason)
+	assert.Equal(t, "not json", lines[1])
+	assert.Empty(t, lines[2])
+}
+
+func TestScanVerify_Credential(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "candidates.jsonl")
+	// The credential takes precedence over the secret. Detectors that don't
+	// verify typed credentials receive its legacy form.
+	input := `{"detector":"2","secret":"invalid","credential":{"token":"valid"}}`
+	require.NoError(t, os.WriteFile(path, []byte(input), 0644))
+
+	_, err := newVerifyTestEngine().ScanVerify(ctx, VerifyConfig{JSONL: []string{path}})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var secret DetectedSecret
+	require.NoError(t, json.Unmarshal(data, &secret))
+	assert.True(t, secret.Verified)
+	require.NotNil(t, secret.Credential)
+	assert.Equal(t, "valid", secret.Credential.Get(detectors.CredentialPartToken))
+}
+
+func TestScanVerify_Versioned(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	e := &Engine{
+		detectors: []detectors.Detector{
+			versionedVerifyTestDetector{version: 1},
+			versionedVerifyTestDetector{version: 2},
+		},
+		concurrency: 1,
+	}
+
+	writeDetectedSecret(t, filepath.Join(dir, "all.txt"), DetectedSecret{Detector: "8", Secret: "v1"})
+	writeDetectedSecret(t, filepath.Join(dir, "v2.txt"), DetectedSecret{DetectorName: "Github", DetectorVersion: 2, Secret: "v1"})
+	writeDetectedSecret(t, filepath.Join(dir, "dotted.txt"), DetectedSecret{Detector: "github.v1", Secret: "v1"})
+
+	_, err := e.ScanVerify(ctx, VerifyConfig{Directories: []string{dir}})
+	require.NoError(t, err)
+
+	// Without a version, every version verifies the secret and any verified
+	// outcome wins.
+	all := readDetectedSecret(t, filepath.Join(dir, "all.txt"))
+	assert.True(t, all.Verified)
+	require.Len(t, all.Outcomes, 2)
+	assert.Equal(t, DetectorOutcome{Detector: "Github.v1", DetectorVersion: 1, Status: detectors.VerificationStatusVerified}, all.Outcomes[0])
+	assert.Equal(t, DetectorOutcome{Detector: "Github.v2", DetectorVersion: 2, Status: detectors.VerificationStatusInvalid}, all.Outcomes[1])
+
+	v2 := readDetectedSecret(t, filepath.Join(dir, "v2.txt"))
+	assert.False(t, v2.Verified)
+	require.Len(t, v2.Outcomes, 1)
+	assert.Equal(t, 2, v2.Outcomes[0].DetectorVersion)
+
+	dotted := readDetectedSecret(t, filepath.Join(dir, "dotted.txt"))
+	assert.True(t, dotted.Verified)
+	require.Len(t, dotted.Outcomes, 1)
+	assert.Equal(t, 1, dotted.Outcomes[0].DetectorVersion)
+}
+
+func TestDetectedSecret_DetectorID(t *testing.T) {
+	tests := []struct {
+		name    string
+		secret  DetectedSecret
+		want    config.DetectorID
+		wantErr bool
+	}{
+		{name: "number", secret: DetectedSecret{Detector: "8"}, want: config.DetectorID{ID: detectorspb.DetectorType_Github}},
+		{name: "name", secret: DetectedSecret{DetectorName: "Github"}, want: config.DetectorID{ID: detectorspb.DetectorType_Github}},
+		{
+			name:   "number and version",
+			secret: DetectedSecret{Detector: "8", DetectorName: "Github", DetectorVersion: 2},
+			want:   config.DetectorID{ID: detectorspb.DetectorType_Github, Version: 2},
+		},
+		{name: "conflicting types", secret: DetectedSecret{Detector: "2", DetectorName: "Github"}, wantErr: true},
+		{name: "conflicting versions", secret: DetectedSecret{Detector: "github.v1", DetectorVersion: 2}, wantErr: true},
+		{name: "unknown", secret: DetectedSecret{Detector: "999999"}, wantErr: true},
+		{name: "missing", secret: DetectedSecret{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.secret.detectorID()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestScanVerify_NoInput(t *testing.T) {
+	_, err := newVerifyTestEngine().ScanVerify(context.Background(), VerifyConfig{})
+	assert.Error(t, err)
+}
+
+func TestScanVerify_SafeOnlyPolicy(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "candidates.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(`{"detector":"2","secret":"valid"}`), 0644))
+
+	// The test detector doesn't declare its verification metadata, so it
+	// isn't safe to verify.
+	e := newVerifyTestEngine()
+	e.verificationPolicy = detectors.VerificationPolicySafeOnly
+	_, err := e.ScanVerify(ctx, VerifyConfig{JSONL: []string{path}})
+	require.NoError(t, err)
+
+	secret := readDetectedSecret(t, path)
+	assert.False(t, secret.Verified)
+	assert.Equal(t, detectors.VerificationStatusUnsupported, secret.Status)
+	assert.Equal(t, detectors.ErrVerificationNotAllowed.Error(), secret.VerificationError)
+}
@@ -0,0 +1,102 @@
+package fileclass
+
+// classExtensions lists the file extensions of each class. An extension listed for several classes belongs to the
+// first of them.
+var classExtensions = []struct {
+	class      Class
+	extensions []string
+}{
+	{Web, []string{
+		".php5", ".xhtml", ".jsbundle", ".wasm", ".ts", ".css", ".jsx", ".js", ".vue", ".html", ".scss", ".php",
+		".less", ".htm", ".mustache", ".aspx", ".js.map", ".css.map",
+	}},
+	{System, []string{
+		".znb", ".rdl", ".pri", ".ipsw", ".upk", ".woff2", ".3", ".file", ".supported", ".pos", ".regtrans-ms",
+		".sthlp", ".csl", ".6", ".inf", ".vmt", ".desktop", ".itc", ".foliage", ".deu", ".localstorage-journal",
+		".cache", ".data", ".cur", ".eot", ".asd", ".mui", ".continuousdata", ".webhistory", ".appcache", ".sqm",
+		".woff", ".pfm", ".model", ".ttc", ".apmaster", ".xnb", ".simss", ".pssg", ".jrs", ".tm2", ".ko", ".1",
+		".dev", ".vcrd", ".10", ".idx", ".ovl", ".cgs", ".ipmeta", ".glk", ".man", ".0", ".lrprev", ".7", ".aux",
+		".ds_store", ".thewitchersave", ".sav", ".cat", ".install", ".dmp", ".pxe", ".part", ".shs", ".fnt", ".crash",
+		".ttf", ".drv", ".raw", ".settingcontent-ms", ".blockdata", ".sys", ".mcdb", ".localstorage", ".stringtable",
+		".tmp", ".download", ".8", ".lock", ".nif", ".stt", ".acsm", ".4", ".emf", ".civ4savedgame", ".otf", ".bif",
+		".idrc", ".primitives", ".map", ".blob", ".save", ".9", ".sns", ".apversion", ".adv", ".apdetected", ".atx",
+		".fon", ".5", ".gpd", ".mot", ".cpl", ".2", ".pac", ".opt", ".etl", ".gadget", ".temp", ".ifi", ".cgz",
+		".vtf", ".fragment", ".sbstore", ".nib", ".supf", ".supx", ".supp", ".mf", ".car", ".mom", ".modulemap",
+		".intentdefinition", ".sinf", ".pro", ".kotlin_module", ".kotlin_metadata", ".rsa",
+	}},
+	{Binary, []string{
+		".obb", ".aar", ".dex", ".epk", ".binary", ".dylib", ".crx", ".x86", ".csh", ".fish", ".ipa", ".lib",
+		".class", ".com", ".application", ".apk", ".o", ".a", ".msi", ".dll", ".bat", ".pak", ".so", ".bin", ".exe",
+		".command", ".pbd", ".dmg", ".app", ".jar", ".arm64", ".omo", ".luac", ".swiftdoc", ".net", ".armv7", ".ecm",
+		".kotlin_builtins", ".kf", ".objectcodec",
+	}},
+	{Code, []string{
+		".ms", ".lhs", ".blg", ".kt", ".sublime-snippet", ".pl", ".bas", ".d", ".ads", ".s", ".ftn", ".psd1",
+		".swift", ".dist", ".sol", ".dsw", ".cpp", ".pp", ".py", ".vmwarevm", ".h", ".cxx", ".ps1xml", ".make",
+		".2.ada", ".go", ".lst", ".cob", ".nas", ".m", ".rss", ".mm", ".zsh", ".lua", ".for", ".hh", ".phps", ".rs",
+		".java", ".bbl", ".f90", ".vbs", ".makefile", ".ada", ".xcodeproj", ".def", ".jav", ".pyc", ".po", ".swg",
+		".rq", ".vb", ".bash", ".props", ".vim", ".seto", ".cbl", ".e", ".jsonp", ".frm", ".f77", ".rdf", ".f",
+		".1.ada", ".dashtoc", ".cc", ".p", ".patch", ".vbox-prev", ".asm", ".ashx", ".psm", ".bsh", ".hs", ".c++",
+		".vs", ".ttl", ".3mf", ".rb", ".myi", ".mo", ".scala", ".adb", ".up_meta", ".psrc", ".diff", ".jsp", ".v",
+		".c#", ".cmake", ".ps1", ".pch", ".fth", ".obj", ".psm1", ".psd", ".c", ".cs", ".vbox", ".manifest", ".el",
+		".m4", ".cmd", ".vbscript", ".down_meta", ".vhd", ".lisp", ".nim", ".myd", ".sh", ".j", ".vcxproj", ".simt",
+		".hxx", ".options", ".pyo", ".clj", ".groovy", ".hpp", ".ksh", ".xsl", ".tpm",
+	}},
+	{Config, []string{
+		".assets", ".assetbundle", ".podspec", ".xcent", ".plist", ".toml", ".arsc", ".pb", ".config", ".cfg",
+		".conf", ".ini", ".properties", ".json", ".yaml", ".yml", ".xml", ".bundle", ".proto", ".axml", ".storekit",
+		".strings", ".stringsdict", ".dict", ".xctestplan", ".prop", ".geojson", ".appintentsmanifest", ".bincfg",
+		".xliff", ".prof", ".profm", ".xcconfig", ".entitlements", ".xsd", ".version",
+		// Configuration dotfiles, matched by their whole name.
+		".env", ".npmrc", ".yarnrc", ".pypirc", ".netrc", ".gitconfig", ".dockercfg", ".htpasswd", ".pgpass",
+	}},
+	{Media, []string{
+		".avc", ".scc", ".tp", ".avchd", ".swf", ".ogg", ".3gp", ".aaf", ".m4p", ".mpeg", ".ogv", ".mp2", ".ogm",
+		".srt", ".mp4", ".mpv", ".mng", ".mpe", ".mov", ".webm", ".wmv", ".mxf", ".drc", ".rm", ".roq", ".svi", ".qt",
+		".nsv", ".mk4", ".avi", ".mpg", ".h264", ".trec", ".asf", ".mkv", ".3g2", ".m2v", ".flv", ".m4v", ".yuv",
+		".vob", ".rmvb", ".emp", ".pls", ".flac", ".l6t", ".aac", ".dmpatch", ".aif", ".xm", ".midi", ".adx", ".au",
+		".mp3", ".ape", ".oct", ".ens", ".s3m", ".mpa", ".it", ".pcm", ".m3u", ".cda", ".seq", ".lng", ".mtp",
+		".link", ".wma", ".m4a", ".gsm", ".wem", ".aiff", ".mod", ".adg", ".sid", ".wav", ".sngw", ".mid", ".ra",
+		".m4r", ".caf", ".flr", ".sf", ".bnk", ".bks", ".pngx", ".3ds", ".icon", ".kmz", ".djvu", ".max", ".rds",
+		".xcf", ".webp", ".thm", ".tif", ".pic", ".gpx", ".img", ".cr2", ".3dm", ".jfif", ".icns", ".png0", ".eps",
+		".ai", ".svg", ".visual", ".dwg", ".png", ".dng", ".psb", ".shape", ".hdr", ".aae", ".catalog", ".px", ".fla",
+		".dxf", ".ps", ".heic", ".photoscachefile", ".apalbum", ".jpeg", ".apfolder", ".ithmb", ".gif", ".odg",
+		".dds", ".bmp", ".ico", ".kml", ".xmp", ".appicon", ".jpg", ".ita", ".tiff", ".tga", ".metallib",
+	}},
+	{Game, []string{
+		".level", ".unity3d", ".p2d", ".vert", ".csb", ".mesh", ".ccz", ".vsh", ".fsh", ".sks", ".resource", ".frag",
+		".cikernel", ".skel", ".u", ".bcmap", ".tmx", ".glsl", ".ress", ".atlas",
+	}},
+	{Cryptography, []string{
+		".pk12", ".pem", ".crt", ".cer", ".key", ".p12", ".pfx", ".pub", ".asc", ".gpg", ".pgp", ".der", ".jks",
+		".md5",
+	}},
+	{Archive, []string{
+		".zzip", ".lha", ".egg", ".tbz2", ".whl", ".009", ".package", ".xpi", ".arj", ".ova", ".004", ".zip", ".vdi",
+		".xz", ".pea", ".tgz", ".pkg", ".vmdk", ".z", ".tlz", ".ims", ".008", ".bz2", ".deb", ".mar", ".tar", ".003",
+		".war", ".rar", ".gz", ".cpio", ".rpm", ".001", ".006", ".vcd", ".005", ".002", ".cab", ".lzma", ".ar", ".7z",
+		".zipx", ".shar", ".ost", ".iso", ".007", ".s7z", ".glz", ".czl",
+	}},
+	{Document, []string{
+		".markdown", ".opf", ".wps", ".fra", ".utf8", ".txt", ".ebook", ".chm", ".docx", ".docm", ".pdf", ".azw6",
+		".text", ".cbz", ".wks", ".epub", ".md", ".tex", ".mobi", ".nfo", ".pages", ".rtf", ".azw3", ".msg", ".dvi",
+		".pfb", ".doc", ".log2", ".cbr", ".azw1", ".azw", ".abw", ".org", ".azw4", ".log", ".rst", ".odt", ".bib",
+		".log1", ".ott", ".ichat", ".wpd", ".emlx", ".lic", ".rsp", ".list", ".mdown", ".xlr", ".ics", ".odf", ".xlk",
+		".vcf", ".ods", ".xlsx", ".csv", ".numbers", ".xls", ".ppt", ".pptx", ".pps", ".ppsx", ".odp",
+	}},
+	{Database, []string{
+		".graphql", ".database", ".sqlite", ".sdf", ".sqlite-wal", ".appinfo", ".enz", ".accdb", ".gdbtable", ".odb",
+		".xg0", ".mdb", ".yg0", ".asy", ".r", ".db", ".hdb", ".meta", ".gdbtablx", ".xyz", ".adf", ".gdbindexes",
+		".mat", ".sql", ".accde", ".cif", ".bgl", ".info", ".mde", ".cdb", ".enl", ".exp", ".gdb", ".dat", ".realm",
+		".pdb", ".cdm",
+	}},
+	{Model, []string{
+		".prototxt", ".pt2", ".prompt", ".llamafile", ".ckpt", ".pth", ".ggjt", ".pte", ".mleap", ".gguf",
+		".caffemodel", ".npy", ".surml", ".tfrecords", ".pkl", ".h5", ".ptl", ".npz", ".keras", ".onnx", ".nc",
+		".ggmf", ".tflite", ".dlc", ".safetensors", ".coreml", ".pt", ".ggml", ".mlmodel", ".mlmodelc", ".hdf5",
+		".conv_model", ".lstm_model", ".weights", ".traineddata", ".emd",
+	}},
+	{Backup, []string{
+		".bak", ".backup", ".back", ".pbf", ".stg",
+	}},
+}
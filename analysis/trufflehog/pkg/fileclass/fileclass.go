@@ -0,0 +1,264 @@
+// Package fileclass classifies files into broad classes, such as config, code or binary, by their extension and,
+// for files whose extension doesn't tell, their MIME type. Sources and file handlers record the class of every
+// chunk, so results can be broken down by class, and filter the files they scan by class.
+package fileclass
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Class is the class of a file.
+type Class string
+
+const (
+	Web          Class = "web"
+	System       Class = "system"
+	Binary       Class = "binary"
+	Code         Class = "code"
+	Config       Class = "config"
+	Media        Class = "media"
+	Game         Class = "game"
+	Cryptography Class = "cryptography"
+	Archive      Class = "archive"
+	Document     Class = "document"
+	Database     Class = "database"
+	Model        Class = "model"
+	Backup       Class = "backup"
+	// Unknown is the class of files that neither their extension nor their MIME type classify.
+	Unknown Class = "unknown"
+)
+
+// extensionClasses maps lowercase file extensions, including compound ones such as ".js.map", to their class.
+var extensionClasses = make(map[string]Class)
+
+func init() {
+	for _, c := range classExtensions {
+		for _, ext := range c.extensions {
+			if _, ok := extensionClasses[ext]; !ok {
+				extensionClasses[ext] = c.class
+			}
+		}
+	}
+}
+
+// Classes returns every class, including Unknown, sorted by name.
+func Classes() []Class {
+	seen := map[Class]struct{}{Unknown: {}}
+	classes := []Class{Unknown}
+	for _, c := range classExtensions {
+		if _, ok := seen[c.class]; !ok {
+			seen[c.class] = struct{}{}
+			classes = append(classes, c.class)
+		}
+	}
+	sort.Slice(classes, func(i, j int) bool { return classes[i] < classes[j] })
+	return classes
+}
+
+// Classify returns the class of the file at path by its extension or, if the extension isn't known, by its MIME
+// type, which may be empty.
+func Classify(filePath, mimeType string) Class {
+	if class, ok := ByExtension(filePath); ok {
+		return class
+	}
+	return ByMIME(mimeType)
+}
+
+// ByExtension returns the class of the file at path by its extension, matching compound extensions such as
+// ".js.map" before the last one, and dotfiles such as ".env" by their name. It returns false if the extension isn't
+// known, or the file has none.
+func ByExtension(filePath string) (Class, bool) {
+	name := strings.ToLower(path.Base(strings.ReplaceAll(filePath, "\\", "/")))
+	ext := path.Ext(name)
+	if ext == "" {
+		return "", false
+	}
+	if ext == name {
+		class, ok := extensionClasses[name]
+		return class, ok
+	}
+	if compound := path.Ext(strings.TrimSuffix(name, ext)); compound != "" {
+		if class, ok := extensionClasses[compound+ext]; ok {
+			return class, true
+		}
+	}
+	class, ok := extensionClasses[ext]
+	return class, ok
+}
+
+// mimeClasses maps MIME types to their class, for files whose extension isn't known.
+var mimeClasses = map[string]Class{
+	"application/octet-stream":                      Binary,
+	"application/x-elf":                             Binary,
+	"application/x-executable":                      Binary,
+	"application/x-sharedlib":                       Binary,
+	"application/x-object":                          Binary,
+	"application/x-coredump":                        Binary,
+	"application/x-mach-binary":                     Binary,
+	"application/vnd.microsoft.portable-executable": Binary,
+	"application/x-java-applet":                     Binary,
+	"application/x-hermes-bytecode":                 Binary,
+	"application/x-xamarin-compressed-assembly":     Binary,
+	"application/x-xamarin-assembly-store":          Binary,
+	"application/x-il2cpp-metadata":                 Game,
+	"application/wasm":                              Web,
+	"text/html":                                     Web,
+	"text/css":                                      Web,
+	"text/javascript":                               Web,
+	"application/javascript":                        Web,
+	"application/x-javascript":                      Web,
+	"application/x-sourcemap":                       Web,
+	"text/x-php":                                    Web,
+	"text/x-python":                                 Code,
+	"application/x-python":                          Code,
+	"application/x-script.python":                   Code,
+	"text/x-perl":                                   Code,
+	"text/x-lua":                                    Code,
+	"text/x-tcl":                                    Code,
+	"application/x-tcl":                             Code,
+	"text/x-shellscript":                            Code,
+	"application/x-shellscript":                     Code,
+	"application/json":                              Config,
+	"application/x-ndjson":                          Config,
+	"text/xml":                                      Config,
+	"application/xml":                               Config,
+	"application/x-plist":                           Config,
+	"application/x-bplist":                          Config,
+	"application/toml":                              Config,
+	"application/yaml":                              Config,
+	"text/plain":                                    Document,
+	"text/rtf":                                      Document,
+	"text/csv":                                      Document,
+	"text/tab-separated-values":                     Document,
+	"application/pdf":                               Document,
+	"application/msword":                            Document,
+	"application/vnd.ms-excel":                      Document,
+	"application/vnd.ms-powerpoint":                 Document,
+	"application/epub+zip":                          Document,
+	"application/x-sqlite3":                         Database,
+	"application/vnd.sqlite3":                       Database,
+	"application/x-pem-file":                        Cryptography,
+	"application/pkcs7-signature":                   Cryptography,
+	"application/x-x509-ca-cert":                    Cryptography,
+	"application/zip":                               Archive,
+	"application/gzip":                              Archive,
+	"application/x-tar":                             Archive,
+	"application/x-7z-compressed":                   Archive,
+	"application/x-rar-compressed":                  Archive,
+	"application/vnd.rar":                           Archive,
+	"application/x-xz":                              Archive,
+	"application/x-bzip2":                           Archive,
+	"application/zstd":                              Archive,
+	"application/x-archive":                         Archive,
+	"application/x-unix-archive":                    Archive,
+	"application/x-rpm":                             Archive,
+	"application/cpio":                              Archive,
+	"application/vnd.debian.binary-package":         Archive,
+	"application/java-archive":                      Archive,
+	"application/vnd.android.package-archive":       Archive,
+	"application/x-android-app-bundle":              Archive,
+	"application/x-ios-app":                         Archive,
+	"application/x-apple-diskimage":                 Archive,
+}
+
+// ByMIME returns the class of a file by its MIME type, ignoring its parameters.
+func ByMIME(mimeType string) Class {
+	mimeType, _, _ = strings.Cut(mimeType, ";")
+	mimeType = strings.ToLower(strings.TrimSpace(mimeType))
+	if class, ok := mimeClasses[mimeType]; ok {
+		return class
+	}
+
+	switch media, _, _ := strings.Cut(mimeType, "/"); media {
+	case "image", "audio", "video":
+		return Media
+	case "font":
+		return System
+	}
+	switch {
+	case strings.HasPrefix(mimeType, "application/vnd.openxmlformats-officedocument."),
+		strings.HasPrefix(mimeType, "application/vnd.oasis.opendocument."):
+		return Document
+	case strings.HasSuffix(mimeType, "+json"), strings.HasSuffix(mimeType, "+xml"):
+		return Config
+	case strings.HasPrefix(mimeType, "text/"):
+		return Document
+	}
+	return Unknown
+}
+
+// Parse returns the class with the given name.
+func Parse(name string) (Class, error) {
+	class := Class(strings.ToLower(strings.TrimSpace(name)))
+	for _, c := range Classes() {
+		if c == class {
+			return class, nil
+		}
+	}
+	return "", fmt.Errorf("unknown file class %q, expected one of %v", name, Classes())
+}
+
+// Filter selects files by class. A nil Filter passes every class.
+type Filter struct {
+	include map[Class]struct{}
+	exclude map[Class]struct{}
+}
+
+// NewFilter returns a filter that passes the included classes, or every class if none is, except the excluded ones.
+// Blank class names are ignored. It returns nil if no class is included or excluded.
+func NewFilter(include, exclude []string) (*Filter, error) {
+	toSet := func(names []string) (map[Class]struct{}, error) {
+		set := make(map[Class]struct{}, len(names))
+		for _, name := range names {
+			if strings.TrimSpace(name) == "" {
+				continue
+			}
+			class, err := Parse(name)
+			if err != nil {
+				return nil, err
+			}
+			set[class] = struct{}{}
+		}
+		return set, nil
+	}
+	includeSet, err := toSet(include)
+	if err != nil {
+		return nil, err
+	}
+	excludeSet, err := toSet(exclude)
+	if err != nil {
+		return nil, err
+	}
+	if len(includeSet) == 0 && len(excludeSet) == 0 {
+		return nil, nil
+	}
+	return &Filter{include: includeSet, exclude: excludeSet}, nil
+}
+
+// Excludes returns true if files of the class are excluded. Unlike files that aren't included, the entries of
+// excluded archives aren't scanned either.
+func (f *Filter) Excludes(class Class) bool {
+	if f == nil {
+		return false
+	}
+	_, ok := f.exclude[class]
+	return ok
+}
+
+// Pass returns true if files of the class should be scanned.
+func (f *Filter) Pass(class Class) bool {
+	if f == nil {
+		return true
+	}
+	if f.Excludes(class) {
+		return false
+	}
+	if len(f.include) == 0 {
+		return true
+	}
+	_, ok := f.include[class]
+	return ok
+}
@@ -0,0 +1,80 @@
+package fileclass
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		path     string
+		mimeType string
+		want     Class
+	}{
+		{path: "config/app.json", want: Config},
+		{path: "res/values/strings.XML", want: Config},
+		{path: "src/main.py", want: Code},
+		{path: "static/main.js.map", want: Web},
+		{path: "static/main.js", want: Web},
+		{path: `C:\Program Files\app\app.exe`, want: Binary},
+		{path: "keys/server.pem", want: Cryptography},
+		{path: "backup/db.sqlite", want: Database},
+		{path: "notes.txt", want: Document},
+		{path: "dist/app.tar.gz", want: Archive},
+		{path: "bin/tool", mimeType: "application/x-elf", want: Binary},
+		{path: "settings", mimeType: "application/json; charset=utf-8", want: Config},
+		{path: "README", mimeType: "text/plain; charset=utf-8", want: Document},
+		{path: "app/.env", mimeType: "text/plain; charset=utf-8", want: Config},
+		{path: ".bash_history", mimeType: "text/plain; charset=utf-8", want: Document},
+		{path: "photo", mimeType: "image/heic", want: Media},
+		{path: "blob.unknownext", mimeType: "application/x-made-up", want: Unknown},
+		{path: "", want: Unknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, Classify(tt.path, tt.mimeType))
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	class, err := Parse(" Config ")
+	require.NoError(t, err)
+	assert.Equal(t, Config, class)
+
+	for _, c := range Classes() {
+		parsed, err := Parse(string(c))
+		require.NoError(t, err)
+		assert.Equal(t, c, parsed)
+	}
+
+	_, err = Parse("configs")
+	assert.Error(t, err)
+}
+
+func TestFilter(t *testing.T) {
+	filter, err := NewFilter([]string{""}, []string{""})
+	require.NoError(t, err)
+	assert.Nil(t, filter)
+	assert.True(t, filter.Pass(Binary))
+	assert.False(t, filter.Excludes(Binary))
+
+	filter, err = NewFilter([]string{"config", "code"}, []string{"code"})
+	require.NoError(t, err)
+	assert.True(t, filter.Pass(Config))
+	assert.False(t, filter.Pass(Code))
+	assert.False(t, filter.Pass(Binary))
+	assert.True(t, filter.Excludes(Code))
+	assert.False(t, filter.Excludes(Binary))
+
+	filter, err = NewFilter(nil, []string{"media", "archive"})
+	require.NoError(t, err)
+	assert.True(t, filter.Pass(Config))
+	assert.True(t, filter.Pass(Unknown))
+	assert.False(t, filter.Pass(Media))
+
+	_, err = NewFilter([]string{"configs"}, nil)
+	assert.Error(t, err)
+}
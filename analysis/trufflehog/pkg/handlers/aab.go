@@ -0,0 +1,661 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/avast/apkparser"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/iobuf"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+)
+
+// Android App Bundles are the format apps are published to Google Play in. An AAB is a zip archive with a directory
+// for each module of the app: base/, and one for each dynamic feature and asset pack, which Play turns into split
+// APKs. A module holds the same files as an APK, except that its manifest/AndroidManifest.xml, its compiled XML
+// resources under res/ and its resource table, resources.pb, are stored in the protocol buffer format of aapt2
+// rather than as binary XML and resources.arsc. These are decoded to the same text the apk handler produces for
+// their APK counterparts, and the chunks of every module record its package name, version and module name.
+
+const (
+	// aabManifestPath and aabResTablePath are the paths of the manifest and the resource table within a module.
+	aabManifestPath = "manifest/AndroidManifest.xml"
+	aabResTablePath = "resources.pb"
+	// aabMaxNodeDepth is the deepest nesting of XML elements that is decoded.
+	aabMaxNodeDepth = 256
+)
+
+// Field numbers of the messages of aapt2's Resources.proto that are decoded.
+const (
+	// ResourceTable
+	aabTablePackage protowire.Number = 2
+	// Package
+	aabPackageID   protowire.Number = 1
+	aabPackageType protowire.Number = 3
+	// Type
+	aabTypeID    protowire.Number = 1
+	aabTypeName  protowire.Number = 2
+	aabTypeEntry protowire.Number = 3
+	// Entry
+	aabEntryID          protowire.Number = 1
+	aabEntryName        protowire.Number = 2
+	aabEntryConfigValue protowire.Number = 6
+	// ConfigValue
+	aabConfigValueConfig protowire.Number = 1
+	aabConfigValueValue  protowire.Number = 2
+	// Value
+	aabValueItem protowire.Number = 4
+	// Item
+	aabItemRef       protowire.Number = 1
+	aabItemStr       protowire.Number = 2
+	aabItemRawStr    protowire.Number = 3
+	aabItemStyledStr protowire.Number = 4
+	aabItemFile      protowire.Number = 5
+	aabItemPrim      protowire.Number = 7
+	// Reference
+	aabReferenceID   protowire.Number = 2
+	aabReferenceName protowire.Number = 3
+	// PackageId, TypeId, EntryId, String, RawString, StyledString and FileReference
+	aabIDValue     protowire.Number = 1
+	aabStringValue protowire.Number = 1
+	// Primitive
+	aabPrimFloat      protowire.Number = 3
+	aabPrimIntDecimal protowire.Number = 6
+	aabPrimIntHex     protowire.Number = 7
+	aabPrimBoolean    protowire.Number = 8
+	aabPrimColorARGB8 protowire.Number = 9
+	aabPrimColorRGB4  protowire.Number = 12
+	aabPrimDimension  protowire.Number = 13
+	aabPrimFraction   protowire.Number = 14
+	// XmlNode
+	aabNodeElement protowire.Number = 1
+	aabNodeText    protowire.Number = 2
+	// XmlElement
+	aabElementNamespaceURI protowire.Number = 2
+	aabElementName         protowire.Number = 3
+	aabElementAttribute    protowire.Number = 4
+	aabElementChild        protowire.Number = 5
+	// XmlAttribute
+	aabAttrNamespaceURI protowire.Number = 1
+	aabAttrName         protowire.Number = 2
+	aabAttrValue        protowire.Number = 3
+	aabAttrCompiledItem protowire.Number = 6
+)
+
+// aabHandler handles Android App Bundles. It reuses the dex processing of the apk handler.
+type aabHandler struct{ *apkHandler }
+
+// newAABHandler creates an aabHandler.
+func newAABHandler() *aabHandler {
+	return &aabHandler{apkHandler: &apkHandler{
+		defaultHandler: newDefaultHandler(aabHandlerType),
+		keywordMatcher: getDefaultDetectorKeywordMatcher(),
+	}}
+}
+
+// HandleFile processes aab formatted files.
+// Fatal errors that will stop processing:
+// - Unable to create ZIP reader from input
+// - No base module found in the archive
+// - Panics during processing (recovered but returned as errors)
+//
+// Non-fatal errors that will be logged and continue processing:
+// - Failed to decode the manifest or the resource table of a module
+// - Failed to process individual files within the AAB
+// - Failed to process individual dex classes
+func (h *aabHandler) HandleFile(ctx logContext.Context, input fileReader) chan DataOrErr {
+	aabChan := make(chan DataOrErr, defaultBufferSize)
+
+	go func() {
+		defer close(aabChan)
+
+		// Defer a panic recovery to handle any panics that occur during the AAB processing.
+		defer func() {
+			if r := recover(); r != nil {
+				// Return the panic as an error.
+				var panicErr error
+				if e, ok := r.(error); ok {
+					panicErr = e
+				} else {
+					panicErr = fmt.Errorf("panic occurred: %v", r)
+				}
+				ctx.Logger().Error(panicErr, "Panic occurred when reading aab archive")
+			}
+		}()
+
+		start := time.Now()
+		err := h.processAAB(ctx, input, aabChan)
+		if err == nil {
+			h.metrics.incFilesProcessed()
+		}
+
+		h.measureLatencyAndHandleErrors(ctx, start, err, aabChan)
+	}()
+
+	return aabChan
+}
+
+// aabModule is a module of an app bundle, with its decoded manifest and resource table.
+type aabModule struct {
+	manifest apkManifest
+	resTable *aabResourceTable
+}
+
+// processAAB processes the aab file and sends the extracted data to the provided channel.
+func (h *aabHandler) processAAB(ctx logContext.Context, input fileReader, aabChan chan DataOrErr) error {
+	zipReader, err := createZipReader(input)
+	if err != nil {
+		return err
+	}
+
+	modules := h.parseModules(ctx, zipReader)
+	base, ok := modules[baseSplitName]
+	if !ok {
+		return errors.New("base module not found in the AAB archive")
+	}
+	ctx = logContext.WithValues(ctx, "package", base.manifest.pkg, "version", base.manifest.versionName)
+
+	for name, module := range modules {
+		if module.resTable == nil {
+			continue
+		}
+		fileName := name + "/" + aabResTablePath
+		metadata := withExtractionStage(module.manifest.metadata(aabResTablePath), apkResourcesStage)
+		if err := h.handleAPKFileContent(ctx, module.resTable.stringsText(), fileName, metadata, aabChan); err != nil {
+			ctx.Logger().Error(err, "failed to process resources.pb", "module", name)
+		}
+	}
+
+	// Native libraries are built once for each ABI, and their copies hold the same strings, so only one is scanned.
+	libs := groupNativeLibs([]apkEntries{{names: zipEntryNames(zipReader.File)}})
+
+	for _, file := range zipReader.File {
+		if _, ok := libs.skipped[file.Name]; ok || file.FileInfo().IsDir() || file.UncompressedSize64 == 0 {
+			continue
+		}
+
+		// Files outside the modules, such as BundleConfig.pb and BUNDLE-METADATA/, keep their archive path.
+		moduleName, modulePath, _ := strings.Cut(file.Name, "/")
+		module, ok := modules[moduleName]
+		if !ok {
+			module = aabModule{manifest: base.manifest}
+			module.manifest.split = ""
+			module.manifest.entryRoot = ""
+			modulePath = file.Name
+		}
+		// Resource tables are scanned as decoded above, unless they failed to decode.
+		if ok && modulePath == aabResTablePath && module.resTable != nil {
+			continue
+		}
+
+		metadata := module.manifest.metadata(modulePath)
+		if variants, ok := libs.variants[file.Name]; ok {
+			metadata.Extraction.VariantPaths = variants
+		}
+		if err := h.processAABFile(ctx, file, modulePath, module.resTable, metadata, aabChan); err != nil {
+			ctx.Logger().V(2).Info(fmt.Sprintf("failed to process file: %s", file.Name), "error", err)
+		}
+	}
+	return nil
+}
+
+// parseModules decodes the manifest and the resource table of each module of the bundle, keyed by module name.
+// Modules whose manifest doesn't declare the package name and version inherit the ones of the base module.
+func (h *aabHandler) parseModules(ctx logContext.Context, zipReader *zip.Reader) map[string]aabModule {
+	modules := make(map[string]aabModule)
+	for _, file := range zipReader.File {
+		name, ok := strings.CutSuffix(file.Name, "/"+aabManifestPath)
+		if !ok || strings.Contains(name, "/") {
+			continue
+		}
+
+		var module aabModule
+		node, err := readAABProto(file)
+		if err == nil {
+			module.manifest, err = parseAABManifest(node)
+		}
+		if err != nil {
+			ctx.Logger().V(2).Info("failed to decode AndroidManifest.xml", "module", name, "error", err)
+		}
+		// The module name is the name of the split APKs Play generates from it.
+		module.manifest.split = name
+		module.manifest.entryRoot = name
+		modules[name] = module
+	}
+
+	for _, file := range zipReader.File {
+		name, ok := strings.CutSuffix(file.Name, "/"+aabResTablePath)
+		module, isModule := modules[name]
+		if !ok || !isModule {
+			continue
+		}
+		table, err := readAABProto(file)
+		if err == nil {
+			module.resTable, err = parseAABResourceTable(table)
+		}
+		if err != nil {
+			ctx.Logger().V(2).Info("failed to decode resources.pb", "module", name, "error", err)
+			continue
+		}
+		modules[name] = module
+	}
+
+	if base, ok := modules[baseSplitName]; ok {
+		for name, module := range modules {
+			if module.manifest.pkg == "" {
+				module.manifest.pkg = base.manifest.pkg
+			}
+			if module.manifest.versionName == "" {
+				module.manifest.versionName = base.manifest.versionName
+			}
+			modules[name] = module
+		}
+	}
+	return modules
+}
+
+// processAABFile decodes a file of a module based on its path within the module, and sends the extracted data to
+// the provided channel.
+func (h *aabHandler) processAABFile(
+	ctx logContext.Context,
+	file *zip.File,
+	modulePath string,
+	resTable *aabResourceTable,
+	metadata *source_metadatapb.MetaData,
+	aabChan chan DataOrErr,
+) error {
+	f, err := openFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", file.Name, err)
+	}
+	defer f.Close()
+
+	rdr := iobuf.NewBufferedReaderSeeker(f)
+	defer rdr.Close()
+
+	var contentReader io.Reader
+	switch {
+	case modulePath == aabManifestPath || (strings.HasPrefix(modulePath, "res/") && path.Ext(modulePath) == ".xml"):
+		contentReader, err = decodeAABXML(rdr, resTable)
+		if err != nil {
+			return fmt.Errorf("failed to decode xml file %s: %w", file.Name, err)
+		}
+		metadata = withExtractionStage(metadata, apkXMLStage)
+	case path.Ext(modulePath) == ".dex":
+		contentReader, metadata, err = h.decodeDexFile(ctx, rdr, metadata)
+		if err != nil {
+			return fmt.Errorf("failed to decode dex file %s: %w", file.Name, err)
+		}
+	default:
+		contentReader = rdr
+	}
+	return h.handleAPKFileContent(ctx, contentReader, file.Name, metadata, aabChan)
+}
+
+// isAABLayout checks whether a zip file with an .aab extension is an app bundle by the manifest of its base module.
+func isAABLayout(zipReader *zip.Reader) bool {
+	for _, file := range zipReader.File {
+		if file.Name == baseSplitName+"/"+aabManifestPath {
+			return true
+		}
+	}
+	return false
+}
+
+// aabProto is a protocol buffer message decoded without its schema, as the values of each of its fields.
+// Length delimited values are kept as bytes, and numeric values are widened to 64 bits.
+type aabProto map[protowire.Number][]aabProtoValue
+
+type aabProtoValue struct {
+	bytes []byte
+	num   uint64
+}
+
+// readAABProto reads and decodes a protocol buffer file of the bundle.
+func readAABProto(file *zip.File) (aabProto, error) {
+	f, err := openFile(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+	return parseAABProto(data)
+}
+
+// parseAABProto decodes the fields of a protocol buffer message.
+func parseAABProto(b []byte) (aabProto, error) {
+	msg := make(aabProto)
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		var v aabProtoValue
+		switch typ {
+		case protowire.VarintType:
+			v.num, n = protowire.ConsumeVarint(b)
+		case protowire.Fixed32Type:
+			var x uint32
+			x, n = protowire.ConsumeFixed32(b)
+			v.num = uint64(x)
+		case protowire.Fixed64Type:
+			v.num, n = protowire.ConsumeFixed64(b)
+		case protowire.BytesType:
+			v.bytes, n = protowire.ConsumeBytes(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+		msg[num] = append(msg[num], v)
+	}
+	return msg, nil
+}
+
+// has returns true if the field is set.
+func (m aabProto) has(num protowire.Number) bool { return len(m[num]) > 0 }
+
+// str returns the value of a string field, which is its last occurrence.
+func (m aabProto) str(num protowire.Number) string {
+	values := m[num]
+	if len(values) == 0 {
+		return ""
+	}
+	return string(values[len(values)-1].bytes)
+}
+
+// uint returns the value of a numeric field.
+func (m aabProto) uint(num protowire.Number) uint64 {
+	values := m[num]
+	if len(values) == 0 {
+		return 0
+	}
+	return values[len(values)-1].num
+}
+
+// msg returns the value of a message field. A field that fails to decode is returned empty.
+func (m aabProto) msg(num protowire.Number) aabProto {
+	values := m[num]
+	if len(values) == 0 {
+		return aabProto{}
+	}
+	msg, err := parseAABProto(values[len(values)-1].bytes)
+	if err != nil {
+		return aabProto{}
+	}
+	return msg
+}
+
+// msgs returns the values of a repeated message field.
+func (m aabProto) msgs(num protowire.Number) ([]aabProto, error) {
+	msgs := make([]aabProto, 0, len(m[num]))
+	for _, v := range m[num] {
+		msg, err := parseAABProto(v.bytes)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+// aabResourceString is a string resource of a module's resource table.
+type aabResourceString struct {
+	name  string
+	value string
+}
+
+// aabResourceTable holds the string resources of a module, which the references of its XML files are resolved to.
+type aabResourceTable struct {
+	strings []aabResourceString
+	byName  map[string]string
+	byID    map[uint32]string
+}
+
+// parseAABResourceTable decodes the string resources of a ResourceTable message.
+// A string resource defined for several configurations takes the value of its default configuration, as in
+// resources.arsc, or the first one if it has no default.
+func parseAABResourceTable(table aabProto) (*aabResourceTable, error) {
+	resTable := &aabResourceTable{byName: make(map[string]string), byID: make(map[uint32]string)}
+	packages, err := table.msgs(aabTablePackage)
+	if err != nil {
+		return nil, err
+	}
+	for _, pkg := range packages {
+		types, err := pkg.msgs(aabPackageType)
+		if err != nil {
+			return nil, err
+		}
+		for _, typ := range types {
+			if typ.str(aabTypeName) != "string" {
+				continue
+			}
+			entries, err := typ.msgs(aabTypeEntry)
+			if err != nil {
+				return nil, err
+			}
+			for _, entry := range entries {
+				value, ok := aabDefaultStringValue(entry)
+				if !ok {
+					continue
+				}
+				name := entry.str(aabEntryName)
+				id := uint32(pkg.msg(aabPackageID).uint(aabIDValue))<<24 |
+					uint32(typ.msg(aabTypeID).uint(aabIDValue))<<16 |
+					uint32(entry.msg(aabEntryID).uint(aabIDValue))
+				resTable.strings = append(resTable.strings, aabResourceString{name: name, value: value})
+				resTable.byName["string/"+name] = value
+				resTable.byID[id] = value
+			}
+		}
+	}
+	return resTable, nil
+}
+
+// aabDefaultStringValue returns the string value of an entry for its default configuration.
+func aabDefaultStringValue(entry aabProto) (string, bool) {
+	configValues, err := entry.msgs(aabEntryConfigValue)
+	if err != nil || len(configValues) == 0 {
+		return "", false
+	}
+	chosen := configValues[0]
+	for _, cv := range configValues {
+		if len(cv.msg(aabConfigValueConfig)) == 0 {
+			chosen = cv
+			break
+		}
+	}
+
+	return aabItemString(chosen.msg(aabConfigValueValue).msg(aabValueItem))
+}
+
+// aabItemString returns the value of an Item message holding a string.
+func aabItemString(item aabProto) (string, bool) {
+	for _, num := range []protowire.Number{aabItemStr, aabItemRawStr, aabItemStyledStr} {
+		if item.has(num) {
+			return item.msg(num).str(aabStringValue), true
+		}
+	}
+	return "", false
+}
+
+// stringsText returns the string resources as "name = value" lines, the format the apk handler uses for the
+// strings of resources.arsc.
+func (t *aabResourceTable) stringsText() io.Reader {
+	var buf bytes.Buffer
+	for _, s := range t.strings {
+		buf.WriteString(s.name)
+		buf.WriteString(" = ")
+		buf.WriteString(s.value)
+		buf.WriteString("\n")
+	}
+	return &buf
+}
+
+// resolve returns the value of a referenced string resource.
+func (t *aabResourceTable) resolve(ref aabProto) (string, bool) {
+	if t == nil {
+		return "", false
+	}
+	if ref.has(aabReferenceID) {
+		if value, ok := t.byID[uint32(ref.uint(aabReferenceID))]; ok {
+			return value, true
+		}
+	}
+	// Names are qualified with the package name for references to other packages.
+	name := ref.str(aabReferenceName)
+	if i := strings.IndexByte(name, ':'); i >= 0 {
+		name = name[i+1:]
+	}
+	value, ok := t.byName[name]
+	return value, ok
+}
+
+// parseAABManifest reads the package name, version and split name of a module from its decoded manifest.
+func parseAABManifest(node aabProto) (apkManifest, error) {
+	var enc apkManifestEncoder
+	err := encodeAABXMLNode(&enc, node, nil, 0)
+	if err != nil && !errors.Is(err, apkparser.ErrEndParsing) {
+		return apkManifest{}, err
+	}
+	return enc.manifest, nil
+}
+
+// decodeAABXML decodes an XML file compiled by aapt2 to text. Attributes referencing string resources are replaced
+// with the value of the resource, as decodeXML does for binary XML.
+func decodeAABXML(rdr io.Reader, resTable *aabResourceTable) (io.Reader, error) {
+	data, err := io.ReadAll(rdr)
+	if err != nil {
+		return nil, err
+	}
+	node, err := parseAABProto(data)
+	if err != nil {
+		return nil, err
+	}
+	if !node.has(aabNodeElement) {
+		return nil, errors.New("no root element in the XML file")
+	}
+
+	var buf bytes.Buffer
+	enc := xml.NewEncoder(&buf)
+	if err := encodeAABXMLNode(enc, node, resTable, 0); err != nil {
+		return nil, err
+	}
+	if err := enc.Flush(); err != nil {
+		return nil, err
+	}
+	return &buf, nil
+}
+
+// encodeAABXMLNode passes the tokens of an XmlNode message and its children to the encoder.
+func encodeAABXMLNode(enc apkparser.ManifestEncoder, node aabProto, resTable *aabResourceTable, depth int) error {
+	if depth > aabMaxNodeDepth {
+		return errors.New("XML nesting is too deep")
+	}
+	if !node.has(aabNodeElement) {
+		if text := node.str(aabNodeText); text != "" {
+			return enc.EncodeToken(xml.CharData(text))
+		}
+		return nil
+	}
+
+	element := node.msg(aabNodeElement)
+	start := xml.StartElement{
+		Name: xml.Name{Space: element.str(aabElementNamespaceURI), Local: element.str(aabElementName)},
+	}
+	attrs, err := element.msgs(aabElementAttribute)
+	if err != nil {
+		return err
+	}
+	for _, attr := range attrs {
+		start.Attr = append(start.Attr, xml.Attr{
+			Name:  xml.Name{Space: attr.str(aabAttrNamespaceURI), Local: attr.str(aabAttrName)},
+			Value: aabAttrValueText(attr, resTable),
+		})
+	}
+	if err := enc.EncodeToken(start); err != nil {
+		return err
+	}
+
+	children, err := element.msgs(aabElementChild)
+	if err != nil {
+		return err
+	}
+	for _, child := range children {
+		if err := encodeAABXMLNode(enc, child, resTable, depth+1); err != nil {
+			return err
+		}
+	}
+	return enc.EncodeToken(start.End())
+}
+
+// aabAttrValueText returns the text of an attribute. References to string resources are resolved, and other
+// compiled values are used when the attribute has no source text.
+func aabAttrValueText(attr aabProto, resTable *aabResourceTable) string {
+	item := attr.msg(aabAttrCompiledItem)
+	if item.has(aabItemRef) {
+		if value, ok := resTable.resolve(item.msg(aabItemRef)); ok {
+			return value
+		}
+	}
+	if value := attr.str(aabAttrValue); value != "" || len(item) == 0 {
+		return value
+	}
+
+	if value, ok := aabItemString(item); ok {
+		return value
+	}
+	switch {
+	case item.has(aabItemRef):
+		return "@" + item.msg(aabItemRef).str(aabReferenceName)
+	case item.has(aabItemFile):
+		return item.msg(aabItemFile).str(aabStringValue)
+	case item.has(aabItemPrim):
+		return aabPrimitiveText(item.msg(aabItemPrim))
+	}
+	return ""
+}
+
+// aabPrimitiveText formats the value of a Primitive message.
+func aabPrimitiveText(prim aabProto) string {
+	switch {
+	case prim.has(aabPrimBoolean):
+		return strconv.FormatBool(prim.uint(aabPrimBoolean) != 0)
+	case prim.has(aabPrimIntDecimal):
+		return strconv.Itoa(int(int32(prim.uint(aabPrimIntDecimal))))
+	case prim.has(aabPrimIntHex):
+		return fmt.Sprintf("0x%08x", uint32(prim.uint(aabPrimIntHex)))
+	case prim.has(aabPrimFloat):
+		return strconv.FormatFloat(float64(math.Float32frombits(uint32(prim.uint(aabPrimFloat)))), 'g', -1, 32)
+	}
+	for num := aabPrimColorARGB8; num <= aabPrimColorRGB4; num++ {
+		if prim.has(num) {
+			return fmt.Sprintf("#%08x", uint32(prim.uint(num)))
+		}
+	}
+	for _, num := range []protowire.Number{aabPrimDimension, aabPrimFraction} {
+		if prim.has(num) {
+			return strconv.FormatUint(prim.uint(num), 10)
+		}
+	}
+	return ""
+}
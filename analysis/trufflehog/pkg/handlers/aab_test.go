@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/feature"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+// testProto encodes the fields of a protocol buffer message. Values are either strings, encoded as length delimited
+// fields, or integers, encoded as varints.
+func testProto(fields ...any) []byte {
+	var b []byte
+	for i := 0; i+1 < len(fields); i += 2 {
+		num := protowire.Number(fields[i].(int))
+		switch v := fields[i+1].(type) {
+		case string:
+			b = protowire.AppendTag(b, num, protowire.BytesType)
+			b = protowire.AppendString(b, v)
+		case []byte:
+			b = protowire.AppendTag(b, num, protowire.BytesType)
+			b = protowire.AppendBytes(b, v)
+		case int:
+			b = protowire.AppendTag(b, num, protowire.VarintType)
+			b = protowire.AppendVarint(b, uint64(v))
+		}
+	}
+	return b
+}
+
+// testAABElement encodes an XmlNode holding an element with the given encoded XmlAttribute messages and child nodes.
+func testAABElement(name string, attrs [][]byte, children ...[]byte) []byte {
+	var element []byte
+	element = append(element, testProto(3, name)...)
+	for _, attr := range attrs {
+		element = append(element, testProto(4, attr)...)
+	}
+	for _, child := range children {
+		element = append(element, testProto(5, child)...)
+	}
+	return testProto(1, element)
+}
+
+const testAndroidNS = "http://schemas.android.com/apk/res/android"
+
+func buildTestAAB(t *testing.T) []byte {
+	t.Helper()
+
+	baseManifest := testAABElement("manifest",
+		[][]byte{
+			testProto(2, "package", 3, "com.example.leaky"),
+			testProto(1, testAndroidNS, 2, "versionName", 3, "1.2.3"),
+		},
+		testAABElement("application", nil,
+			testAABElement("meta-data", [][]byte{
+				testProto(1, testAndroidNS, 2, "name", 3, "com.google.android.geo.API_KEY"),
+				// A reference to a string resource, resolved through the resource table.
+				testProto(1, testAndroidNS, 2, "value", 3, "@string/maps_key",
+					6, testProto(1, testProto(2, 0x7f010000, 3, "string/maps_key"))),
+			}),
+		),
+	)
+	featureManifest := testAABElement("manifest", [][]byte{testProto(2, "split", 3, "feature")})
+	xmlConfig := testAABElement("config", nil, testProto(2, "xml-secret"))
+
+	stringEntry := func(id int, name string, configValues ...[]byte) []byte {
+		fields := []any{1, testProto(1, id), 2, name}
+		for _, cv := range configValues {
+			fields = append(fields, 6, cv)
+		}
+		return testProto(fields...)
+	}
+	stringValue := func(config []byte, value string) []byte {
+		return testProto(1, config, 2, testProto(4, testProto(2, testProto(1, value))))
+	}
+	resTable := testProto(2, testProto(
+		1, testProto(1, 0x7f),
+		2, "com.example.leaky",
+		3, testProto(1, testProto(1, 2), 2, "color"),
+		3, testProto(1, testProto(1, 1), 2, "string",
+			3, stringEntry(0, "maps_key", stringValue(nil, "resource-secret")),
+			3, stringEntry(1, "localized_key",
+				stringValue(testProto(9, "fr"), "localized-value"),
+				stringValue(nil, "default-value"),
+			),
+		),
+	))
+
+	return buildTestZip(t, map[string][]byte{
+		"BundleConfig.pb":                         testProto(1, testProto(1, "1.15.0")),
+		"BUNDLE-METADATA/com.example/mapping.txt": []byte("metadata-secret"),
+		"base/manifest/AndroidManifest.xml":       baseManifest,
+		"base/resources.pb":                       resTable,
+		"base/res/xml/config.xml":                 xmlConfig,
+		"base/assets/config.json":                 []byte(`{"api_key": "asset-secret"}`),
+		"base/lib/arm64-v8a/libkeys.so":           []byte("native-secret"),
+		"base/lib/x86/libkeys.so":                 []byte("native-secret"),
+		"feature/manifest/AndroidManifest.xml":    featureManifest,
+		"feature/assets/feature.txt":              []byte("feature-secret"),
+	})
+}
+
+func TestHandleFileAAB(t *testing.T) {
+	feature.EnableAPKHandler.Store(true)
+	t.Cleanup(func() { feature.EnableAPKHandler.Store(false) })
+
+	chunkSkel := &sources.Chunk{
+		SourceMetadata: &source_metadatapb.MetaData{
+			Data: &source_metadatapb.MetaData_Filesystem{Filesystem: &source_metadatapb.Filesystem{File: "leaky.aab"}},
+		},
+	}
+	chunkCh := make(chan *sources.Chunk, 32)
+	err := HandleFile(context.Background(), bytes.NewReader(buildTestAAB(t)), chunkSkel, sources.ChanReporter{Ch: chunkCh})
+	require.NoError(t, err)
+	close(chunkCh)
+
+	chunks := make(map[string]*sources.Chunk)
+	for chunk := range chunkCh {
+		bundle := chunk.SourceMetadata.GetAppBundle()
+		require.NotNil(t, bundle)
+		assert.Equal(t, "com.example.leaky", bundle.GetBundleId())
+		assert.Equal(t, "1.2.3", bundle.GetBundleVersion())
+		chunks[bundle.GetSplitName()+":"+bundle.GetBundlePath()] = chunk
+	}
+
+	tests := []struct {
+		key      string
+		contains string
+	}{
+		{key: "base:resources.pb", contains: "maps_key = resource-secret\nlocalized_key = default-value\n"},
+		{key: "base:manifest/AndroidManifest.xml", contains: `name="com.google.android.geo.API_KEY" android:value="resource-secret"`},
+		{key: "base:res/xml/config.xml", contains: "<config>xml-secret</config>"},
+		{key: "base:assets/config.json", contains: "asset-secret"},
+		{key: "base:lib/arm64-v8a/libkeys.so", contains: "native-secret"},
+		{key: "feature:manifest/AndroidManifest.xml", contains: `<manifest split="feature"></manifest>`},
+		{key: "feature:assets/feature.txt", contains: "feature-secret"},
+		{key: ":BundleConfig.pb", contains: "1.15.0"},
+		{key: ":BUNDLE-METADATA/com.example/mapping.txt", contains: "metadata-secret"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			chunk, ok := chunks[tt.key]
+			require.True(t, ok, "no chunk for %s", tt.key)
+			assert.Contains(t, string(chunk.Data), tt.contains)
+		})
+	}
+	assert.Len(t, chunks, len(tests))
+	assert.Equal(t,
+		&source_metadatapb.Extraction{
+			EntryPath:    "base/lib/arm64-v8a/libkeys.so",
+			Line:         1,
+			VariantPaths: []string{"base/lib/arm64-v8a/libkeys.so", "base/lib/x86/libkeys.so"},
+		},
+		chunks["base:lib/arm64-v8a/libkeys.so"].SourceMetadata.GetExtraction(),
+	)
+	assert.Equal(t,
+		&source_metadatapb.Extraction{Stage: apkResourcesStage, EntryPath: "base/resources.pb", Line: 1},
+		chunks["base:resources.pb"].SourceMetadata.GetExtraction(),
+	)
+	assert.Equal(t, "feature/assets/feature.txt", chunks["feature:assets/feature.txt"].SourceMetadata.GetExtraction().GetEntryPath())
+	assert.Equal(t, "BundleConfig.pb", chunks[":BundleConfig.pb"].SourceMetadata.GetExtraction().GetEntryPath())
+}
+
+func TestIsAABFile(t *testing.T) {
+	feature.EnableAPKHandler.Store(true)
+	t.Cleanup(func() { feature.EnableAPKHandler.Store(false) })
+
+	manifest := testAABElement("manifest", nil)
+	tests := map[string]struct {
+		files   map[string][]byte
+		options []readerOption
+		want    bool
+	}{
+		"app bundle": {
+			files:   map[string][]byte{"BundleConfig.pb": nil, "base/manifest/AndroidManifest.xml": manifest},
+			options: []readerOption{withFileExtension(aabExt)},
+			want:    true,
+		},
+		"app bundle without aab extension": {
+			files: map[string][]byte{"BundleConfig.pb": nil, "base/manifest/AndroidManifest.xml": manifest},
+			want:  false,
+		},
+		"base module with aab extension": {
+			files:   map[string][]byte{"base/manifest/AndroidManifest.xml": manifest},
+			options: []readerOption{withFileExtension(aabExt)},
+			want:    true,
+		},
+		"app bundle entry": {
+			files:   map[string][]byte{"base/manifest/AndroidManifest.xml": manifest},
+			options: []readerOption{withEntryName("bundles/leaky.aab")},
+			want:    true,
+		},
+		"plain zip with aab extension": {
+			files:   map[string][]byte{"README.md": []byte("readme")},
+			options: []readerOption{withFileExtension(aabExt)},
+			want:    false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			rdr, err := newFileReader(bytes.NewReader(buildTestZip(t, tt.files)), tt.options...)
+			require.NoError(t, err)
+			defer rdr.Close()
+
+			assert.Equal(t, tt.want, rdr.mime.String() == string(aabMime))
+			if !tt.want {
+				// The reader must be rewound for the archive handler.
+				assert.True(t, rdr.isGenericArchive)
+			}
+		})
+	}
+}
@@ -3,12 +3,15 @@ package handlers
 import (
 	"archive/zip"
 	"bytes"
+	"crypto/sha256"
 	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
+	"path"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -20,6 +23,7 @@ import (
 	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/defaults"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/iobuf"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
 )
 
 // General Note: There are tools that can fully decompile an apk (e.g. jadx, apktool, etc.)
@@ -28,8 +32,13 @@ import (
 // lightweight version that will search for secrets in the most common files that contain them.
 // And run in a fraction of the time (ex: 15 seconds vs. 5 minutes)
 
-// ToDo: Scan nested APKs (aka XAPK files). ATM the archive.go file will skip over them.
-// ToDo: Provide file location information to secret output.
+// Apps distributed as Android App Bundles are installed as a base APK plus split APKs holding the code, resources
+// and native libraries for each device configuration, and asset packs. Stores and backup tools package these together
+// in .xapk, .apks and .apkm containers. The APKs of a container are each scanned as an APK, and the chunks of every
+// APK record its package name, version and split name.
+
+// The chunks of every file record its path within the APK, or within the container for the APKs of a container,
+// along with the stage that decoded it: the string resources of resources.arsc, decoded binary XML or dex code.
 
 var (
 	keywordMatcherOnce sync.Once
@@ -105,6 +114,14 @@ func (km *detectorKeywordMatcher) FindKeywords(text []byte) []string {
 	return found
 }
 
+var (
+	// nativeLibRegex matches the native libraries of an APK, or of a module of an app bundle, capturing the
+	// directory they are in, their ABI and their file name.
+	nativeLibRegex = regexp.MustCompile(`^((?:[^/]+/)?lib)/([^/]+)/([^/]+\.so)$`)
+	// nativeLibABIs are the ABIs native libraries are built for, from the most to the least common.
+	nativeLibABIs = []string{"arm64-v8a", "armeabi-v7a", "x86_64", "x86", "armeabi", "mips64", "mips"}
+)
+
 var (
 	stringInstructionType  = "const-string"
 	targetInstructionTypes = []string{stringInstructionType, "iput-object", "sput-object", "const-class", "invoke-virtual", "invoke-super", "invoke-direct", "invoke-static", "invoke-interface"}
@@ -144,11 +161,11 @@ func newAPKHandler() *apkHandler {
 // HandleFile processes apk formatted files.
 // Fatal errors that will stop processing:
 // - Unable to create ZIP reader from input
-// - Unable to parse resources.arsc file
+// - Unable to parse resources.arsc file of a base APK
 // - Panics during processing (recovered but returned as errors)
 //
 // Non-fatal errors that will be logged and continue processing:
-// - Failed to process individual files within the APK
+// - Failed to process individual files within the APK, or individual APKs within a container
 // - Failed to process resources.arsc contents
 // - Failed to process individual dex classes
 // - Failed to decode specific XML files
@@ -184,7 +201,8 @@ func (h *apkHandler) HandleFile(ctx logContext.Context, input fileReader) chan D
 	return apkChan
 }
 
-// processAPK processes the apk file and sends the extracted data to the provided channel.
+// processAPK processes the apk file, or the APKs of an apk container, and sends the extracted data to the provided
+// channel.
 func (h *apkHandler) processAPK(ctx logContext.Context, input fileReader, apkChan chan DataOrErr) error {
 	// Create a ZIP reader from the input fileReader
 	zipReader, err := createZipReader(input)
@@ -192,20 +210,188 @@ func (h *apkHandler) processAPK(ctx logContext.Context, input fileReader, apkCha
 		return err
 	}
 
-	// Extract the resources.arsc file into a ResourceTable (needed for XML decoding)
-	resTable, err := parseResTable(zipReader)
+	if isAPKContainer(zipReader) {
+		return h.processAPKContainer(ctx, zipReader, apkChan)
+	}
+	// The libraries of the split APKs of an app stored as separate files are grouped by the source that scans them.
+	libs := apkNativeLibs{groups: groupNativeLibs([]apkEntries{{names: zipEntryNames(zipReader.File)}})}
+	_, nested := ctx.Value(depthKey).(int)
+	if split, ok := ctx.Value(splitAPKKey).(splitAPK); ok && !nested {
+		libs = apkNativeLibs{groups: split.splits.libs, root: split.path}
+	}
+	return h.processAPKZip(ctx, zipReader, "", libs, apkChan)
+}
+
+// SplitAPKs are the split APKs of an app stored as separate files, such as the base.apk and config.<abi>.apk files
+// installed by the Play Store, or the <app>.apk and <app>.split.*.apk files pulled from a device.
+type SplitAPKs struct {
+	libs nativeLibGroups
+}
+
+// NewSplitAPKs reads the listings of the split APKs of an app, at paths, to group the copies of each native library
+// built for the different ABIs across them.
+func NewSplitAPKs(paths []string) (*SplitAPKs, error) {
+	entries := make([]apkEntries, 0, len(paths))
+	for _, apkPath := range paths {
+		zipReader, err := zip.OpenReader(apkPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open apk %s: %w", apkPath, err)
+		}
+		entries = append(entries, apkEntries{path: apkPath, names: zipEntryNames(zipReader.File)})
+		if err := zipReader.Close(); err != nil {
+			return nil, fmt.Errorf("failed to close apk %s: %w", apkPath, err)
+		}
+	}
+	return &SplitAPKs{libs: groupNativeLibs(entries)}, nil
+}
+
+// splitAPK is the split APK at path, one of splits.
+type splitAPK struct {
+	splits *SplitAPKs
+	path   string
+}
+
+// nestedAPK is an APK stored within an apk container, opened for the duration of the container's processing.
+type nestedAPK struct {
+	f         io.ReadCloser
+	rdr       *iobuf.BufferedReadSeeker
+	zipReader *zip.Reader
+}
+
+func (a *nestedAPK) Close() {
+	_ = a.rdr.Close()
+	_ = a.f.Close()
+}
+
+// processAPKContainer processes the APKs of an .xapk, .apks or .apkm container as a single app. Other files,
+// such as the container's own manifest, are scanned as is. The APKs are all opened first, so the copies of each
+// native library built for the different ABIs are grouped across them, as they ship in their own config.<abi> splits.
+func (h *apkHandler) processAPKContainer(ctx logContext.Context, zipReader *zip.Reader, apkChan chan DataOrErr) error {
+	apks := make(map[*zip.File]*nestedAPK)
+	var entries []apkEntries
+	for _, file := range zipReader.File {
+		if file.FileInfo().IsDir() || file.UncompressedSize64 == 0 || strings.ToLower(path.Ext(file.Name)) != apkExt {
+			continue
+		}
+		apk, err := openNestedAPK(file)
+		if err != nil {
+			ctx.Logger().V(2).Info(fmt.Sprintf("failed to process file: %s", file.Name), "error", err)
+			continue
+		}
+		defer apk.Close()
+		apks[file] = apk
+		entries = append(entries, apkEntries{path: file.Name, names: zipEntryNames(apk.zipReader.File)})
+	}
+
+	libs := apkNativeLibs{groups: groupNativeLibs(entries), scanned: make(nativeLibSet)}
+	for _, file := range zipReader.File {
+		if file.FileInfo().IsDir() || file.UncompressedSize64 == 0 {
+			continue
+		}
+		var err error
+		if apk, ok := apks[file]; ok {
+			libs.root = file.Name
+			err = h.processAPKZip(logContext.WithValues(ctx, "apk", file.Name), apk.zipReader, file.Name, libs, apkChan)
+		} else if strings.ToLower(path.Ext(file.Name)) != apkExt {
+			err = h.processFile(ctx, file, nil, withEntryPath(nil, file.Name), apkChan)
+		}
+		if err != nil {
+			ctx.Logger().V(2).Info(fmt.Sprintf("failed to process file: %s", file.Name), "error", err)
+		}
+	}
+	return nil
+}
+
+// openNestedAPK opens an APK stored within an apk container.
+func openNestedAPK(file *zip.File) (*nestedAPK, error) {
+	f, err := openFile(file)
 	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", file.Name, err)
+	}
+	apk := &nestedAPK{f: f, rdr: iobuf.NewBufferedReaderSeeker(f)}
+
+	size, err := apk.rdr.Size()
+	if err != nil {
+		apk.Close()
+		return nil, fmt.Errorf("failed to get size of apk %s: %w", file.Name, err)
+	}
+	apk.zipReader, err = zip.NewReader(apk.rdr, size)
+	if err != nil {
+		apk.Close()
+		return nil, fmt.Errorf("failed to open apk %s: %w", file.Name, err)
+	}
+	return apk, nil
+}
+
+// apkNativeLibs are the native libraries of the APKs of an app.
+type apkNativeLibs struct {
+	// groups groups the copies of each library built for the different ABIs across the APKs of the app.
+	groups nativeLibGroups
+	// root is the path of the APK being processed that the paths of its libraries in groups are relative to.
+	root string
+	// scanned holds the libraries already scanned in the other APKs of a container, and is nil for an APK that
+	// isn't in one.
+	scanned nativeLibSet
+}
+
+// processAPKZip processes the files of a single APK. apkPath is the path of the APK within its container, which
+// the entry paths of its files are relative to, and from which its split name is told if its manifest can't be
+// parsed. It is empty for an APK that isn't in a container. libs are the native libraries of the app's APKs.
+func (h *apkHandler) processAPKZip(
+	ctx logContext.Context,
+	zipReader *zip.Reader,
+	apkPath string,
+	libs apkNativeLibs,
+	apkChan chan DataOrErr,
+) error {
+	manifest, err := parseAPKManifest(zipReader)
+	if err != nil {
+		ctx.Logger().V(2).Info("failed to parse AndroidManifest.xml", "error", err)
+		if apkPath != "" {
+			manifest.split = splitNameFromPath(apkPath)
+		}
+	}
+	manifest.entryRoot = apkPath
+	if manifest.split == "" {
+		manifest.split = baseSplitName
+	}
+	ctx = logContext.WithValues(ctx, "package", manifest.pkg, "split", manifest.split)
+
+	// Extract the resources.arsc file into a ResourceTable (needed for XML decoding).
+	// Split APKs that only hold assets or native libraries have no resources.arsc of their own.
+	resTable, err := parseResTable(zipReader)
+	if err != nil && !(errors.Is(err, errResourceTableNotFound) && manifest.split != baseSplitName) {
 		return err
 	}
 
 	// Process the ResourceTable file for secrets
-	if err := h.processResources(ctx, resTable, apkChan); err != nil {
-		ctx.Logger().Error(err, "failed to process resources.arsc")
+	if resTable != nil {
+		if err := h.processResources(ctx, resTable, manifest.metadata("resources.arsc"), apkChan); err != nil {
+			ctx.Logger().Error(err, "failed to process resources.arsc")
+		}
 	}
 
 	// Process all files for secrets
 	for _, file := range zipReader.File {
-		if err := h.processFile(ctx, file, resTable, apkChan); err != nil {
+		// Native libraries are built once for each ABI, and their copies hold the same strings, so only one is
+		// scanned.
+		libPath := path.Join(libs.root, file.Name)
+		if _, ok := libs.groups.skipped[libPath]; ok {
+			continue
+		}
+		entryPath := path.Join(apkPath, file.Name)
+		if scanned, ok := libs.scanned.scanned(file, entryPath); ok {
+			ctx.Logger().V(3).Info("skipping native library identical to one already scanned",
+				"path", entryPath,
+				"scanned", scanned,
+			)
+			continue
+		}
+		metadata := manifest.metadata(file.Name)
+		if variants, ok := libs.groups.variants[libPath]; ok {
+			metadata.Extraction.VariantPaths = variants
+		}
+		if err := h.processFile(ctx, file, resTable, metadata, apkChan); err != nil {
 			ctx.Logger().V(2).Info(fmt.Sprintf("failed to process file: %s", file.Name), "error", err)
 		}
 	}
@@ -213,7 +399,12 @@ func (h *apkHandler) processAPK(ctx logContext.Context, input fileReader, apkCha
 }
 
 // processResources processes the resources.arsc file and sends the extracted data to the provided channel.
-func (h *apkHandler) processResources(ctx logContext.Context, resTable *apkparser.ResourceTable, apkChan chan DataOrErr) error {
+func (h *apkHandler) processResources(
+	ctx logContext.Context,
+	resTable *apkparser.ResourceTable,
+	metadata *source_metadatapb.MetaData,
+	apkChan chan DataOrErr,
+) error {
 	if resTable == nil {
 		return errors.New("ResourceTable is nil")
 	}
@@ -221,7 +412,235 @@ func (h *apkHandler) processResources(ctx logContext.Context, resTable *apkparse
 	if err != nil {
 		return fmt.Errorf("failed to parse strings from resources.arsc: %w", err)
 	}
-	return h.handleAPKFileContent(ctx, rscStrRdr, "resources.arsc", apkChan)
+	return h.handleAPKFileContent(ctx, rscStrRdr, "resources.arsc", withExtractionStage(metadata, apkResourcesStage), apkChan)
+}
+
+// Extraction stages of the files of an APK that are decoded before being scanned.
+const (
+	// apkResourcesStage is the stage of the string resources of a resource table, written on "name = value" lines.
+	apkResourcesStage = "resources"
+	// apkXMLStage is the stage of compiled XML files decoded to text.
+	apkXMLStage = "xml"
+)
+
+// baseSplitName is the split name of the base APK of an app, which declares no split name in its manifest.
+const baseSplitName = "base"
+
+// apkManifest holds the AndroidManifest.xml attributes that identify an APK within its app.
+type apkManifest struct {
+	pkg         string
+	versionName string
+	split       string
+	// entryRoot is the path the entry paths of the APK's files are relative to, such as the path of the APK within
+	// its container.
+	entryRoot string
+}
+
+// metadata returns the metadata of a file within the APK.
+func (m apkManifest) metadata(fileName string) *source_metadatapb.MetaData {
+	return &source_metadatapb.MetaData{
+		AppBundle: &source_metadatapb.AppBundle{
+			BundleId:      m.pkg,
+			BundleVersion: m.versionName,
+			BundlePath:    fileName,
+			SplitName:     m.split,
+		},
+		Extraction: &source_metadatapb.Extraction{EntryPath: path.Join(m.entryRoot, fileName)},
+	}
+}
+
+// apkManifestEncoder reads the attributes of the root element of an AndroidManifest.xml, and then stops the parsing.
+type apkManifestEncoder struct{ manifest apkManifest }
+
+func (e *apkManifestEncoder) EncodeToken(t xml.Token) error {
+	start, ok := t.(xml.StartElement)
+	if !ok {
+		return nil
+	}
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "package":
+			e.manifest.pkg = attr.Value
+		case "versionName":
+			e.manifest.versionName = attr.Value
+		case "split":
+			e.manifest.split = attr.Value
+		}
+	}
+	return apkparser.ErrEndParsing
+}
+
+func (e *apkManifestEncoder) Flush() error { return nil }
+
+// parseAPKManifest reads the package name, version and split name of an APK from its AndroidManifest.xml,
+// which can be in either the binary or the plain text format.
+func parseAPKManifest(zipReader *zip.Reader) (apkManifest, error) {
+	for _, file := range zipReader.File {
+		if file.Name != "AndroidManifest.xml" {
+			continue
+		}
+		f, err := openFile(file)
+		if err != nil {
+			return apkManifest{}, err
+		}
+		defer f.Close()
+
+		rdr := iobuf.NewBufferedReaderSeeker(f)
+		defer rdr.Close()
+
+		var enc apkManifestEncoder
+		err = apkparser.ParseManifest(rdr, &enc, nil)
+		if errors.Is(err, apkparser.ErrPlainTextManifest) {
+			if _, err := rdr.Seek(0, io.SeekStart); err != nil {
+				return apkManifest{}, fmt.Errorf("error resetting reader after manifest parsing error: %w", err)
+			}
+			err = parsePlainTextManifest(rdr, &enc)
+		}
+		return enc.manifest, err
+	}
+	return apkManifest{}, errors.New("AndroidManifest.xml file not found in the APK archive")
+}
+
+// parsePlainTextManifest passes the root element of a plain text AndroidManifest.xml to the encoder.
+func parsePlainTextManifest(rdr io.Reader, enc *apkManifestEncoder) error {
+	decoder := xml.NewDecoder(rdr)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			_ = enc.EncodeToken(start)
+			return nil
+		}
+	}
+}
+
+// isAPKContainer checks whether a zip file is an apk container, which holds APKs rather than being one.
+func isAPKContainer(zipReader *zip.Reader) bool {
+	hasNestedAPK := false
+	for _, file := range zipReader.File {
+		switch {
+		case file.Name == "AndroidManifest.xml":
+			return false
+		case strings.ToLower(path.Ext(file.Name)) == apkExt:
+			hasNestedAPK = true
+		}
+	}
+	return hasNestedAPK
+}
+
+// splitNameFromPath derives the split name of an APK in a container from its path. Containers name their APKs after
+// their split, with tool specific prefixes, such as "split_config.arm64_v8a.apk" in .apkm files, or
+// "splits/base-master.apk" in .apks files.
+func splitNameFromPath(apkPath string) string {
+	name := strings.TrimSuffix(path.Base(apkPath), path.Ext(apkPath))
+	name = strings.TrimPrefix(name, "split_")
+	if name == baseSplitName || strings.HasPrefix(name, baseSplitName+"-") {
+		return baseSplitName
+	}
+	return name
+}
+
+// apkEntries are the names of the files of an APK, or of an app bundle, at path.
+type apkEntries struct {
+	path  string
+	names []string
+}
+
+// zipEntryNames returns the names of the files of a zip archive.
+func zipEntryNames(files []*zip.File) []string {
+	names := make([]string, 0, len(files))
+	for _, file := range files {
+		names = append(names, file.Name)
+	}
+	return names
+}
+
+// nativeLibGroups groups the copies of each native library built for the different ABIs, by their paths: the path of
+// their APK joined with their path within it.
+type nativeLibGroups struct {
+	// variants are the paths of the copies of each library built for several ABIs, keyed by the path of the copy
+	// that is scanned.
+	variants map[string][]string
+	// skipped are the paths of the other copies.
+	skipped map[string]struct{}
+}
+
+// groupNativeLibs groups the copies of each native library, lib/<abi>/<name>.so, built for the different ABIs,
+// across the APKs of an app. The copies of an app's split APKs are each in the config.<abi> split of their ABI.
+// The libraries of each module of an app bundle, <module>/lib/<abi>/<name>.so, are grouped separately. The copy that
+// is scanned is the one for the most common ABI.
+func groupNativeLibs(apks []apkEntries) nativeLibGroups {
+	type nativeLib struct{ path, abi string }
+	libs := make(map[string][]nativeLib)
+	for _, apk := range apks {
+		for _, name := range apk.names {
+			if matches := nativeLibRegex.FindStringSubmatch(name); matches != nil {
+				key := matches[1] + "/" + matches[3]
+				libs[key] = append(libs[key], nativeLib{path: path.Join(apk.path, name), abi: matches[2]})
+			}
+		}
+	}
+
+	groups := nativeLibGroups{variants: make(map[string][]string), skipped: make(map[string]struct{})}
+	for _, copies := range libs {
+		if len(copies) < 2 {
+			continue
+		}
+		sort.SliceStable(copies, func(i, j int) bool {
+			return nativeLibABIRank(copies[i].abi) < nativeLibABIRank(copies[j].abi)
+		})
+		paths := make([]string, 0, len(copies))
+		for _, lib := range copies {
+			paths = append(paths, lib.path)
+		}
+		groups.variants[paths[0]] = paths
+		for _, path := range paths[1:] {
+			groups.skipped[path] = struct{}{}
+		}
+	}
+	return groups
+}
+
+// nativeLibSet holds the SHA-256 hashes of the native libraries scanned in the APKs of a container, along with the
+// entry path of each one. Besides the copies built for the different ABIs, the split and standalone APKs of a
+// container may ship identical copies of a library under different paths, which are only scanned once.
+type nativeLibSet map[[sha256.Size]byte]string
+
+// scanned returns the entry path of an identical copy of a native library that was already scanned, and otherwise
+// records the library as scanned. Files that aren't native libraries, or can't be read, are never skipped.
+func (s nativeLibSet) scanned(file *zip.File, entryPath string) (string, bool) {
+	if s == nil || !nativeLibRegex.MatchString(file.Name) {
+		return "", false
+	}
+	f, err := openFile(file)
+	if err != nil {
+		return "", false
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", false
+	}
+	var key [sha256.Size]byte
+	hash.Sum(key[:0])
+	if scanned, ok := s[key]; ok {
+		return scanned, true
+	}
+	s[key] = entryPath
+	return "", false
+}
+
+// nativeLibABIRank returns the position of an ABI in nativeLibABIs. Unknown ABIs come last.
+func nativeLibABIRank(abi string) int {
+	for i, known := range nativeLibABIs {
+		if abi == known {
+			return i
+		}
+	}
+	return len(nativeLibABIs)
 }
 
 // processFile processes the file and sends the extracted data to the provided channel.
@@ -229,6 +648,7 @@ func (h *apkHandler) processFile(
 	ctx logContext.Context,
 	file *zip.File,
 	resTable *apkparser.ResourceTable,
+	metadata *source_metadatapb.MetaData,
 	apkChan chan DataOrErr,
 ) error {
 	// check if the file is empty
@@ -254,15 +674,16 @@ func (h *apkHandler) processFile(
 		if err != nil {
 			return fmt.Errorf("failed to decode xml file %s: %w", file.Name, err)
 		}
+		metadata = withExtractionStage(metadata, apkXMLStage)
 	case ".dex":
-		contentReader, err = h.processDexFile(ctx, rdr)
+		contentReader, metadata, err = h.decodeDexFile(ctx, rdr, metadata)
 		if err != nil {
 			return fmt.Errorf("failed to decode dex file %s: %w", file.Name, err)
 		}
 	default:
 		contentReader = rdr
 	}
-	return h.handleAPKFileContent(ctx, contentReader, file.Name, apkChan)
+	return h.handleAPKFileContent(ctx, contentReader, file.Name, metadata, apkChan)
 }
 
 // handleAPKFileContent sends the extracted data to the provided channel via the handleNonArchiveContent function.
@@ -270,6 +691,7 @@ func (h *apkHandler) handleAPKFileContent(
 	ctx logContext.Context,
 	rdr io.Reader,
 	fileName string,
+	metadata *source_metadatapb.MetaData,
 	apkChan chan DataOrErr,
 ) error {
 	mimeReader, err := newMimeTypeReader(rdr)
@@ -280,11 +702,15 @@ func (h *apkHandler) handleAPKFileContent(
 		ctx,
 		"filename", fileName,
 	)
-	return h.handleNonArchiveContent(ctx, mimeReader, apkChan)
+	return h.handleNonArchiveContent(ctx, mimeReader, metadata, apkChan)
 }
 
-// createZipReader creates a new ZIP reader from the input fileReader.
+// createZipReader creates a new ZIP reader from the input fileReader, unless one was already opened to detect its
+// type.
 func createZipReader(input fileReader) (*zip.Reader, error) {
+	if input.zipReader != nil {
+		return input.zipReader, nil
+	}
 	size, err := input.Size()
 	if err != nil {
 		return nil, err
@@ -313,9 +739,11 @@ func parseResTable(zipReader *zip.Reader) (*apkparser.ResourceTable, error) {
 			return resTable, nil
 		}
 	}
-	return nil, errors.New("resources.arsc file not found in the APK archive")
+	return nil, errResourceTableNotFound
 }
 
+var errResourceTableNotFound = errors.New("resources.arsc file not found in the APK archive")
+
 // openFile opens the file from the zip archive and returns the data as an io.ReadCloser
 // Note: responsibility of calling function to close the reader
 func openFile(file *zip.File) (io.ReadCloser, error) {
@@ -346,7 +774,7 @@ func extractStringsFromResTable(resTable *apkparser.ResourceTable) (io.Reader, e
 			}
 			// Write directly to the buffer
 			resourceStrings.WriteString(entry.Key)
-			resourceStrings.WriteString(": ")
+			resourceStrings.WriteString(" = ")
 			resourceStrings.WriteString(val)
 			resourceStrings.WriteString("\n")
 		}
@@ -1,16 +1,24 @@
 package handlers
 
 import (
+	"bytes"
 	"io"
 	"net/http"
+	"os"
+	"path"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
 	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/feature"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
 )
 
 func TestAPKHandler(t *testing.T) {
@@ -109,3 +117,240 @@ func TestOpenValidZipInvalidAPK(t *testing.T) {
 	err = handler.processAPK(ctx, newReader, archiveChan)
 	assert.Contains(t, err.Error(), "resources.arsc file not found")
 }
+
+// emptyResourceTable is a resources.arsc holding no packages.
+var emptyResourceTable = []byte{0x02, 0x00, 0x0c, 0x00, 0x0c, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+
+func buildTestAPKManifest(attrs string) []byte {
+	return []byte(`<?xml version="1.0" encoding="utf-8"?>` +
+		`<manifest xmlns:android="http://schemas.android.com/apk/res/android" ` + attrs + `></manifest>`)
+}
+
+func TestHandleFileAPKContainer(t *testing.T) {
+	feature.EnableAPKHandler.Store(true)
+	t.Cleanup(func() { feature.EnableAPKHandler.Store(false) })
+
+	container := buildTestZip(t, map[string][]byte{
+		"manifest.json": []byte(`{"package_name": "com.example.leaky", "token": "container-secret"}`),
+		"com.example.leaky.apk": buildTestZip(t, map[string][]byte{
+			"AndroidManifest.xml": buildTestAPKManifest(`package="com.example.leaky" android:versionName="2.0"`),
+			"resources.arsc":      emptyResourceTable,
+			"assets/config.txt":   []byte("base-secret"),
+		}),
+		"config.arm64_v8a.apk": buildTestZip(t, map[string][]byte{
+			"AndroidManifest.xml":         buildTestAPKManifest(`package="com.example.leaky" split="config.arm64_v8a"`),
+			"lib/arm64-v8a/libconfig.txt": []byte("config-secret"),
+		}),
+		// An asset pack whose manifest can't be parsed is named after its path.
+		"split_assets.apk": buildTestZip(t, map[string][]byte{
+			"assets/pack.txt": []byte("pack-secret"),
+		}),
+	})
+
+	chunkSkel := &sources.Chunk{
+		SourceMetadata: &source_metadatapb.MetaData{
+			Data: &source_metadatapb.MetaData_Filesystem{Filesystem: &source_metadatapb.Filesystem{File: "leaky.xapk"}},
+		},
+	}
+	chunkCh := make(chan *sources.Chunk, 32)
+	err := HandleFile(context.Background(), bytes.NewReader(container), chunkSkel, sources.ChanReporter{Ch: chunkCh})
+	require.NoError(t, err)
+	close(chunkCh)
+
+	chunks := make(map[string]*sources.Chunk)
+	for chunk := range chunkCh {
+		chunks[string(chunk.Data)] = chunk
+	}
+
+	tests := []struct {
+		data     string
+		split    string
+		bundleID string
+		path     string
+	}{
+		{data: "base-secret", split: "base", bundleID: "com.example.leaky", path: "assets/config.txt"},
+		{data: "config-secret", split: "config.arm64_v8a", bundleID: "com.example.leaky", path: "lib/arm64-v8a/libconfig.txt"},
+		{data: "pack-secret", split: "assets", path: "assets/pack.txt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.split, func(t *testing.T) {
+			chunk, ok := chunks[tt.data]
+			require.True(t, ok, "no chunk for %s", tt.data)
+			bundle := chunk.SourceMetadata.GetAppBundle()
+			assert.Equal(t, tt.split, bundle.GetSplitName())
+			assert.Equal(t, tt.bundleID, bundle.GetBundleId())
+			assert.Equal(t, tt.path, bundle.GetBundlePath())
+			assert.Equal(t, "leaky.xapk", chunk.SourceMetadata.GetFilesystem().GetFile())
+		})
+	}
+	assert.Equal(t, "2.0", chunks["base-secret"].SourceMetadata.GetAppBundle().GetBundleVersion())
+	// The container's own files are scanned as is.
+	assert.Contains(t, chunks, `{"package_name": "com.example.leaky", "token": "container-secret"}`)
+}
+
+func TestHandleFileAPKContainer_IdenticalNativeLibs(t *testing.T) {
+	feature.EnableAPKHandler.Store(true)
+	t.Cleanup(func() { feature.EnableAPKHandler.Store(false) })
+
+	// The splits and the standalone APK of an .apks container ship identical copies of the arm64 library.
+	lib := []byte("lib-secret")
+	container := buildTestZip(t, map[string][]byte{
+		"splits/base-master.apk": buildTestZip(t, map[string][]byte{
+			"AndroidManifest.xml": buildTestAPKManifest(`package="com.example.leaky"`),
+			"resources.arsc":      emptyResourceTable,
+		}),
+		"splits/base-arm64_v8a.apk": buildTestZip(t, map[string][]byte{
+			"AndroidManifest.xml":       buildTestAPKManifest(`package="com.example.leaky" split="config.arm64_v8a"`),
+			"lib/arm64-v8a/libleaky.so": lib,
+		}),
+		"standalones/standalone-arm64_v8a.apk": buildTestZip(t, map[string][]byte{
+			"AndroidManifest.xml":       buildTestAPKManifest(`package="com.example.leaky"`),
+			"resources.arsc":            emptyResourceTable,
+			"lib/arm64-v8a/libleaky.so": lib,
+			"lib/arm64-v8a/libother.so": []byte("other-secret"),
+		}),
+	})
+
+	chunkSkel := &sources.Chunk{
+		SourceMetadata: &source_metadatapb.MetaData{
+			Data: &source_metadatapb.MetaData_Filesystem{Filesystem: &source_metadatapb.Filesystem{File: "leaky.apks"}},
+		},
+	}
+	chunkCh := make(chan *sources.Chunk, 32)
+	err := HandleFile(context.Background(), bytes.NewReader(container), chunkSkel, sources.ChanReporter{Ch: chunkCh})
+	require.NoError(t, err)
+	close(chunkCh)
+
+	counts := make(map[string]int)
+	for chunk := range chunkCh {
+		counts[string(chunk.Data)]++
+	}
+	assert.Equal(t, 1, counts["lib-secret"])
+	assert.Equal(t, 1, counts["other-secret"])
+}
+
+func TestHandleFileAPKContainer_ABISplits(t *testing.T) {
+	feature.EnableAPKHandler.Store(true)
+	t.Cleanup(func() { feature.EnableAPKHandler.Store(false) })
+
+	// Each ABI's copy of the library ships in its own config.<abi> split.
+	container := buildTestZip(t, map[string][]byte{
+		"base.apk": buildTestZip(t, map[string][]byte{
+			"AndroidManifest.xml": buildTestAPKManifest(`package="com.example.leaky"`),
+			"resources.arsc":      emptyResourceTable,
+		}),
+		"config.x86_64.apk": buildTestZip(t, map[string][]byte{
+			"AndroidManifest.xml":    buildTestAPKManifest(`package="com.example.leaky" split="config.x86_64"`),
+			"lib/x86_64/libleaky.so": []byte("x86_64-secret"),
+		}),
+		"config.arm64_v8a.apk": buildTestZip(t, map[string][]byte{
+			"AndroidManifest.xml":       buildTestAPKManifest(`package="com.example.leaky" split="config.arm64_v8a"`),
+			"lib/arm64-v8a/libleaky.so": []byte("arm64-secret"),
+		}),
+	})
+
+	chunkSkel := &sources.Chunk{
+		SourceMetadata: &source_metadatapb.MetaData{
+			Data: &source_metadatapb.MetaData_Filesystem{Filesystem: &source_metadatapb.Filesystem{File: "leaky.xapk"}},
+		},
+	}
+	chunkCh := make(chan *sources.Chunk, 32)
+	err := HandleFile(context.Background(), bytes.NewReader(container), chunkSkel, sources.ChanReporter{Ch: chunkCh})
+	require.NoError(t, err)
+	close(chunkCh)
+
+	chunks := make(map[string]*sources.Chunk)
+	for chunk := range chunkCh {
+		chunks[string(chunk.Data)] = chunk
+	}
+	assert.NotContains(t, chunks, "x86_64-secret")
+	require.Contains(t, chunks, "arm64-secret")
+	assert.Equal(t, []string{
+		"config.arm64_v8a.apk/lib/arm64-v8a/libleaky.so",
+		"config.x86_64.apk/lib/x86_64/libleaky.so",
+	}, chunks["arm64-secret"].SourceMetadata.GetExtraction().GetVariantPaths())
+}
+
+func TestHandleFileSplitAPKs(t *testing.T) {
+	feature.EnableAPKHandler.Store(true)
+	t.Cleanup(func() { feature.EnableAPKHandler.Store(false) })
+
+	// The split APKs of an app pulled from a device are stored as separate files.
+	dir := t.TempDir()
+	apks := map[string][]byte{
+		"base.apk": buildTestZip(t, map[string][]byte{
+			"AndroidManifest.xml": buildTestAPKManifest(`package="com.example.leaky"`),
+			"resources.arsc":      emptyResourceTable,
+		}),
+		"split_config.x86_64.apk": buildTestZip(t, map[string][]byte{
+			"AndroidManifest.xml":    buildTestAPKManifest(`package="com.example.leaky" split="config.x86_64"`),
+			"lib/x86_64/libleaky.so": []byte("x86_64-secret"),
+		}),
+		"split_config.arm64_v8a.apk": buildTestZip(t, map[string][]byte{
+			"AndroidManifest.xml":       buildTestAPKManifest(`package="com.example.leaky" split="config.arm64_v8a"`),
+			"lib/arm64-v8a/libleaky.so": []byte("arm64-secret"),
+		}),
+	}
+	var paths []string
+	for name, data := range apks {
+		apkPath := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(apkPath, data, 0o600))
+		paths = append(paths, apkPath)
+	}
+	splits, err := NewSplitAPKs(paths)
+	require.NoError(t, err)
+
+	chunks := make(map[string]*sources.Chunk)
+	for _, apkPath := range paths {
+		chunkSkel := &sources.Chunk{
+			SourceMetadata: &source_metadatapb.MetaData{
+				Data: &source_metadatapb.MetaData_Filesystem{Filesystem: &source_metadatapb.Filesystem{File: apkPath}},
+			},
+		}
+		chunkCh := make(chan *sources.Chunk, 32)
+		err := HandleFile(
+			context.Background(), bytes.NewReader(apks[filepath.Base(apkPath)]), chunkSkel,
+			sources.ChanReporter{Ch: chunkCh}, WithSplitAPKs(splits, apkPath),
+		)
+		require.NoError(t, err)
+		close(chunkCh)
+		for chunk := range chunkCh {
+			chunks[string(chunk.Data)] = chunk
+		}
+	}
+	assert.NotContains(t, chunks, "x86_64-secret")
+	require.Contains(t, chunks, "arm64-secret")
+	assert.Equal(t, []string{
+		path.Join(dir, "split_config.arm64_v8a.apk", "lib/arm64-v8a/libleaky.so"),
+		path.Join(dir, "split_config.x86_64.apk", "lib/x86_64/libleaky.so"),
+	}, chunks["arm64-secret"].SourceMetadata.GetExtraction().GetVariantPaths())
+}
+
+func TestIsAPKFile(t *testing.T) {
+	feature.EnableAPKHandler.Store(true)
+	t.Cleanup(func() { feature.EnableAPKHandler.Store(false) })
+
+	apk := map[string][]byte{"AndroidManifest.xml": buildTestAPKManifest(`package="com.example.leaky"`)}
+	tests := map[string]struct {
+		files map[string][]byte
+		ext   string
+		want  bool
+	}{
+		"split apk":                   {files: apk, ext: apkExt, want: true},
+		"xapk":                        {files: map[string][]byte{"base.apk": buildTestZip(t, apk)}, ext: xapkExt, want: true},
+		"apks":                        {files: map[string][]byte{"splits/base-master.apk": buildTestZip(t, apk)}, ext: apksExt, want: true},
+		"apkm":                        {files: map[string][]byte{"base.apk": buildTestZip(t, apk)}, ext: apkmExt, want: true},
+		"apk without manifest":        {files: map[string][]byte{"README.md": []byte("readme")}, ext: apkExt, want: false},
+		"container without apks":      {files: map[string][]byte{"README.md": []byte("readme")}, ext: xapkExt, want: false},
+		"apks without apks extension": {files: map[string][]byte{"base.apk": buildTestZip(t, apk)}, ext: ".zip", want: false},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			rdr, err := newFileReader(bytes.NewReader(buildTestZip(t, tt.files)), withFileExtension(tt.ext))
+			require.NoError(t, err)
+			defer rdr.Close()
+
+			assert.Equal(t, tt.want, rdr.mime.String() == string(apkMime))
+		})
+	}
+}
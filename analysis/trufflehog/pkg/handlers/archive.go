@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path"
 	"time"
 
 	"github.com/mholt/archiver/v4"
@@ -11,15 +12,22 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/feature"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
 )
 
 type ctxKey int
 
 const (
-	depthKey          ctxKey = iota
-	defaultBufferSize        = 512
+	depthKey ctxKey = iota
+	// entryPathKey holds the path of the archive entry being extracted, joined with the paths of the archives
+	// enclosing it.
+	entryPathKey
+	// splitAPKKey holds the splitAPK of the file being handled, if it is one of the split APKs of an app.
+	splitAPKKey
 )
 
+const defaultBufferSize = 512
+
 var (
 	// NOTE: This is a temporary workaround for |openArchive| incrementing depth twice per archive.
 	// See: https://github.com/trufflesecurity/trufflehog/issues/2942
@@ -124,7 +132,11 @@ func (h *archiveHandler) openArchive(
 
 	if reader.format == nil {
 		if depth > 0 {
-			return h.handleNonArchiveContent(ctx, newMimeTypeReaderFromFileReader(reader), dataOrErrChan)
+			var metadata *source_metadatapb.MetaData
+			if entryPath, ok := ctx.Value(entryPathKey).(string); ok && entryPath != "" {
+				metadata = withEntryPath(nil, entryPath)
+			}
+			return h.handleNonArchiveContent(ctx, newMimeTypeReaderFromFileReader(reader), metadata, dataOrErrChan)
 		}
 		return fmt.Errorf("unknown archive format")
 	}
@@ -191,6 +203,12 @@ func (h *archiveHandler) extractorHandler(dataOrErrChan chan DataOrErr) func(con
 			depth = ctxDepth
 		}
 
+		entryPath := file.NameInArchive
+		if parentPath, ok := ctx.Value(entryPathKey).(string); ok {
+			entryPath = path.Join(parentPath, file.NameInArchive)
+		}
+		lCtx = logContext.WithValue(lCtx, entryPathKey, entryPath)
+
 		fileSize := file.Size()
 		if int(fileSize) > maxSize {
 			lCtx.Logger().V(2).Info("skipping file: size exceeds max allowed", "size", fileSize, "limit", maxSize)
@@ -226,7 +244,7 @@ func (h *archiveHandler) extractorHandler(dataOrErrChan chan DataOrErr) func(con
 			}
 		}()
 
-		rdr, err := newFileReader(f)
+		rdr, err := newFileReader(f, withEntryName(file.Name()))
 		if err != nil {
 			if errors.Is(err, ErrEmptyReader) {
 				lCtx.Logger().V(5).Info("empty reader, skipping file")
@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"net/http"
 	"regexp"
@@ -9,8 +11,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
 )
 
 func TestArchiveHandler(t *testing.T) {
@@ -128,3 +132,28 @@ func TestOpenInvalidArchive(t *testing.T) {
 	err = handler.openArchive(ctx, 0, rdr, dataOrErrChan)
 	assert.Error(t, err)
 }
+
+func TestArchiveHandlerEntryPath(t *testing.T) {
+	var content bytes.Buffer
+	for i := 1; content.Len() < sources.ChunkSize*3/2; i++ {
+		fmt.Fprintf(&content, "setting_%d = value\n", i)
+	}
+	inner := buildTestZip(t, map[string][]byte{"config/app.ini": content.Bytes()})
+	outer := buildTestZip(t, map[string][]byte{"nested/inner.zip": inner})
+
+	chunkCh := make(chan *sources.Chunk, 8)
+	err := HandleFile(logContext.Background(), bytes.NewReader(outer), &sources.Chunk{}, sources.ChanReporter{Ch: chunkCh})
+	require.NoError(t, err)
+	close(chunkCh)
+
+	var lines []int64
+	for chunk := range chunkCh {
+		extraction := chunk.SourceMetadata.GetExtraction()
+		require.NotNil(t, extraction)
+		assert.Equal(t, "nested/inner.zip/config/app.ini", extraction.GetEntryPath())
+		lines = append(lines, extraction.GetLine())
+	}
+	// The second chunk starts on the line following the newlines of the first.
+	wantSecond := 1 + int64(bytes.Count(content.Bytes()[:sources.ChunkSize], []byte("\n")))
+	assert.Equal(t, []int64{1, wantSecond}, lines)
+}
@@ -0,0 +1,368 @@
+package handlers
+
+import (
+	"bytes"
+	"debug/elf"
+	"debug/macho"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+)
+
+// Flutter apps compile their Dart code ahead of time into a shared library, libapp.so on Android, or a framework,
+// App.framework on iOS. Their string literals aren't in the string sections of the executable, but are objects of
+// the snapshots exported by the kDartVmSnapshotData and kDartIsolateSnapshotData symbols. In AOT snapshots, string
+// objects are stored in the read-only data image that follows the serialized snapshot, in their in-memory layout:
+// a header word, the length as a Smi and the characters, one or two bytes each, padded to the object alignment.
+//
+// The layout depends on the target the snapshot was compiled for, which is told by the features string of its
+// header, following its version hash. The string objects are recognized by their length, the size recorded in their
+// header and their padding, so they are found regardless of the class ids of the Dart version.
+
+// dartStage is the extraction stage of chunks extracted from Dart AOT snapshots.
+const dartStage = "dart"
+
+const (
+	dartSnapshotMagic = 0xdcdcf5f5
+	// dartVersionHashSize is the length of the snapshot version, a hexadecimal hash of the snapshot format.
+	dartVersionHashSize = 32
+	// maxDartFeaturesSize is the longest features string that is read.
+	maxDartFeaturesSize = 1024
+	// maxDartSnapshotSize is the largest snapshot that is read.
+	maxDartSnapshotSize = 256 << 20 // 256 MB
+)
+
+// dartSnapshotSymbols are the symbols of the snapshots holding string objects, without their leading underscores.
+// Their case varies between toolchains.
+var dartSnapshotSymbols = map[string]struct{}{
+	"kdartvmsnapshotdata":      {},
+	"kdartisolatesnapshotdata": {},
+}
+
+func isDartSnapshotSymbol(name string) bool {
+	_, ok := dartSnapshotSymbols[strings.ToLower(strings.TrimLeft(name, "_"))]
+	return ok
+}
+
+// dartSnapshot is the data of a snapshot symbol, read from the section holding it.
+type dartSnapshot struct {
+	symbol string
+	addr   uint64
+	data   []byte
+}
+
+// elfDartSnapshots returns the snapshots exported by an ELF shared library.
+func elfDartSnapshots(ctx logContext.Context, f *elf.File) []dartSnapshot {
+	syms, err := f.DynamicSymbols()
+	if err != nil {
+		return nil
+	}
+	var out []dartSnapshot
+	for _, sym := range syms {
+		if !isDartSnapshotSymbol(sym.Name) || int(sym.Section) >= len(f.Sections) {
+			continue
+		}
+		sec := f.Sections[sym.Section]
+		if sec.Type == elf.SHT_NOBITS || sym.Value < sec.Addr || sym.Value >= sec.Addr+sec.Size {
+			continue
+		}
+		size := sec.Addr + sec.Size - sym.Value
+		if sym.Size > 0 && sym.Size < size {
+			size = sym.Size
+		}
+		data, err := readDartSnapshot(sec, sym.Value-sec.Addr, size)
+		if err != nil {
+			ctx.Logger().V(3).Info("failed to read Dart snapshot", "symbol", sym.Name, "error", err)
+			continue
+		}
+		out = append(out, dartSnapshot{symbol: sym.Name, addr: sym.Value, data: data})
+	}
+	return out
+}
+
+// machoDartSnapshots returns the snapshots exported by a Mach-O file. The size of the symbols isn't recorded, so
+// each snapshot is read up to the end of its section.
+func machoDartSnapshots(ctx logContext.Context, f *macho.File) []dartSnapshot {
+	if f.Symtab == nil {
+		return nil
+	}
+	var out []dartSnapshot
+	for _, sym := range f.Symtab.Syms {
+		// Section numbers start at 1, 0 being no section.
+		if !isDartSnapshotSymbol(sym.Name) || sym.Sect == 0 || int(sym.Sect) > len(f.Sections) {
+			continue
+		}
+		sec := f.Sections[sym.Sect-1]
+		if sec.Offset == 0 || sym.Value < sec.Addr || sym.Value >= sec.Addr+sec.Size {
+			continue
+		}
+		data, err := readDartSnapshot(sec, sym.Value-sec.Addr, sec.Addr+sec.Size-sym.Value)
+		if err != nil {
+			ctx.Logger().V(3).Info("failed to read Dart snapshot", "symbol", sym.Name, "error", err)
+			continue
+		}
+		out = append(out, dartSnapshot{symbol: sym.Name, addr: sym.Value, data: data})
+	}
+	return out
+}
+
+func readDartSnapshot(sec io.ReaderAt, off, size uint64) ([]byte, error) {
+	if size > maxDartSnapshotSize {
+		return nil, fmt.Errorf("snapshot exceeds max size %d", maxDartSnapshotSize)
+	}
+	data := make([]byte, size)
+	if _, err := sec.ReadAt(data, int64(off)); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	return data, nil
+}
+
+// handleDartSnapshots scans the strings of the snapshots of a Dart AOT program, one per line. The chunks record
+// the version hash of the snapshots.
+// It returns false if none of the snapshots could be parsed.
+func (h *defaultHandler) handleDartSnapshots(
+	ctx logContext.Context,
+	snapshots []dartSnapshot,
+	metadata *source_metadatapb.MetaData,
+	dataOrErrChan chan DataOrErr,
+) (bool, error) {
+	var out bytes.Buffer
+	var version string
+	// The VM and isolate snapshots, and the snapshots of each architecture, share many strings.
+	seen := make(map[string]struct{})
+	for _, snapshot := range snapshots {
+		header, err := parseDartSnapshotHeader(snapshot.data)
+		if err != nil {
+			ctx.Logger().V(3).Info("failed to parse Dart snapshot", "symbol", snapshot.symbol, "error", err)
+			continue
+		}
+		ctx.Logger().V(4).Info("found Dart snapshot",
+			"symbol", snapshot.symbol, "version", header.version, "features", header.features)
+		version = header.version
+
+		for _, str := range header.layout.strings(snapshot.addr, snapshot.data) {
+			if _, ok := seen[str]; ok {
+				continue
+			}
+			seen[str] = struct{}{}
+			out.WriteString(str)
+			out.WriteByte('\n')
+		}
+	}
+	if version == "" {
+		return false, nil
+	}
+
+	decoded := withExtractionStage(metadata, dartStage)
+	decoded.Extraction.FormatVersion = version
+	reader := mimeTypeReader{mimeExt: ".txt", mimeName: textMime, Reader: bytes.NewReader(out.Bytes())}
+	return true, h.handleNonArchiveContent(ctx, reader, decoded, dataOrErrChan)
+}
+
+// dartSnapshotHeader is the header of a snapshot: its magic, length and kind, followed by its version hash and its
+// NUL terminated features string.
+type dartSnapshotHeader struct {
+	version  string
+	features string
+	layout   dartObjectLayout
+}
+
+// parseDartSnapshotHeader parses the header of a snapshot. The length and kind follow the magic at word aligned
+// offsets, so the version hash is at offset 24 for 64-bit targets, and at 20 for 32-bit ones.
+func parseDartSnapshotHeader(data []byte) (dartSnapshotHeader, error) {
+	if len(data) < 4 || binary.LittleEndian.Uint32(data) != dartSnapshotMagic {
+		return dartSnapshotHeader{}, errors.New("missing Dart snapshot magic")
+	}
+	for _, off := range []int{24, 20} {
+		if len(data) < off+dartVersionHashSize || !isHex(data[off:off+dartVersionHashSize]) {
+			continue
+		}
+		features := data[off+dartVersionHashSize : min(len(data), off+dartVersionHashSize+maxDartFeaturesSize)]
+		end := bytes.IndexByte(features, 0)
+		if end < 0 {
+			return dartSnapshotHeader{}, errors.New("unterminated Dart snapshot features")
+		}
+		header := dartSnapshotHeader{
+			version:  string(data[off : off+dartVersionHashSize]),
+			features: string(features[:end]),
+		}
+		header.layout = dartLayoutForFeatures(header.features, off == 24)
+		return header, nil
+	}
+	return dartSnapshotHeader{}, errors.New("missing Dart snapshot version")
+}
+
+func isHex(b []byte) bool {
+	for _, c := range b {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// dartObjectLayout is the layout of string objects for a target.
+type dartObjectLayout struct {
+	// alignment is the alignment of objects, twice the word size.
+	alignment int
+	// lengthOffset is the offset of the length, following the header word.
+	lengthOffset int
+	// lengthSize is the size of the length, a word, or 4 bytes for 64-bit targets with compressed pointers.
+	lengthSize int
+	// dataOffset is the offset of the characters. 32-bit targets store the hash of a string after its length.
+	dataOffset int
+}
+
+// dartLayoutForFeatures returns the object layout of the target a snapshot's features string names, such as
+// "product no-code_comments arm64-sysv compressed-pointers null-safety". If it names no known architecture, the
+// word size is told by the header of the snapshot.
+func dartLayoutForFeatures(features string, wordSize64 bool) dartObjectLayout {
+	compressed := false
+	for _, feature := range strings.Fields(features) {
+		arch, _, _ := strings.Cut(feature, "-")
+		switch arch {
+		case "x64", "arm64", "riscv64", "simarm64", "simriscv64":
+			wordSize64 = true
+		case "ia32", "arm", "riscv32", "simarm", "simriscv32":
+			wordSize64 = false
+		case "compressed":
+			compressed = feature == "compressed-pointers"
+		}
+	}
+	switch {
+	case !wordSize64:
+		return dartObjectLayout{alignment: 8, lengthOffset: 4, lengthSize: 4, dataOffset: 12}
+	case compressed:
+		return dartObjectLayout{alignment: 16, lengthOffset: 8, lengthSize: 4, dataOffset: 12}
+	default:
+		return dartObjectLayout{alignment: 16, lengthOffset: 8, lengthSize: 8, dataOffset: 16}
+	}
+}
+
+// strings returns the one-byte and two-byte string objects of a snapshot loaded at addr. Objects are aligned from
+// the start of the address space, and their header word records their size in units of the alignment, in 4 bits
+// in recent Dart versions and in 8 bits in older ones, or 0 if it doesn't fit.
+func (l dartObjectLayout) strings(addr uint64, data []byte) []string {
+	var out []string
+	start := int((uint64(l.alignment) - addr%uint64(l.alignment)) % uint64(l.alignment))
+	for off := start; off+l.dataOffset <= len(data); {
+		str, size, ok := l.stringAt(data[off:])
+		if !ok {
+			off += l.alignment
+			continue
+		}
+		if len(str) >= minPrintableStringLength {
+			out = append(out, str)
+		}
+		off += size
+	}
+	return out
+}
+
+// stringAt decodes the string object at the start of data, returning it along with the size of the object.
+func (l dartObjectLayout) stringAt(data []byte) (string, int, bool) {
+	var smi uint64
+	if l.lengthSize == 4 {
+		smi = uint64(binary.LittleEndian.Uint32(data[l.lengthOffset:]))
+	} else {
+		smi = binary.LittleEndian.Uint64(data[l.lengthOffset:])
+	}
+	// Smis are tagged with a zero low bit.
+	if smi&1 != 0 || smi == 0 || smi>>1 > uint64(len(data)) {
+		return "", 0, false
+	}
+	length := int(smi >> 1)
+	// The header word precedes the length.
+	var tags uint64
+	if l.lengthOffset == 8 {
+		tags = binary.LittleEndian.Uint64(data)
+	} else {
+		tags = uint64(binary.LittleEndian.Uint32(data))
+	}
+
+	for _, charSize := range []int{1, 2} {
+		end := l.dataOffset + length*charSize
+		size := (end + l.alignment - 1) / l.alignment * l.alignment
+		if size > len(data) || !l.sizeTagMatches(tags, size) || !isZero(data[end:size]) {
+			continue
+		}
+		chars := data[l.dataOffset:end]
+		if charSize == 1 {
+			if str, ok := decodeDartOneByteString(chars); ok {
+				return str, size, true
+			}
+			continue
+		}
+		if str, ok := decodeDartTwoByteString(chars); ok {
+			return str, size, true
+		}
+	}
+	return "", 0, false
+}
+
+func (l dartObjectLayout) sizeTagMatches(tags uint64, size int) bool {
+	units := uint64(size / l.alignment)
+	for _, bits := range []uint{4, 8} {
+		want := units
+		if units >= 1<<bits {
+			want = 0
+		}
+		if tags>>8&(1<<bits-1) == want {
+			return true
+		}
+	}
+	return false
+}
+
+func isZero(b []byte) bool {
+	for _, c := range b {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// decodeDartOneByteString decodes the Latin-1 characters of a one-byte string, which must be printable.
+func decodeDartOneByteString(chars []byte) (string, bool) {
+	runes := make([]rune, len(chars))
+	for i, c := range chars {
+		if !isPrintableDartChar(rune(c)) {
+			return "", false
+		}
+		runes[i] = rune(c)
+	}
+	return string(runes), true
+}
+
+// decodeDartTwoByteString decodes the UTF-16 characters of a two-byte string. Strings whose characters all fit in
+// a byte are stored as one-byte strings, so a two-byte string has a character that doesn't.
+func decodeDartTwoByteString(chars []byte) (string, bool) {
+	units := make([]uint16, len(chars)/2)
+	wide := false
+	for i := range units {
+		units[i] = binary.LittleEndian.Uint16(chars[2*i:])
+		wide = wide || units[i] > 0xff
+	}
+	if !wide {
+		return "", false
+	}
+	runes := utf16.Decode(units)
+	for _, r := range runes {
+		if r == utf8.RuneError || !isPrintableDartChar(r) {
+			return "", false
+		}
+	}
+	return string(runes), true
+}
+
+func isPrintableDartChar(r rune) bool {
+	return r >= 0x20 && r != 0x7f && (r < 0x80 || r >= 0xa0) || r == '\t' || r == '\n' || r == '\r'
+}
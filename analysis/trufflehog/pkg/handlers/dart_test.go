@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+const testDartVersion = "0123456789abcdef0123456789abcdef"
+
+// buildTestDartSnapshot builds a snapshot for a 64-bit target with compressed pointers, whose read-only data holds
+// the given strings as one-byte or two-byte string objects, following some serialized data.
+func buildTestDartSnapshot(strs ...string) []byte {
+	le := binary.LittleEndian
+	align := func(b []byte) []byte {
+		for len(b)%16 != 0 {
+			b = append(b, 0)
+		}
+		return b
+	}
+
+	snapshot := le.AppendUint32(nil, dartSnapshotMagic)
+	snapshot = append(snapshot, 0, 0, 0, 0)
+	snapshot = le.AppendUint64(snapshot, 0) // length
+	snapshot = le.AppendUint64(snapshot, 3) // kind
+	snapshot = append(snapshot, testDartVersion...)
+	snapshot = append(snapshot, "product no-code_comments arm64 android compressed-pointers null-safety\x00"...)
+	snapshot = align(append(snapshot, 0x81, 0x92, 0x03, 0xff, 0x10, 0x22))
+
+	for _, str := range strs {
+		units := utf16.Encode([]rune(str))
+		chars, cid := []byte(str), uint64(94)
+		for _, u := range units {
+			if u > 0xff {
+				chars, cid = nil, 95
+				for _, u := range units {
+					chars = le.AppendUint16(chars, u)
+				}
+				break
+			}
+		}
+		size := (12 + len(chars) + 15) / 16 * 16
+		sizeTag := uint64(size / 16)
+		if sizeTag >= 16 {
+			sizeTag = 0
+		}
+		snapshot = le.AppendUint64(snapshot, 0xabcd<<32|cid<<12|sizeTag<<8|0x0a)
+		snapshot = le.AppendUint32(snapshot, uint32(len(units))<<1)
+		snapshot = align(append(snapshot, chars...))
+	}
+	return snapshot
+}
+
+func TestHandleFileDartSnapshot(t *testing.T) {
+	long := "long-" + strings.Repeat("y", 300) + "-secret"
+	snapshot := buildTestDartSnapshot("dart-api-secret", "ab", "clé-🔑-secret", long, "dart-api-secret")
+
+	dynstr := []byte("\x00_kDartIsolateSnapshotData\x00")
+	dynsym := make([]byte, 24)
+	dynsym = binary.LittleEndian.AppendUint32(dynsym, 1)
+	dynsym = append(dynsym, byte(elf.STB_GLOBAL)<<4|byte(elf.STT_OBJECT), 0)
+	dynsym = binary.LittleEndian.AppendUint16(dynsym, 1)
+	dynsym = binary.LittleEndian.AppendUint64(dynsym, 0x2000)
+	dynsym = binary.LittleEndian.AppendUint64(dynsym, uint64(len(snapshot)))
+
+	lib := buildTestELFSections(t, []testELFSection{
+		{name: ".rodata", typ: elf.SHT_PROGBITS, addr: 0x2000, data: snapshot},
+		{name: ".dynstr", typ: elf.SHT_STRTAB, addr: 0x300, data: dynstr},
+		{name: ".dynsym", typ: elf.SHT_DYNSYM, addr: 0x200, data: dynsym, link: 2},
+	})
+
+	chunkCh := make(chan *sources.Chunk, 8)
+	err := HandleFile(context.Background(), bytes.NewReader(lib), &sources.Chunk{}, sources.ChanReporter{Ch: chunkCh})
+	require.NoError(t, err)
+	close(chunkCh)
+
+	var chunks []*sources.Chunk
+	for chunk := range chunkCh {
+		chunks = append(chunks, chunk)
+	}
+	require.Len(t, chunks, 1)
+	assert.Equal(t, "dart-api-secret\nclé-🔑-secret\n"+long+"\n", string(chunks[0].Data))
+	extraction := chunks[0].SourceMetadata.GetExtraction()
+	assert.Equal(t, dartStage, extraction.GetStage())
+	assert.Equal(t, testDartVersion, extraction.GetFormatVersion())
+}
+
+func TestDartLayoutForFeatures(t *testing.T) {
+	tests := map[string]struct {
+		features   string
+		wordSize64 bool
+		want       dartObjectLayout
+	}{
+		"arm64 with compressed pointers": {
+			features: "product arm64 android compressed-pointers null-safety",
+			want:     dartObjectLayout{alignment: 16, lengthOffset: 8, lengthSize: 4, dataOffset: 12},
+		},
+		"x64": {
+			features: "product x64-sysv null-safety",
+			want:     dartObjectLayout{alignment: 16, lengthOffset: 8, lengthSize: 8, dataOffset: 16},
+		},
+		"arm": {
+			features:   "product arm-eabi softfp",
+			wordSize64: true,
+			want:       dartObjectLayout{alignment: 8, lengthOffset: 4, lengthSize: 4, dataOffset: 12},
+		},
+		"unknown architecture": {
+			features:   "product",
+			wordSize64: true,
+			want:       dartObjectLayout{alignment: 16, lengthOffset: 8, lengthSize: 8, dataOffset: 16},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, dartLayoutForFeatures(tt.features, tt.wordSize64))
+		})
+	}
+}
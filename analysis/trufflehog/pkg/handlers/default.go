@@ -1,14 +1,20 @@
 package handlers
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io"
 	"time"
 
+	"google.golang.org/protobuf/proto"
+
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/fileclass"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/iobuf"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
 )
 
@@ -44,7 +50,7 @@ func (h *defaultHandler) HandleFile(ctx logContext.Context, input fileReader) ch
 		defer close(dataOrErrChan)
 
 		start := time.Now()
-		err := h.handleNonArchiveContent(ctx, newMimeTypeReaderFromFileReader(input), dataOrErrChan)
+		err := h.handleNonArchiveContent(ctx, newMimeTypeReaderFromFileReader(input), nil, dataOrErrChan)
 		if err == nil {
 			h.metrics.incFilesProcessed()
 		}
@@ -91,11 +97,62 @@ func (h *defaultHandler) measureLatencyAndHandleErrors(
 // on the type, particularly for binary files. It manages reading file chunks and writing them to the archive channel,
 // effectively collecting the final bytes for further processing. This function is a key component in ensuring that all
 // file content, regardless of being an archive or not, is handled appropriately.
+// Property lists are flattened into "key.path = value" text and the string literals of Hermes bytecode and IL2CPP
+// metadata are decoded before being chunked. Only the string sections of Mach-O and ELF files, and the strings of the
+// metadata of .NET assemblies, are scanned. Each source embedded in a source map is scanned on its own.
+// If metadata is not nil, it is attached to every chunk of the content.
 func (h *defaultHandler) handleNonArchiveContent(
 	ctx logContext.Context,
 	reader mimeTypeReader,
+	metadata *source_metadatapb.MetaData,
 	dataOrErrChan chan DataOrErr,
 ) error {
+	// Archive entries are classified by their detected MIME type, which their name may not tell.
+	if entryPath := metadata.GetExtraction().GetEntryPath(); entryPath != "" && metadata.GetFileClass() == "" {
+		metadata = withFileClass(metadata, fileclass.Classify(entryPath, string(reader.mimeName)))
+	}
+
+	// Encoded content is decoded to text first.
+	var decode func(
+		logContext.Context, mimeTypeReader, *source_metadatapb.MetaData,
+	) (mimeTypeReader, *source_metadatapb.MetaData, error)
+	switch {
+	case isPlistMime(reader.mimeName):
+		decode = decodePlistContent
+	case reader.mimeName == hermesMime:
+		decode = decodeHermesContent
+	case reader.mimeName == il2cppMime:
+		decode = decodeIL2CPPContent
+	}
+	if decode != nil {
+		var err error
+		if reader, metadata, err = decode(ctx, reader, metadata); err != nil {
+			return fmt.Errorf("%w: %v", ErrProcessingWarning, err)
+		}
+	}
+
+	var handleSections func(
+		logContext.Context, *iobuf.BufferedReadSeeker, *source_metadatapb.MetaData, chan DataOrErr,
+	) (bool, error)
+	switch reader.mimeName {
+	case machoMime:
+		handleSections = h.handleMachOContent
+	case elfMime, elfExeMime, elfLibMime:
+		handleSections = h.handleELFContent
+	case peMime, xalzMime, asmStoreMime:
+		handleSections = h.handleDotNetContent
+	case srcMapMime:
+		handleSections = h.handleSourceMapContent
+	}
+	if handleSections != nil {
+		rdr := iobuf.NewBufferedReaderSeeker(reader)
+		defer rdr.Close()
+		if parsed, err := handleSections(ctx, rdr, metadata, dataOrErrChan); parsed {
+			return err
+		}
+		reader.Reader = rdr
+	}
+
 	mimeExt := reader.mimeExt
 
 	if common.SkipFile(mimeExt) || common.IsBinary(mimeExt) {
@@ -106,6 +163,9 @@ func (h *defaultHandler) handleNonArchiveContent(
 		return nil
 	}
 
+	// Chunks of content extracted or decoded from a file record the line of the content they start on. Each chunk
+	// starts ChunkSize bytes after the previous one, the rest of its data being peeked from the next.
+	line := int64(1)
 	chunkReader := sources.NewChunkReader()
 	for data := range chunkReader(ctx, reader) {
 		dataOrErr := DataOrErr{}
@@ -119,6 +179,12 @@ func (h *defaultHandler) handleNonArchiveContent(
 		}
 
 		dataOrErr.Data = data.Bytes()
+		dataOrErr.Metadata = metadata
+		if metadata.GetExtraction() != nil {
+			dataOrErr.Metadata = proto.Clone(metadata).(*source_metadatapb.MetaData)
+			dataOrErr.Metadata.Extraction.Line = line
+			line += int64(bytes.Count(dataOrErr.Data[:min(len(dataOrErr.Data), sources.ChunkSize)], []byte("\n")))
+		}
 		if err := common.CancellableWrite(ctx, dataOrErrChan, dataOrErr); err != nil {
 			return err
 		}
@@ -0,0 +1,356 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	dextk "github.com/csnewman/dextk"
+
+	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/feature"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+)
+
+// By default, only the const-string operands of the dex classes mentioning a detector keyword are scanned, which
+// misses secrets stored under innocuous names, in the initial values of static fields or in annotations. With the
+// dex string pool mode enabled, every string of a dex file is scanned instead. The strings used by each class are
+// written on "symbol = value" lines attributing them to the method loading them, the static field they initialize,
+// or the class, field or method they annotate, so the engine records the symbol of each result as its key path.
+// The strings no class uses, such as type and member names, follow on their own lines.
+
+const (
+	// dexStage is the extraction stage of chunks decoded from dex files.
+	dexStage = "dex"
+	// dexStringPoolStage is the extraction stage of the strings of dex files decoded in the dex string pool mode.
+	dexStringPoolStage = "dexstrings"
+)
+
+// maxDexEncodedValueDepth is the deepest nesting of arrays and annotations within an encoded value that is decoded.
+const maxDexEncodedValueDepth = 32
+
+// Types of the encoded values of static field initializers and annotation elements.
+const (
+	dexValueString     = 0x17
+	dexValueArray      = 0x1c
+	dexValueAnnotation = 0x1d
+	dexValueNull       = 0x1e
+	dexValueBoolean    = 0x1f
+)
+
+// decodeDexFile decodes a dex file into the text that is scanned, and returns the metadata of its chunks.
+func (h *apkHandler) decodeDexFile(
+	ctx logContext.Context,
+	rdr io.ReaderAt,
+	metadata *source_metadatapb.MetaData,
+) (io.Reader, *source_metadatapb.MetaData, error) {
+	if feature.EnableDexStringPool.Load() {
+		out, err := extractDexStrings(ctx, rdr)
+		if err != nil {
+			return nil, metadata, err
+		}
+		return out, withExtractionStage(metadata, dexStringPoolStage), nil
+	}
+	out, err := h.processDexFile(ctx, rdr)
+	if err != nil {
+		return nil, metadata, err
+	}
+	return out, withExtractionStage(metadata, dexStage), nil
+}
+
+// extractDexStrings returns every string of a dex file, those used by its classes first, attributed to the symbol
+// using them.
+func extractDexStrings(ctx logContext.Context, rdr io.ReaderAt) (io.Reader, error) {
+	// The encoded values and annotations are read byte by byte, so the file is read into memory.
+	data, err := io.ReadAll(io.NewSectionReader(rdr, 0, 1<<63-1))
+	if err != nil {
+		return nil, err
+	}
+	dexReader, err := dextk.Read(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	w := &dexStringWriter{reader: dexReader, used: make([]bool, dexReader.StringIDCount)}
+	for id := uint32(0); id < dexReader.ClassDefCount; id++ {
+		if err := w.writeClass(id, data); err != nil {
+			ctx.Logger().V(2).Info("failed to process dex class", "error", err)
+		}
+	}
+
+	for id := uint32(0); id < dexReader.StringIDCount; id++ {
+		if w.used[id] {
+			continue
+		}
+		str, err := dexReader.ReadString(id)
+		if err != nil {
+			ctx.Logger().V(2).Info("failed to read dex string", "error", err)
+			continue
+		}
+		if str.Parsed != "" {
+			w.out.WriteString(str.Parsed)
+			w.out.WriteByte('\n')
+		}
+	}
+	return &w.out, nil
+}
+
+// dexStringWriter writes the strings of a dex file on "symbol = value" lines.
+type dexStringWriter struct {
+	reader *dextk.Reader
+	// used records the strings written with a symbol.
+	used []bool
+	// written records the lines written for the current class, so a string used repeatedly is written once.
+	written map[dexStringUse]struct{}
+	out     bytes.Buffer
+}
+
+type dexStringUse struct {
+	symbol string
+	id     uint32
+}
+
+func (w *dexStringWriter) write(symbol string, id uint32) {
+	use := dexStringUse{symbol: symbol, id: id}
+	if _, ok := w.written[use]; ok || id >= uint32(len(w.used)) {
+		return
+	}
+	w.written[use] = struct{}{}
+
+	str, err := w.reader.ReadString(id)
+	if err != nil || str.Parsed == "" {
+		return
+	}
+	w.used[id] = true
+	w.out.WriteString(symbol)
+	w.out.WriteString(" = ")
+	w.out.WriteString(str.Parsed)
+	w.out.WriteByte('\n')
+}
+
+// writeClass writes the strings loaded by the methods of a class, the initial values of its static fields and the
+// values of its annotations.
+func (w *dexStringWriter) writeClass(id uint32, data []byte) error {
+	def, err := w.reader.ReadClassDef(id)
+	if err != nil {
+		return err
+	}
+	node, err := w.reader.ReadClassAndParse(id)
+	if err != nil {
+		return err
+	}
+	class := strings.ReplaceAll(node.Name.String(), "/", ".")
+	w.written = make(map[dexStringUse]struct{})
+
+	for _, method := range append(node.DirectMethods, node.VirtualMethods...) {
+		if method.CodeOff == 0 {
+			continue
+		}
+		if err := w.writeMethodStrings(class+"."+method.Name.String(), method.CodeOff); err != nil {
+			return fmt.Errorf("method %s.%s: %w", class, method.Name, err)
+		}
+	}
+
+	// The initial values of the static fields are stored in the order of the fields. Trailing fields initialized
+	// to their default value are omitted.
+	if def.StaticValuesOff != 0 {
+		c := &dexCursor{data: data, pos: int(def.StaticValuesOff)}
+		size := c.uleb128()
+		for i := 0; i < int(size) && c.err == nil; i++ {
+			symbol := class
+			if i < len(node.StaticFields) {
+				symbol += "." + node.StaticFields[i].Name.String()
+			}
+			c.encodedValue(func(id uint32) { w.write(symbol, id) }, 0)
+		}
+		if c.err != nil {
+			return fmt.Errorf("static values of %s: %w", class, c.err)
+		}
+	}
+
+	if def.AnnotationsDirectoryOff != 0 {
+		if err := w.writeAnnotationStrings(class, node, def.AnnotationsDirectoryOff, data); err != nil {
+			return fmt.Errorf("annotations of %s: %w", class, err)
+		}
+	}
+	return nil
+}
+
+// writeMethodStrings writes the operands of the const-string instructions of a method.
+func (w *dexStringWriter) writeMethodStrings(symbol string, codeOff uint32) error {
+	code, err := w.reader.ReadCode(codeOff)
+	if err != nil {
+		return err
+	}
+	ops := dextk.NewOpReader(code.Insns)
+	for ops.HasMore() {
+		op, err := ops.Read()
+		if err != nil {
+			return err
+		}
+		switch o := op.(type) {
+		case dextk.OpConstString:
+			w.write(symbol, uint32(o.B))
+		case dextk.OpConstStringJumbo:
+			w.write(symbol, o.B)
+		}
+	}
+	return nil
+}
+
+// writeAnnotationStrings writes the string values of the annotations of a class, of its fields and of its methods
+// and their parameters, read from its annotations_directory_item.
+func (w *dexStringWriter) writeAnnotationStrings(class string, node dextk.ClassNode, off uint32, data []byte) error {
+	members := make(map[uint32]string)
+	for _, fields := range [][]dextk.FieldNode{node.StaticFields, node.InstanceFields} {
+		for _, field := range fields {
+			members[field.Id] = field.Name.String()
+		}
+	}
+	methods := make(map[uint32]string)
+	for _, method := range append(node.DirectMethods, node.VirtualMethods...) {
+		methods[method.Id] = method.Name.String()
+	}
+	memberSymbol := func(names map[uint32]string, id uint32) string {
+		if name, ok := names[id]; ok {
+			return class + "." + name
+		}
+		return class
+	}
+
+	c := &dexCursor{data: data, pos: int(off)}
+	classAnnotations := c.uint32()
+	fieldsSize, methodsSize, paramsSize := c.uint32(), c.uint32(), c.uint32()
+	w.writeAnnotationSet(class, classAnnotations, data)
+
+	for i := uint32(0); i < fieldsSize && c.err == nil; i++ {
+		field, annotations := c.uint32(), c.uint32()
+		w.writeAnnotationSet(memberSymbol(members, field), annotations, data)
+	}
+	for i := uint32(0); i < methodsSize && c.err == nil; i++ {
+		method, annotations := c.uint32(), c.uint32()
+		w.writeAnnotationSet(memberSymbol(methods, method), annotations, data)
+	}
+	for i := uint32(0); i < paramsSize && c.err == nil; i++ {
+		method, refList := c.uint32(), c.uint32()
+		if refList == 0 {
+			continue
+		}
+		// An annotation_set_ref_list holds the annotation set of each parameter.
+		refs := &dexCursor{data: data, pos: int(refList)}
+		size := refs.uint32()
+		for j := uint32(0); j < size && refs.err == nil; j++ {
+			w.writeAnnotationSet(memberSymbol(methods, method), refs.uint32(), data)
+		}
+	}
+	return c.err
+}
+
+// writeAnnotationSet writes the string values of the annotations of an annotation_set_item.
+func (w *dexStringWriter) writeAnnotationSet(symbol string, off uint32, data []byte) {
+	if off == 0 {
+		return
+	}
+	set := &dexCursor{data: data, pos: int(off)}
+	size := set.uint32()
+	for i := uint32(0); i < size && set.err == nil; i++ {
+		// An annotation_item is a visibility byte followed by an encoded_annotation.
+		item := &dexCursor{data: data, pos: int(set.uint32())}
+		item.byte()
+		item.encodedAnnotation(func(id uint32) { w.write(symbol, id) }, 0)
+	}
+}
+
+var errDexTruncated = errors.New("truncated dex data")
+
+// dexCursor reads the variable length structures of a dex file.
+type dexCursor struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+func (c *dexCursor) byte() byte {
+	if c.err != nil || c.pos < 0 || c.pos >= len(c.data) {
+		c.err = errDexTruncated
+		return 0
+	}
+	b := c.data[c.pos]
+	c.pos++
+	return b
+}
+
+func (c *dexCursor) uint32() uint32 {
+	if c.err != nil || c.pos < 0 || c.pos+4 > len(c.data) {
+		c.err = errDexTruncated
+		return 0
+	}
+	v := binary.LittleEndian.Uint32(c.data[c.pos:])
+	c.pos += 4
+	return v
+}
+
+func (c *dexCursor) uleb128() uint32 {
+	var v uint32
+	for shift := 0; shift < 35; shift += 7 {
+		b := c.byte()
+		v |= uint32(b&0x7f) << shift
+		if b&0x80 == 0 {
+			break
+		}
+	}
+	return v
+}
+
+// uintN reads a little endian unsigned integer of n bytes.
+func (c *dexCursor) uintN(n int) uint32 {
+	var v uint32
+	for i := 0; i < n; i++ {
+		b := c.byte()
+		if i < 4 {
+			v |= uint32(b) << (8 * i)
+		}
+	}
+	return v
+}
+
+// encodedValue reads an encoded_value, calling fn with the index of each string it holds.
+func (c *dexCursor) encodedValue(fn func(uint32), depth int) {
+	if depth > maxDexEncodedValueDepth {
+		c.err = errors.New("dex encoded value is nested too deeply")
+		return
+	}
+	header := c.byte()
+	typ, arg := header&0x1f, int(header>>5)
+	switch typ {
+	case dexValueString:
+		id := c.uintN(arg + 1)
+		if c.err == nil {
+			fn(id)
+		}
+	case dexValueArray:
+		size := c.uleb128()
+		for i := uint32(0); i < size && c.err == nil; i++ {
+			c.encodedValue(fn, depth+1)
+		}
+	case dexValueAnnotation:
+		c.encodedAnnotation(fn, depth+1)
+	case dexValueNull, dexValueBoolean:
+		// The value, if any, is held by the argument.
+	default:
+		c.uintN(arg + 1)
+	}
+}
+
+// encodedAnnotation reads an encoded_annotation, calling fn with the index of each string its elements hold.
+func (c *dexCursor) encodedAnnotation(fn func(uint32), depth int) {
+	c.uleb128() // type_idx
+	size := c.uleb128()
+	for i := uint32(0); i < size && c.err == nil; i++ {
+		c.uleb128() // name_idx
+		c.encodedValue(fn, depth)
+	}
+}
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/feature"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+)
+
+var testDexStrings = []string{
+	"Lcom/example/Keys;",
+	"Ljava/lang/Object;",
+	"Ljava/lang/String;",
+	"V",
+	"Lcom/example/Config;",
+	"load",
+	"API_KEY",
+	"value",
+	"method-secret",
+	"static-secret",
+	"annotation-secret",
+	"unused-secret",
+	"field-annotation-secret",
+}
+
+// buildTestDex builds a dex file with a single class, com.example.Keys, whose static method load() loads a string,
+// whose static field API_KEY is initialized to a string, and which is annotated along with its field.
+func buildTestDex(t *testing.T) []byte {
+	t.Helper()
+
+	const (
+		headerSize = 0x70
+		typeCount  = 5
+		dataOff    = headerSize + 4*13 + 4*typeCount + 12 + 8 + 8 + 32
+	)
+	le := binary.LittleEndian
+
+	// The data section, whose offsets are relative to the start of the file.
+	var data []byte
+	align := func() {
+		for len(data)%4 != 0 {
+			data = append(data, 0)
+		}
+	}
+	offset := func() uint32 { return uint32(dataOff + len(data)) }
+
+	var stringOffs []uint32
+	for _, s := range testDexStrings {
+		stringOffs = append(stringOffs, offset())
+		data = append(data, byte(len(s)))
+		data = append(data, s+"\x00"...)
+	}
+
+	align()
+	codeOff := offset()
+	// const-string v0, "method-secret"; return-void
+	for _, v := range []uint16{1, 0, 0, 0} {
+		data = le.AppendUint16(data, v)
+	}
+	data = le.AppendUint32(data, 0)
+	data = le.AppendUint32(data, 3)
+	for _, v := range []uint16{0x001a, 8, 0x000e} {
+		data = le.AppendUint16(data, v)
+	}
+
+	classDataOff := offset()
+	// One static field and one direct method, with the method's access flags and code offset.
+	data = append(data, 1, 0, 1, 0, 0, 0x19, 0, 0x09)
+	data = binary.AppendUvarint(data, uint64(codeOff))
+
+	staticValuesOff := offset()
+	data = append(data, 1, 0x17, 9)
+
+	classItemOff := offset()
+	data = append(data, 1, 4, 1, 7, 0x17, 10)
+	// An array of strings.
+	fieldItemOff := offset()
+	data = append(data, 1, 4, 1, 7, 0x1c, 1, 0x17, 12)
+
+	align()
+	classSetOff := offset()
+	data = le.AppendUint32(data, 1)
+	data = le.AppendUint32(data, classItemOff)
+	fieldSetOff := offset()
+	data = le.AppendUint32(data, 1)
+	data = le.AppendUint32(data, fieldItemOff)
+
+	annotationsOff := offset()
+	for _, v := range []uint32{classSetOff, 1, 0, 0, 0, fieldSetOff} {
+		data = le.AppendUint32(data, v)
+	}
+
+	var ids []byte
+	for _, off := range stringOffs {
+		ids = le.AppendUint32(ids, off)
+	}
+	for i := uint32(0); i < typeCount; i++ {
+		ids = le.AppendUint32(ids, i)
+	}
+	// The proto of ()V, the field API_KEY and the method load.
+	for _, v := range []uint32{3, 3, 0} {
+		ids = le.AppendUint32(ids, v)
+	}
+	ids = append(ids, 0, 0, 2, 0, 6, 0, 0, 0)
+	ids = append(ids, 0, 0, 0, 0, 5, 0, 0, 0)
+	for _, v := range []uint32{0, 1, 1, 0, 0xffffffff, annotationsOff, classDataOff, staticValuesOff} {
+		ids = le.AppendUint32(ids, v)
+	}
+	require.Equal(t, dataOff-headerSize, len(ids))
+
+	header := make([]byte, headerSize)
+	copy(header, "dex\n035\x00")
+	fileSize := uint32(dataOff + len(data))
+	for off, v := range map[int]uint32{
+		32: fileSize, 36: headerSize, 40: 0x12345678,
+		56: uint32(len(testDexStrings)), 60: headerSize,
+		64: typeCount, 68: headerSize + 4*13,
+		72: 1, 76: headerSize + 4*13 + 4*typeCount,
+		80: 1, 84: headerSize + 4*13 + 4*typeCount + 12,
+		88: 1, 92: headerSize + 4*13 + 4*typeCount + 20,
+		96: 1, 100: headerSize + 4*13 + 4*typeCount + 28,
+		104: uint32(len(data)), 108: dataOff,
+	} {
+		le.PutUint32(header[off:], v)
+	}
+
+	return append(append(header, ids...), data...)
+}
+
+func TestDecodeDexFile_StringPool(t *testing.T) {
+	feature.EnableDexStringPool.Store(true)
+	t.Cleanup(func() { feature.EnableDexStringPool.Store(false) })
+
+	metadata := &source_metadatapb.MetaData{AppBundle: &source_metadatapb.AppBundle{BundlePath: "classes.dex"}}
+	out, decoded, err := newAPKHandler().decodeDexFile(context.Background(), bytes.NewReader(buildTestDex(t)), metadata)
+	require.NoError(t, err)
+
+	text, err := io.ReadAll(out)
+	require.NoError(t, err)
+	assert.Equal(t, "com.example.Keys.load = method-secret\n"+
+		"com.example.Keys.API_KEY = static-secret\n"+
+		"com.example.Keys = annotation-secret\n"+
+		"com.example.Keys.API_KEY = field-annotation-secret\n"+
+		"Lcom/example/Keys;\nLjava/lang/Object;\nLjava/lang/String;\nV\nLcom/example/Config;\n"+
+		"load\nAPI_KEY\nvalue\nunused-secret\n",
+		string(text))
+
+	assert.Equal(t, dexStringPoolStage, decoded.GetExtraction().GetStage())
+	assert.Equal(t, "classes.dex", decoded.GetAppBundle().GetBundlePath())
+	// The metadata of the file is shared, so it must not be modified.
+	assert.Nil(t, metadata.GetExtraction())
+}
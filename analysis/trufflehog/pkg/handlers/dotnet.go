@@ -0,0 +1,695 @@
+package handlers
+
+import (
+	"bytes"
+	"debug/pe"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"unicode/utf16"
+
+	"github.com/gabriel-vasile/mimetype"
+
+	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/iobuf"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+)
+
+// Xamarin and .NET MAUI apps ship their code as .NET assemblies. iOS apps keep them as plain DLLs in the app
+// bundle, while Android apps pack them into an assembly store, assemblies.blob or, since .NET 9, the payload
+// section of libassemblies.<abi>.blob.so, or into per-assembly files compressed with LZ4 behind an XALZ header.
+//
+// The string literals of an assembly are in the #US (user strings) heap of its CLI metadata, as UTF-16, and the
+// values of its constant string fields in the Constant table. The constant fields are written on
+// "Namespace.Type.Field = value" lines, so the engine records the field of each result as its key path, followed by
+// the user strings on their own lines. Each assembly of a store is scanned on its own, with its module name
+// appended to the entry path.
+
+const (
+	// dotnetStage is the extraction stage of chunks decoded from .NET assemblies.
+	dotnetStage = "dotnet"
+
+	xalzMagic          = "XALZ"
+	assemblyStoreMagic = "XABA"
+	// xalzHeaderSize is the size of the header of a compressed assembly: its magic, its index in the app's
+	// assembly list and its uncompressed size.
+	xalzHeaderSize = 12
+
+	// assemblyStorePayloadSection is the ELF section holding the assembly store of libassemblies.<abi>.blob.so.
+	assemblyStorePayloadSection = "payload"
+
+	cliMetadataMagic = 0x424a5342 // BSJB
+	// cliHeaderDirectory is the index of the CLI header in the data directories of a PE file.
+	cliHeaderDirectory = 14
+	// elementTypeString is the type of constants holding a string.
+	elementTypeString = 0x0e
+
+	// maxDotNetSize is the largest assembly or assembly store that is decoded. Larger ones are scanned as is.
+	maxDotNetSize = 256 << 20 // 256 MB
+)
+
+func init() {
+	mimetype.Lookup("application/octet-stream").Extend(isXALZAssembly, string(xalzMime), ".dll")
+	mimetype.Lookup("application/octet-stream").Extend(isAssemblyStore, string(asmStoreMime), ".blob")
+}
+
+func isXALZAssembly(raw []byte, _ uint32) bool { return bytes.HasPrefix(raw, []byte(xalzMagic)) }
+
+func isAssemblyStore(raw []byte, _ uint32) bool {
+	return bytes.HasPrefix(raw, []byte(assemblyStoreMagic))
+}
+
+// handleDotNetContent scans the assemblies of a DLL, a compressed assembly or an assembly store.
+// It returns false, with rdr rewound, if no .NET assembly could be decoded from the content, such as for native PE
+// executables.
+func (h *defaultHandler) handleDotNetContent(
+	ctx logContext.Context,
+	rdr *iobuf.BufferedReadSeeker,
+	metadata *source_metadatapb.MetaData,
+	dataOrErrChan chan DataOrErr,
+) (bool, error) {
+	data, err := io.ReadAll(io.LimitReader(rdr, maxDotNetSize+1))
+	if err != nil {
+		return true, fmt.Errorf("%w: error reading .NET assembly: %v", ErrProcessingWarning, err)
+	}
+
+	var parsed bool
+	if len(data) <= maxDotNetSize {
+		parsed, err = h.handleDotNetData(ctx, data, metadata, dataOrErrChan)
+	} else {
+		ctx.Logger().V(3).Info(".NET assembly exceeds max size, scanning as is", "limit", maxDotNetSize)
+	}
+	if parsed {
+		return true, err
+	}
+
+	if _, err := rdr.Seek(0, io.SeekStart); err != nil {
+		return true, fmt.Errorf("%w: error resetting .NET assembly reader: %v", ErrProcessingWarning, err)
+	}
+	return false, nil
+}
+
+// handleDotNetData scans the assemblies of data, returning false if it holds none.
+func (h *defaultHandler) handleDotNetData(
+	ctx logContext.Context,
+	data []byte,
+	metadata *source_metadatapb.MetaData,
+	dataOrErrChan chan DataOrErr,
+) (bool, error) {
+	if bytes.HasPrefix(data, []byte(assemblyStoreMagic)) {
+		return h.handleAssemblyStore(ctx, data, metadata, dataOrErrChan)
+	}
+
+	if bytes.HasPrefix(data, []byte(xalzMagic)) {
+		var err error
+		if data, _, err = decompressXALZ(data); err != nil {
+			ctx.Logger().V(3).Info("failed to decompress XALZ assembly", "error", err)
+			return false, nil
+		}
+	}
+	asm, err := parseCLIAssembly(data)
+	if err != nil {
+		ctx.Logger().V(3).Info("failed to parse .NET assembly", "error", err)
+		return false, nil
+	}
+	return true, h.writeDotNetAssembly(ctx, asm, withExtractionStage(metadata, dotnetStage), dataOrErrChan)
+}
+
+// handleAssemblyStore scans the assemblies of an assembly store. The layout of the store's index differs between
+// versions, so the assemblies are found by their signature, the XALZ header of compressed assemblies or the DOS
+// header of uncompressed ones, which are laid out one after the other.
+func (h *defaultHandler) handleAssemblyStore(
+	ctx logContext.Context,
+	data []byte,
+	metadata *source_metadatapb.MetaData,
+	dataOrErrChan chan DataOrErr,
+) (bool, error) {
+	var found bool
+	for off := len(assemblyStoreMagic); off < len(data); {
+		next := nextAssemblySignature(data, off)
+		if next < 0 {
+			break
+		}
+
+		asmData, size := data[next:], 0
+		if bytes.HasPrefix(asmData, []byte(xalzMagic)) {
+			var err error
+			if asmData, size, err = decompressXALZ(asmData); err != nil {
+				off = next + 1
+				continue
+			}
+		}
+		asm, err := parseCLIAssembly(asmData)
+		if err != nil {
+			off = next + 1
+			continue
+		}
+		if size == 0 {
+			size = asm.fileSize
+		}
+		off = next + max(size, 1)
+
+		found = true
+		asmMetadata := withExtractionStage(metadata, dotnetStage)
+		asmMetadata.Extraction.EntryPath = path.Join(asmMetadata.Extraction.EntryPath, asm.name)
+		if err := h.writeDotNetAssembly(ctx, asm, asmMetadata, dataOrErrChan); err != nil {
+			return true, err
+		}
+	}
+	if !found {
+		ctx.Logger().V(3).Info("no assemblies found in assembly store")
+	}
+	return found, nil
+}
+
+// nextAssemblySignature returns the offset of the first XALZ header or DOS header at or after off, or -1.
+func nextAssemblySignature(data []byte, off int) int {
+	next := -1
+	for _, sig := range []string{xalzMagic, "MZ"} {
+		if i := bytes.Index(data[off:], []byte(sig)); i >= 0 && (next < 0 || off+i < next) {
+			next = off + i
+		}
+	}
+	return next
+}
+
+// writeDotNetAssembly writes the chunks of the strings of an assembly.
+func (h *defaultHandler) writeDotNetAssembly(
+	ctx logContext.Context,
+	asm *cliAssembly,
+	metadata *source_metadatapb.MetaData,
+	dataOrErrChan chan DataOrErr,
+) error {
+	reader := mimeTypeReader{mimeExt: ".txt", mimeName: textMime, Reader: bytes.NewReader(asm.strings)}
+	return h.handleNonArchiveContent(logContext.WithValues(ctx, "assembly", asm.name), reader, metadata, dataOrErrChan)
+}
+
+// decompressXALZ decompresses a compressed assembly, returning it along with the number of bytes of data it was
+// decompressed from.
+func decompressXALZ(data []byte) ([]byte, int, error) {
+	if len(data) < xalzHeaderSize || !bytes.HasPrefix(data, []byte(xalzMagic)) {
+		return nil, 0, errors.New("missing XALZ header")
+	}
+	size := binary.LittleEndian.Uint32(data[8:])
+	if size > maxDotNetSize {
+		return nil, 0, fmt.Errorf("XALZ assembly exceeds max size %d", maxDotNetSize)
+	}
+	out, n, err := decodeLZ4Block(data[xalzHeaderSize:], int(size))
+	if err != nil {
+		return nil, 0, err
+	}
+	return out, xalzHeaderSize + n, nil
+}
+
+var errLZ4Corrupt = errors.New("corrupt LZ4 block")
+
+// decodeLZ4Block decodes an LZ4 block of size bytes, returning it along with the number of bytes of src it was
+// decoded from. Decoding stops once size bytes are decoded, so src may hold data following the block.
+func decodeLZ4Block(src []byte, size int) ([]byte, int, error) {
+	// readLength reads the extension bytes of a literal or match length whose 4 bit field is saturated.
+	i := 0
+	readLength := func(n int) (int, bool) {
+		for {
+			if i >= len(src) {
+				return 0, false
+			}
+			b := src[i]
+			i++
+			n += int(b)
+			if b != 0xff {
+				return n, true
+			}
+		}
+	}
+
+	dst := make([]byte, 0, size)
+	for len(dst) < size {
+		if i >= len(src) {
+			return nil, 0, errLZ4Corrupt
+		}
+		token := src[i]
+		i++
+
+		literals, ok := int(token>>4), true
+		if literals == 0xf {
+			if literals, ok = readLength(literals); !ok {
+				return nil, 0, errLZ4Corrupt
+			}
+		}
+		if literals > len(src)-i || literals > size-len(dst) {
+			return nil, 0, errLZ4Corrupt
+		}
+		dst = append(dst, src[i:i+literals]...)
+		i += literals
+		// The last sequence of a block only has literals.
+		if len(dst) == size {
+			break
+		}
+
+		if i+2 > len(src) {
+			return nil, 0, errLZ4Corrupt
+		}
+		offset := int(binary.LittleEndian.Uint16(src[i:]))
+		i += 2
+		match := int(token&0xf) + 4
+		if token&0xf == 0xf {
+			if match, ok = readLength(match); !ok {
+				return nil, 0, errLZ4Corrupt
+			}
+		}
+		if offset == 0 || offset > len(dst) || match > size-len(dst) {
+			return nil, 0, errLZ4Corrupt
+		}
+		// Matches may overlap the bytes they copy, so they are copied byte by byte.
+		for start := len(dst) - offset; match > 0; match-- {
+			dst = append(dst, dst[start])
+			start++
+		}
+	}
+	return dst, i, nil
+}
+
+// cliAssembly is the decoded text of an assembly.
+type cliAssembly struct {
+	// name is the name of the assembly's module, such as "App.dll".
+	name string
+	// strings are the constant string fields and the user strings of the assembly, one per line.
+	strings []byte
+	// fileSize is the size of the PE file, the end of its last section.
+	fileSize int
+}
+
+var errNotCLIAssembly = errors.New("not a .NET assembly")
+
+// parseCLIAssembly decodes the strings of the CLI metadata of a PE file.
+func parseCLIAssembly(data []byte) (*cliAssembly, error) {
+	f, err := pe.NewFile(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var dir pe.DataDirectory
+	switch oh := f.OptionalHeader.(type) {
+	case *pe.OptionalHeader32:
+		if oh.NumberOfRvaAndSizes > cliHeaderDirectory {
+			dir = oh.DataDirectory[cliHeaderDirectory]
+		}
+	case *pe.OptionalHeader64:
+		if oh.NumberOfRvaAndSizes > cliHeaderDirectory {
+			dir = oh.DataDirectory[cliHeaderDirectory]
+		}
+	}
+	if dir.VirtualAddress == 0 {
+		return nil, errNotCLIAssembly
+	}
+
+	img := peImage{file: f, data: data}
+	// The CLI header holds the RVA and size of the metadata at offset 8.
+	cliHeader, err := img.rva(dir.VirtualAddress, 16)
+	if err != nil {
+		return nil, fmt.Errorf("CLI header: %w", err)
+	}
+	root, err := img.rva(binary.LittleEndian.Uint32(cliHeader[8:]), binary.LittleEndian.Uint32(cliHeader[12:]))
+	if err != nil {
+		return nil, fmt.Errorf("CLI metadata: %w", err)
+	}
+	md, err := parseCLIMetadata(root)
+	if err != nil {
+		return nil, err
+	}
+
+	asm := &cliAssembly{strings: md.text()}
+	asm.name, _ = md.moduleName()
+	for _, sec := range f.Sections {
+		asm.fileSize = max(asm.fileSize, int(sec.Offset+sec.Size))
+	}
+	return asm, nil
+}
+
+// peImage maps the RVAs of a PE file to its data.
+type peImage struct {
+	file *pe.File
+	data []byte
+}
+
+// rva returns the size bytes at a relative virtual address.
+func (img peImage) rva(rva, size uint32) ([]byte, error) {
+	for _, sec := range img.file.Sections {
+		if rva < sec.VirtualAddress || rva-sec.VirtualAddress >= max(sec.VirtualSize, sec.Size) {
+			continue
+		}
+		off := uint64(sec.Offset) + uint64(rva-sec.VirtualAddress)
+		if off+uint64(size) > uint64(len(img.data)) {
+			return nil, errors.New("RVA out of bounds")
+		}
+		return img.data[off : off+uint64(size)], nil
+	}
+	return nil, fmt.Errorf("RVA %#x isn't in a section", rva)
+}
+
+// Tables of the #~ stream.
+const (
+	cliTableModule        = 0x00
+	cliTableTypeRef       = 0x01
+	cliTableTypeDef       = 0x02
+	cliTableFieldPtr      = 0x03
+	cliTableField         = 0x04
+	cliTableMethodPtr     = 0x05
+	cliTableMethodDef     = 0x06
+	cliTableParamPtr      = 0x07
+	cliTableParam         = 0x08
+	cliTableInterfaceImpl = 0x09
+	cliTableMemberRef     = 0x0a
+	cliTableConstant      = 0x0b
+	cliTableProperty      = 0x17
+	cliTableModuleRef     = 0x1a
+	cliTableTypeSpec      = 0x1b
+	cliTableAssemblyRef   = 0x23
+)
+
+// cliMetadata holds the streams of the CLI metadata of an assembly.
+type cliMetadata struct {
+	stringHeap []byte
+	userHeap   []byte
+	blobHeap   []byte
+	tables     *cliTables
+}
+
+// parseCLIMetadata parses the metadata root and its stream headers.
+func parseCLIMetadata(root []byte) (*cliMetadata, error) {
+	c := &cliReader{data: root}
+	if c.uint32() != cliMetadataMagic {
+		return nil, errNotCLIAssembly
+	}
+	c.pos += 8 // major and minor version, reserved
+	c.pos += int(c.uint32())
+	c.pos += 2 // flags
+	streams := int(c.uint16())
+
+	md := &cliMetadata{}
+	for i := 0; i < streams && c.err == nil; i++ {
+		off, size := c.uint32(), c.uint32()
+		name := c.cString()
+		// Stream names are padded to 4 bytes.
+		c.pos = (c.pos + 3) &^ 3
+		if uint64(off)+uint64(size) > uint64(len(root)) {
+			return nil, fmt.Errorf("stream %s out of bounds", name)
+		}
+		stream := root[off : off+size]
+		switch name {
+		case "#Strings":
+			md.stringHeap = stream
+		case "#US":
+			md.userHeap = stream
+		case "#Blob":
+			md.blobHeap = stream
+		case "#~", "#-":
+			md.tables = &cliTables{data: stream}
+		}
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+	if md.tables != nil {
+		if err := md.tables.parse(); err != nil {
+			return nil, fmt.Errorf("metadata tables: %w", err)
+		}
+	}
+	return md, nil
+}
+
+// text returns the constant string fields of the assembly, as "Namespace.Type.Field = value" lines, followed by its
+// user strings, one per line.
+func (md *cliMetadata) text() []byte {
+	var out bytes.Buffer
+	if md.tables != nil {
+		md.writeConstantFields(&out)
+	}
+
+	// Each user string is a blob of UTF-16 characters followed by a byte flagging special characters.
+	c := &cliReader{data: md.userHeap, pos: 1}
+	for c.pos < len(md.userHeap) && c.err == nil {
+		blob := c.blob()
+		if len(blob) < 2 {
+			continue
+		}
+		out.WriteString(decodeUTF16LE(blob[:len(blob)-1]))
+		out.WriteByte('\n')
+	}
+	return out.Bytes()
+}
+
+func (md *cliMetadata) writeConstantFields(out *bytes.Buffer) {
+	t := md.tables
+	// The fields of a type are the run starting at its field list, up to the field list of the next type.
+	owners := make([]uint32, t.rows[cliTableField]+1)
+	for row := uint32(1); row <= t.rows[cliTableTypeDef]; row++ {
+		first := t.column(cliTableTypeDef, row, 4)
+		last := t.rows[cliTableField] + 1
+		if row < t.rows[cliTableTypeDef] {
+			last = t.column(cliTableTypeDef, row+1, 4)
+		}
+		for field := first; field < last && field < uint32(len(owners)); field++ {
+			owners[field] = row
+		}
+	}
+
+	for row := uint32(1); row <= t.rows[cliTableConstant]; row++ {
+		if t.column(cliTableConstant, row, 0) != elementTypeString {
+			continue
+		}
+		// The parent is a HasConstant coded index, whose 2 bit tag is 0 for fields.
+		parent := t.column(cliTableConstant, row, 2)
+		field := parent >> 2
+		if parent&3 != 0 || field == 0 || field > t.rows[cliTableField] {
+			continue
+		}
+		value := md.blob(t.column(cliTableConstant, row, 3))
+		if value == nil {
+			continue
+		}
+
+		symbol := md.string(t.column(cliTableField, field, 1))
+		if owner := owners[field]; owner != 0 {
+			typeName := md.string(t.column(cliTableTypeDef, owner, 1))
+			if ns := md.string(t.column(cliTableTypeDef, owner, 2)); ns != "" {
+				typeName = ns + "." + typeName
+			}
+			symbol = typeName + "." + symbol
+		}
+		out.WriteString(symbol)
+		out.WriteString(" = ")
+		out.WriteString(decodeUTF16LE(value))
+		out.WriteByte('\n')
+	}
+}
+
+// moduleName returns the name of the assembly's module.
+func (md *cliMetadata) moduleName() (string, bool) {
+	if md.tables == nil || md.tables.rows[cliTableModule] == 0 {
+		return "", false
+	}
+	return md.string(md.tables.column(cliTableModule, 1, 1)), true
+}
+
+func (md *cliMetadata) string(idx uint32) string {
+	if idx >= uint32(len(md.stringHeap)) {
+		return ""
+	}
+	str := md.stringHeap[idx:]
+	if end := bytes.IndexByte(str, 0); end >= 0 {
+		str = str[:end]
+	}
+	return string(str)
+}
+
+func (md *cliMetadata) blob(idx uint32) []byte {
+	if idx == 0 || idx >= uint32(len(md.blobHeap)) {
+		return nil
+	}
+	c := &cliReader{data: md.blobHeap, pos: int(idx)}
+	blob := c.blob()
+	if c.err != nil {
+		return nil
+	}
+	return blob
+}
+
+func decodeUTF16LE(b []byte) string {
+	units := make([]uint16, len(b)/2)
+	for i := range units {
+		units[i] = binary.LittleEndian.Uint16(b[2*i:])
+	}
+	return string(utf16.Decode(units))
+}
+
+// cliTables is the #~ stream, holding the metadata tables.
+type cliTables struct {
+	data []byte
+	rows [64]uint32
+	// offsets and rowSizes locate the tables within data, and columns the offsets of the columns of their rows.
+	offsets  [64]int
+	rowSizes [64]int
+	columns  [64][]int
+	// sizes of the indexes into the heaps.
+	stringIdx, guidIdx, blobIdx int
+}
+
+// parse reads the row counts of the tables and computes the layout of the ones up to the Constant table.
+func (t *cliTables) parse() error {
+	c := &cliReader{data: t.data, pos: 6}
+	heapSizes := c.byte()
+	c.pos++
+	valid := c.uint64()
+	c.pos += 8 // sorted
+	for i := 0; i < 64; i++ {
+		if valid&(1<<i) != 0 {
+			t.rows[i] = c.uint32()
+		}
+	}
+	// Uncompressed (#-) streams may have extra data following the row counts.
+	if heapSizes&0x40 != 0 {
+		c.pos += 4
+	}
+	if c.err != nil {
+		return c.err
+	}
+
+	t.stringIdx, t.guidIdx, t.blobIdx = 2, 2, 2
+	if heapSizes&0x01 != 0 {
+		t.stringIdx = 4
+	}
+	if heapSizes&0x02 != 0 {
+		t.guidIdx = 4
+	}
+	if heapSizes&0x04 != 0 {
+		t.blobIdx = 4
+	}
+
+	typeDefOrRef := t.codedIdx(2, cliTableTypeDef, cliTableTypeRef, cliTableTypeSpec)
+	str, blob := t.stringIdx, t.blobIdx
+	layouts := [][]int{
+		cliTableModule:        {2, str, t.guidIdx, t.guidIdx, t.guidIdx},
+		cliTableTypeRef:       {t.codedIdx(2, cliTableModule, cliTableModuleRef, cliTableAssemblyRef, cliTableTypeRef), str, str},
+		cliTableTypeDef:       {4, str, str, typeDefOrRef, t.idx(cliTableField), t.idx(cliTableMethodDef)},
+		cliTableFieldPtr:      {t.idx(cliTableField)},
+		cliTableField:         {2, str, blob},
+		cliTableMethodPtr:     {t.idx(cliTableMethodDef)},
+		cliTableMethodDef:     {4, 2, 2, str, blob, t.idx(cliTableParam)},
+		cliTableParamPtr:      {t.idx(cliTableParam)},
+		cliTableParam:         {2, 2, str},
+		cliTableInterfaceImpl: {t.idx(cliTableTypeDef), typeDefOrRef},
+		cliTableMemberRef: {
+			t.codedIdx(3, cliTableTypeDef, cliTableTypeRef, cliTableModuleRef, cliTableMethodDef, cliTableTypeSpec), str, blob,
+		},
+		cliTableConstant: {1, 1, t.codedIdx(2, cliTableField, cliTableParam, cliTableProperty), blob},
+	}
+
+	off := c.pos
+	for table, layout := range layouts {
+		t.columns[table] = make([]int, len(layout))
+		for i, size := range layout {
+			t.columns[table][i] = t.rowSizes[table]
+			t.rowSizes[table] += size
+		}
+		t.offsets[table] = off
+		off += int(t.rows[table]) * t.rowSizes[table]
+	}
+	if off > len(t.data) {
+		return errors.New("tables out of bounds")
+	}
+	return nil
+}
+
+// idx returns the size of an index into a table.
+func (t *cliTables) idx(table int) int {
+	if t.rows[table] < 1<<16 {
+		return 2
+	}
+	return 4
+}
+
+// codedIdx returns the size of a coded index into one of tables, whose tag takes the low tagBits bits.
+func (t *cliTables) codedIdx(tagBits uint, tables ...int) int {
+	for _, table := range tables {
+		if t.rows[table] >= 1<<(16-tagBits) {
+			return 4
+		}
+	}
+	return 2
+}
+
+// column returns the value of a column of a row, numbered from 1, of one of the tables parsed.
+func (t *cliTables) column(table int, row uint32, col int) uint32 {
+	off := t.offsets[table] + int(row-1)*t.rowSizes[table] + t.columns[table][col]
+	size := t.rowSizes[table] - t.columns[table][col]
+	if col+1 < len(t.columns[table]) {
+		size = t.columns[table][col+1] - t.columns[table][col]
+	}
+	switch size {
+	case 1:
+		return uint32(t.data[off])
+	case 2:
+		return uint32(binary.LittleEndian.Uint16(t.data[off:]))
+	default:
+		return binary.LittleEndian.Uint32(t.data[off:])
+	}
+}
+
+var errCLITruncated = errors.New("truncated CLI metadata")
+
+// cliReader reads the little endian structures of CLI metadata.
+type cliReader struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+// bytes reads n bytes. Once the data is exhausted, it returns zeros for the fixed size fields, and nil for blobs.
+func (c *cliReader) bytes(n int) []byte {
+	if c.err != nil || n < 0 || c.pos < 0 || c.pos+n > len(c.data) {
+		c.err = errCLITruncated
+		if n <= 8 {
+			return make([]byte, 8)
+		}
+		return nil
+	}
+	b := c.data[c.pos : c.pos+n]
+	c.pos += n
+	return b
+}
+
+func (c *cliReader) byte() byte     { return c.bytes(1)[0] }
+func (c *cliReader) uint16() uint16 { return binary.LittleEndian.Uint16(c.bytes(2)) }
+func (c *cliReader) uint32() uint32 { return binary.LittleEndian.Uint32(c.bytes(4)) }
+func (c *cliReader) uint64() uint64 { return binary.LittleEndian.Uint64(c.bytes(8)) }
+
+func (c *cliReader) cString() string {
+	end := bytes.IndexByte(c.data[min(c.pos, len(c.data)):], 0)
+	if end < 0 {
+		c.err = errCLITruncated
+		return ""
+	}
+	str := string(c.data[c.pos : c.pos+end])
+	c.pos += end + 1
+	return str
+}
+
+// blob reads a blob, whose compressed length is 1, 2 or 4 bytes long, as told by its top bits.
+func (c *cliReader) blob() []byte {
+	b := c.byte()
+	var n int
+	switch {
+	case b&0x80 == 0:
+		n = int(b)
+	case b&0xc0 == 0x80:
+		n = int(b&0x3f)<<8 | int(c.byte())
+	default:
+		rest := c.bytes(3)
+		n = int(b&0x1f)<<24 | int(rest[0])<<16 | int(rest[1])<<8 | int(rest[2])
+	}
+	return c.bytes(n)
+}
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"bytes"
+	"debug/pe"
+	"encoding/binary"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+// testCLIBlob encodes a blob of the #US or #Blob heap with a one byte length.
+func testCLIBlob(data []byte) []byte { return append([]byte{byte(len(data))}, data...) }
+
+func testUTF16LE(s string) []byte {
+	var b []byte
+	for _, u := range utf16.Encode([]rune(s)) {
+		b = binary.LittleEndian.AppendUint16(b, u)
+	}
+	return b
+}
+
+// buildTestAssembly builds a PE32 .NET assembly whose module is named module. Its type Example.Config has a
+// constant string field ApiKey set to constant and a field without a constant, and its user strings are userStrings.
+func buildTestAssembly(t *testing.T, module, constant string, userStrings ...string) []byte {
+	t.Helper()
+	le := binary.LittleEndian
+	pad4 := func(b []byte) []byte {
+		for len(b)%4 != 0 {
+			b = append(b, 0)
+		}
+		return b
+	}
+
+	strs := []byte{0}
+	str := func(s string) uint16 {
+		idx := uint16(len(strs))
+		strs = append(strs, s+"\x00"...)
+		return idx
+	}
+	moduleName, moduleType, typeName, typeNamespace := str(module), str("<Module>"), str("Config"), str("Example")
+	apiKey, other := str("ApiKey"), str("Other")
+
+	blobs := append([]byte{0}, testCLIBlob(testUTF16LE(constant))...)
+	us := []byte{0}
+	for _, s := range userStrings {
+		us = append(us, testCLIBlob(append(testUTF16LE(s), 0))...)
+	}
+
+	// The Module, TypeDef, Field and Constant tables.
+	tables := le.AppendUint32(nil, 0)
+	tables = append(tables, 2, 0, 0, 1)
+	tables = le.AppendUint64(tables, 1<<cliTableModule|1<<cliTableTypeDef|1<<cliTableField|1<<cliTableConstant)
+	tables = le.AppendUint64(tables, 0)
+	for _, rows := range []uint32{1, 2, 2, 1} {
+		tables = le.AppendUint32(tables, rows)
+	}
+	for _, v := range []uint16{0, moduleName, 0, 0, 0} {
+		tables = le.AppendUint16(tables, v)
+	}
+	for _, row := range [][]uint16{{moduleType, 0, 0, 1, 1}, {typeName, typeNamespace, 0, 1, 1}} {
+		tables = le.AppendUint32(tables, 0)
+		for _, v := range row {
+			tables = le.AppendUint16(tables, v)
+		}
+	}
+	for _, name := range []uint16{apiKey, other} {
+		for _, v := range []uint16{0x8053, name, 0} {
+			tables = le.AppendUint16(tables, v)
+		}
+	}
+	tables = append(tables, elementTypeString, 0)
+	tables = le.AppendUint16(tables, 1<<2) // the first field
+	tables = le.AppendUint16(tables, 1)
+
+	streams := []struct {
+		name string
+		data []byte
+	}{{"#~", tables}, {"#Strings", strs}, {"#US", us}, {"#Blob", blobs}}
+	headersSize := 0
+	for _, s := range streams {
+		headersSize += 8 + len(pad4([]byte(s.name+"\x00")))
+	}
+
+	root := le.AppendUint32(nil, cliMetadataMagic)
+	root = le.AppendUint32(root, 0x00010001)
+	root = le.AppendUint32(root, 0)
+	root = le.AppendUint32(root, 12)
+	root = append(root, "v4.0.30319\x00\x00"...)
+	root = le.AppendUint16(root, 0)
+	root = le.AppendUint16(root, uint16(len(streams)))
+	off := len(root) + headersSize
+	var streamData []byte
+	for _, s := range streams {
+		data := pad4(s.data)
+		root = le.AppendUint32(root, uint32(off+len(streamData)))
+		root = le.AppendUint32(root, uint32(len(data)))
+		root = append(root, pad4([]byte(s.name+"\x00"))...)
+		streamData = append(streamData, data...)
+	}
+	root = append(root, streamData...)
+
+	const (
+		sectionRVA    = 0x2000
+		sectionOffset = 0x200
+		cliHeaderSize = 72
+	)
+	section := le.AppendUint32(nil, cliHeaderSize)
+	section = le.AppendUint32(section, 0x00050002)
+	section = le.AppendUint32(section, sectionRVA+cliHeaderSize)
+	section = le.AppendUint32(section, uint32(len(root)))
+	section = append(section, make([]byte, cliHeaderSize-len(section))...)
+	section = append(section, root...)
+
+	var buf bytes.Buffer
+	dos := make([]byte, 0x40)
+	copy(dos, "MZ")
+	le.PutUint32(dos[0x3c:], 0x40)
+	buf.Write(dos)
+	buf.WriteString("PE\x00\x00")
+	oh := pe.OptionalHeader32{Magic: 0x10b, NumberOfRvaAndSizes: 16, SectionAlignment: 0x1000, FileAlignment: 0x200}
+	oh.DataDirectory[cliHeaderDirectory] = pe.DataDirectory{VirtualAddress: sectionRVA, Size: cliHeaderSize}
+	for _, v := range []any{
+		pe.FileHeader{Machine: pe.IMAGE_FILE_MACHINE_I386, NumberOfSections: 1, SizeOfOptionalHeader: 224},
+		oh,
+		pe.SectionHeader32{
+			Name:             [8]uint8{'.', 't', 'e', 'x', 't'},
+			VirtualSize:      uint32(len(section)),
+			VirtualAddress:   sectionRVA,
+			SizeOfRawData:    uint32(len(section)),
+			PointerToRawData: sectionOffset,
+		},
+	} {
+		require.NoError(t, binary.Write(&buf, le, v))
+	}
+	buf.Write(make([]byte, sectionOffset-buf.Len()))
+	buf.Write(section)
+	return buf.Bytes()
+}
+
+// testXALZ compresses an assembly into a single LZ4 sequence of literals.
+func testXALZ(assembly []byte) []byte {
+	out := []byte(xalzMagic)
+	out = binary.LittleEndian.AppendUint32(out, 7)
+	out = binary.LittleEndian.AppendUint32(out, uint32(len(assembly)))
+	out = append(out, 0xf0)
+	n := len(assembly) - 15
+	for ; n >= 0xff; n -= 0xff {
+		out = append(out, 0xff)
+	}
+	out = append(out, byte(n))
+	return append(out, assembly...)
+}
+
+func TestHandleFileDotNet(t *testing.T) {
+	appDLL := buildTestAssembly(t, "App.dll", "const-secret", "user-secret", "clé-🔑")
+	libDLL := buildTestAssembly(t, "Lib.dll", "lib-secret")
+	store := append([]byte(assemblyStoreMagic), 0x01, 0, 0, 0, 2, 0, 0, 0)
+	store = append(append(store, testXALZ(appDLL)...), libDLL...)
+
+	tests := map[string]struct {
+		data []byte
+		want map[string]string
+	}{
+		"assembly": {
+			data: appDLL,
+			want: map[string]string{"": "Example.Config.ApiKey = const-secret\nuser-secret\nclé-🔑\n"},
+		},
+		"compressed assembly": {
+			data: testXALZ(appDLL),
+			want: map[string]string{"": "Example.Config.ApiKey = const-secret\nuser-secret\nclé-🔑\n"},
+		},
+		"assembly store": {
+			data: store,
+			want: map[string]string{
+				"App.dll": "Example.Config.ApiKey = const-secret\nuser-secret\nclé-🔑\n",
+				"Lib.dll": "Example.Config.ApiKey = lib-secret\n",
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			chunkCh := make(chan *sources.Chunk, 8)
+			err := HandleFile(context.Background(), bytes.NewReader(tt.data), &sources.Chunk{}, sources.ChanReporter{Ch: chunkCh})
+			require.NoError(t, err)
+			close(chunkCh)
+
+			got := make(map[string]string)
+			for chunk := range chunkCh {
+				extraction := chunk.SourceMetadata.GetExtraction()
+				assert.Equal(t, dotnetStage, extraction.GetStage())
+				got[extraction.GetEntryPath()] = string(chunk.Data)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDecodeLZ4Block(t *testing.T) {
+	// The literals "abc" followed by a match of 9 bytes at offset 3, and data following the block.
+	out, n, err := decodeLZ4Block([]byte{0x35, 'a', 'b', 'c', 3, 0, 0xde, 0xad}, 12)
+	require.NoError(t, err)
+	assert.Equal(t, "abcabcabcabc", string(out))
+	assert.Equal(t, 6, n)
+
+	_, _, err = decodeLZ4Block([]byte{0x35, 'a', 'b', 'c', 4, 0}, 12)
+	assert.ErrorIs(t, err, errLZ4Corrupt)
+}
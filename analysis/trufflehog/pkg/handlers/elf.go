@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"debug/elf"
+	"fmt"
+	"io"
+
+	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/iobuf"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+)
+
+// ELF executables and shared libraries, such as the native libraries of Android apps, keep their constant data in
+// .rodata and .data, and the names of their imported and exported symbols in .dynstr. Only the printable strings of
+// these sections are scanned, which avoids the noise of machine code and lets results be attributed to the section
+// and address they were found at.
+
+// elfStage is the extraction stage of chunks extracted from ELF sections.
+const elfStage = "elf"
+
+// elfStringSections are the sections whose printable strings are scanned.
+var elfStringSections = []string{".rodata", ".data", ".dynstr"}
+
+// minPrintableStringLength is the shortest run of printable characters that is extracted as a string.
+const minPrintableStringLength = 4
+
+// handleELFContent scans the printable strings of an ELF file's data sections.
+// It returns false, with rdr rewound, if the content couldn't be parsed as an ELF file.
+func (h *defaultHandler) handleELFContent(
+	ctx logContext.Context,
+	rdr *iobuf.BufferedReadSeeker,
+	metadata *source_metadatapb.MetaData,
+	dataOrErrChan chan DataOrErr,
+) (bool, error) {
+	f, err := elf.NewFile(rdr)
+	if err != nil {
+		ctx.Logger().V(3).Info("failed to parse ELF file, scanning as is", "error", err)
+		if _, err := rdr.Seek(0, io.SeekStart); err != nil {
+			return true, fmt.Errorf("%w: error resetting ELF reader: %v", ErrProcessingWarning, err)
+		}
+		return false, nil
+	}
+	defer f.Close()
+
+	// The strings of Flutter apps are objects of their Dart snapshots rather than strings of their sections.
+	if snapshots := elfDartSnapshots(ctx, f); len(snapshots) > 0 {
+		if parsed, err := h.handleDartSnapshots(ctx, snapshots, metadata, dataOrErrChan); parsed {
+			return true, err
+		}
+	}
+
+	// Since .NET 9, the assembly store of .NET for Android apps is the payload of a shared library.
+	if sec := f.Section(assemblyStorePayloadSection); sec != nil && sec.Type != elf.SHT_NOBITS {
+		magic := make([]byte, len(assemblyStoreMagic))
+		if _, err := sec.ReadAt(magic, 0); err == nil && string(magic) == assemblyStoreMagic {
+			if data, err := sec.Data(); err == nil {
+				if parsed, err := h.handleAssemblyStore(ctx, data, metadata, dataOrErrChan); parsed {
+					return true, err
+				}
+			}
+		}
+	}
+
+	for _, name := range elfStringSections {
+		sec := f.Section(name)
+		if sec == nil || sec.Type == elf.SHT_NOBITS {
+			continue
+		}
+		data, err := sec.Data()
+		if err != nil {
+			ctx.Logger().V(3).Info("failed to read ELF section", "section", name, "error", err)
+			continue
+		}
+		strs := printableStrings(name, sec.Addr, data)
+		if len(strs.data) == 0 {
+			continue
+		}
+		if err := h.writeSectionStrings(ctx, elfStage, strs, metadata, dataOrErrChan); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
+
+// printableStrings extracts the runs of printable ASCII characters from a section, the way the strings utility does,
+// and batches them into one run, one per line, along with the address of each line.
+func printableStrings(section string, addr uint64, data []byte) sectionStrings {
+	out := sectionStrings{section: section}
+	strStart := -1
+	for i := 0; i <= len(data); i++ {
+		if i < len(data) && isPrintableASCII(data[i]) {
+			if strStart < 0 {
+				strStart = i
+			}
+			continue
+		}
+		if strStart >= 0 && i-strStart >= minPrintableStringLength {
+			out.addString(addr+uint64(strStart), data[strStart:i])
+		}
+		strStart = -1
+	}
+	return out
+}
+
+func isPrintableASCII(b byte) bool { return b >= 0x20 && b < 0x7f || b == '\t' }
@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+var testELFRodata = []byte("\x01\x02ab\x00api_key=rodata-secret\x00\x00ok\x00near-secret\x00" +
+	string(make([]byte, 100)) + "far-away-secret\x00")
+
+type testELFSection struct {
+	name string
+	typ  elf.SectionType
+	addr uint64
+	data []byte
+	// link is the index of the section header linked to, such as the string table of a symbol table.
+	link uint32
+}
+
+// buildTestELF builds a 64-bit little endian shared library with .rodata, .data, .dynstr and .bss sections.
+func buildTestELF(t *testing.T) []byte {
+	return buildTestELFSections(t, []testELFSection{
+		{name: ".rodata", typ: elf.SHT_PROGBITS, addr: 0x1000, data: testELFRodata},
+		{name: ".data", typ: elf.SHT_PROGBITS, addr: 0x2000, data: []byte("data-secret\x00")},
+		{name: ".dynstr", typ: elf.SHT_STRTAB, addr: 0x300, data: []byte("\x00libc.so\x00JNI_OnLoad\x00")},
+		{name: ".bss", typ: elf.SHT_NOBITS, addr: 0x3000},
+	})
+}
+
+// buildTestELFSections builds a 64-bit little endian shared library with the given sections, whose headers follow
+// the null section header.
+func buildTestELFSections(t *testing.T, sections []testELFSection) []byte {
+	t.Helper()
+
+	shstrtab := []byte("\x00")
+	body := bytes.NewBuffer(make([]byte, 64))
+	headers := []elf.Section64{{}}
+	for _, sec := range sections {
+		headers = append(headers, elf.Section64{
+			Name: uint32(len(shstrtab)),
+			Type: uint32(sec.typ),
+			Addr: sec.addr,
+			Off:  uint64(body.Len()),
+			Size: uint64(len(sec.data)),
+			Link: sec.link,
+		})
+		shstrtab = append(shstrtab, sec.name+"\x00"...)
+		body.Write(sec.data)
+	}
+	headers = append(headers, elf.Section64{
+		Name: uint32(len(shstrtab)),
+		Type: uint32(elf.SHT_STRTAB),
+		Off:  uint64(body.Len()),
+		Size: uint64(len(shstrtab) + len(".shstrtab\x00")),
+	})
+	body.WriteString(string(shstrtab) + ".shstrtab\x00")
+
+	shoff := body.Len()
+	for _, hdr := range headers {
+		require.NoError(t, binary.Write(body, binary.LittleEndian, hdr))
+	}
+
+	hdr := elf.Header64{
+		Type:      uint16(elf.ET_DYN),
+		Machine:   uint16(elf.EM_AARCH64),
+		Version:   uint32(elf.EV_CURRENT),
+		Shoff:     uint64(shoff),
+		Ehsize:    64,
+		Shentsize: 64,
+		Shnum:     uint16(len(headers)),
+		Shstrndx:  uint16(len(headers) - 1),
+	}
+	copy(hdr.Ident[:], elf.ELFMAG)
+	hdr.Ident[elf.EI_CLASS] = byte(elf.ELFCLASS64)
+	hdr.Ident[elf.EI_DATA] = byte(elf.ELFDATA2LSB)
+	hdr.Ident[elf.EI_VERSION] = byte(elf.EV_CURRENT)
+
+	var hdrBuf bytes.Buffer
+	require.NoError(t, binary.Write(&hdrBuf, binary.LittleEndian, hdr))
+	out := body.Bytes()
+	copy(out, hdrBuf.Bytes())
+	return out
+}
+
+func TestHandleFileELF(t *testing.T) {
+	type sectionString struct {
+		section       string
+		address       uint64
+		lineAddresses []uint64
+		data          string
+	}
+	want := []sectionString{
+		{
+			section:       ".rodata",
+			address:       0x1005,
+			lineAddresses: []uint64{0x1005, 0x101f, 0x1000 + uint64(bytes.Index(testELFRodata, []byte("far")))},
+			data:          "api_key=rodata-secret\nnear-secret\nfar-away-secret\n",
+		},
+		{section: ".data", address: 0x2000, lineAddresses: []uint64{0x2000}, data: "data-secret\n"},
+		{section: ".dynstr", address: 0x301, lineAddresses: []uint64{0x301, 0x309}, data: "libc.so\nJNI_OnLoad\n"},
+	}
+
+	chunkCh := make(chan *sources.Chunk, 32)
+	err := HandleFile(context.Background(), bytes.NewReader(buildTestELF(t)), &sources.Chunk{}, sources.ChanReporter{Ch: chunkCh})
+	require.NoError(t, err)
+	close(chunkCh)
+
+	var got []sectionString
+	for chunk := range chunkCh {
+		extraction := chunk.SourceMetadata.GetExtraction()
+		require.NotNil(t, extraction)
+		assert.Equal(t, elfStage, extraction.GetStage())
+		got = append(got, sectionString{
+			section:       extraction.GetSection(),
+			address:       extraction.GetAddress(),
+			lineAddresses: extraction.GetLineAddresses(),
+			data:          string(chunk.Data),
+		})
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestHandleFileELF_SparseSection(t *testing.T) {
+	// The strings of a section are batched into one chunk however far apart they are.
+	var data []byte
+	var wantAddresses []uint64
+	for i := range 50 {
+		wantAddresses = append(wantAddresses, 0x2000+uint64(len(data)))
+		data = append(data, fmt.Sprintf("secret-%02d", i)...)
+		data = append(data, make([]byte, 200)...)
+	}
+	elfData := buildTestELFSections(t, []testELFSection{{name: ".data", typ: elf.SHT_PROGBITS, addr: 0x2000, data: data}})
+
+	chunkCh := make(chan *sources.Chunk, 32)
+	err := HandleFile(context.Background(), bytes.NewReader(elfData), &sources.Chunk{}, sources.ChanReporter{Ch: chunkCh})
+	require.NoError(t, err)
+	close(chunkCh)
+
+	var chunks []*sources.Chunk
+	for chunk := range chunkCh {
+		chunks = append(chunks, chunk)
+	}
+	require.Len(t, chunks, 1)
+	extraction := chunks[0].SourceMetadata.GetExtraction()
+	assert.Equal(t, ".data", extraction.GetSection())
+	assert.Equal(t, wantAddresses, extraction.GetLineAddresses())
+	assert.Equal(t, 50, bytes.Count(chunks[0].Data, []byte("\n")))
+}
+
+func TestGroupNativeLibs(t *testing.T) {
+	groups := groupNativeLibs([]apkEntries{{names: []string{
+		"lib/x86/libkeys.so",
+		"lib/armeabi-v7a/libkeys.so",
+		"lib/arm64-v8a/libkeys.so",
+		"lib/riscv64/libkeys.so",
+		"lib/x86/libonly.so",
+		"lib/arm64-v8a/nested/libnested.so",
+		"assets/libkeys.so",
+	}}})
+	assert.Equal(t, map[string][]string{
+		"lib/arm64-v8a/libkeys.so": {
+			"lib/arm64-v8a/libkeys.so",
+			"lib/armeabi-v7a/libkeys.so",
+			"lib/x86/libkeys.so",
+			"lib/riscv64/libkeys.so",
+		},
+	}, groups.variants)
+	assert.Equal(t, map[string]struct{}{
+		"lib/armeabi-v7a/libkeys.so": {},
+		"lib/x86/libkeys.so":         {},
+		"lib/riscv64/libkeys.so":     {},
+	}, groups.skipped)
+}
+
+func TestGroupNativeLibs_SplitAPKs(t *testing.T) {
+	groups := groupNativeLibs([]apkEntries{
+		{path: "base.apk", names: []string{"classes.dex", "lib/x86/libbase.so"}},
+		{path: "config.x86_64.apk", names: []string{"lib/x86_64/libkeys.so", "lib/x86_64/libbase.so"}},
+		{path: "config.arm64_v8a.apk", names: []string{"lib/arm64-v8a/libkeys.so"}},
+	})
+	assert.Equal(t, map[string][]string{
+		"config.arm64_v8a.apk/lib/arm64-v8a/libkeys.so": {
+			"config.arm64_v8a.apk/lib/arm64-v8a/libkeys.so",
+			"config.x86_64.apk/lib/x86_64/libkeys.so",
+		},
+		"config.x86_64.apk/lib/x86_64/libbase.so": {
+			"config.x86_64.apk/lib/x86_64/libbase.so",
+			"base.apk/lib/x86/libbase.so",
+		},
+	}, groups.variants)
+	assert.Equal(t, map[string]struct{}{
+		"config.x86_64.apk/lib/x86_64/libkeys.so": {},
+		"base.apk/lib/x86/libbase.so":             {},
+	}, groups.skipped)
+}
@@ -8,13 +8,17 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gabriel-vasile/mimetype"
 	"github.com/mholt/archiver/v4"
+	"google.golang.org/protobuf/proto"
 
 	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/feature"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/fileclass"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/iobuf"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
@@ -39,6 +43,8 @@ type fileReader struct {
 	format           archiver.Format
 	mime             *mimetype.MIME
 	isGenericArchive bool
+	// zipReader is the zip reader opened to detect an app archive, which its handler reuses.
+	zipReader *zip.Reader
 
 	*iobuf.BufferedReadSeeker
 }
@@ -54,7 +60,11 @@ var (
 	ErrProcessingWarning = errors.New("error processing file")
 )
 
-type readerConfig struct{ fileExtension string }
+type readerConfig struct {
+	fileExtension string
+	// entryName is the name of a file extracted from an archive. It's empty for files that are handled directly.
+	entryName string
+}
 
 type readerOption func(*readerConfig)
 
@@ -62,6 +72,19 @@ func withFileExtension(ext string) readerOption {
 	return func(c *readerConfig) { c.fileExtension = ext }
 }
 
+func withEntryName(name string) readerOption {
+	return func(c *readerConfig) { c.entryName = name }
+}
+
+// appArchiveExtension returns the extension an app archive is recognized by: the file's own extension, or that of
+// the entry's name for files extracted from an archive.
+func (c readerConfig) appArchiveExtension() string {
+	if c.entryName != "" {
+		return strings.ToLower(filepath.Ext(c.entryName))
+	}
+	return c.fileExtension
+}
+
 // mimeTypeReader wraps an io.Reader with MIME type information.
 // This type is used to pass content through the processing pipeline
 // while carrying its detected MIME type, avoiding redundant type detection.
@@ -138,7 +161,7 @@ func newFileReader(r io.Reader, options ...readerOption) (fReader fileReader, er
 
 	// Check for APK files
 	if shouldHandleAsAPK(cfg, fReader) {
-		isAPK, err := isAPKFile(&fReader)
+		isAPK, err := isAPKFile(cfg, &fReader)
 		if err != nil {
 			return fReader, fmt.Errorf("error checking for APK: %w", err)
 		}
@@ -147,6 +170,23 @@ func newFileReader(r io.Reader, options ...readerOption) (fReader fileReader, er
 		}
 	}
 
+	// Check for AAB and IPA files
+	if shouldHandleAsAppArchive(cfg, fReader) {
+		appMime, zipReader := detectAppArchive(cfg, &fReader)
+		// Reset the reader because the zip reader moves it.
+		if _, err = fReader.Seek(0, io.SeekStart); err != nil {
+			return fReader, fmt.Errorf("error resetting reader after app archive detection: %w", err)
+		}
+		switch appMime {
+		case aabMime:
+			fReader.zipReader = zipReader
+			return handleAABFile(&fReader)
+		case ipaMime:
+			fReader.zipReader = zipReader
+			return handleIPAFile(&fReader)
+		}
+	}
+
 	// If a MIME type is known to not be an archive type, we might as well return here rather than
 	// paying the I/O penalty of an archiver.Identify() call that won't identify anything.
 	if _, ok := skipArchiverMimeTypes[mimeType(mime.String())]; ok {
@@ -185,6 +225,9 @@ func newFileReader(r io.Reader, options ...readerOption) (fReader fileReader, er
 type DataOrErr struct {
 	Data []byte
 	Err  error
+	// Metadata, if set, is merged into the source metadata of the chunk created from Data.
+	// Handlers use it to describe where within the file the data was extracted from.
+	Metadata *source_metadatapb.MetaData
 }
 
 // FileHandler represents a handler for files.
@@ -195,7 +238,11 @@ type FileHandler interface {
 }
 
 // fileHandlingConfig encapsulates configuration settings that control the behavior of file processing.
-type fileHandlingConfig struct{ skipArchives bool }
+type fileHandlingConfig struct {
+	skipArchives bool
+	classFilter  *fileclass.Filter
+	splitAPK     *splitAPK
+}
 
 // newFileHandlingConfig creates a default fileHandlingConfig with default settings.
 // Optional functional parameters can customize the configuration.
@@ -214,6 +261,23 @@ func WithSkipArchives(skip bool) func(*fileHandlingConfig) {
 	return func(c *fileHandlingConfig) { c.skipArchives = skip }
 }
 
+// WithFileClassFilter sets the classFilter field of the fileHandlingConfig.
+// The FileHandler will skip files, and archive entries, of the classes the filter doesn't pass.
+func WithFileClassFilter(filter *fileclass.Filter) func(*fileHandlingConfig) {
+	return func(c *fileHandlingConfig) { c.classFilter = filter }
+}
+
+// WithSplitAPKs sets the split APKs of the app that the file at path is one of.
+// The copies of each native library built for the different ABIs are then grouped across them, and only one is
+// scanned. A nil splits is ignored.
+func WithSplitAPKs(splits *SplitAPKs, path string) func(*fileHandlingConfig) {
+	return func(c *fileHandlingConfig) {
+		if splits != nil {
+			c.splitAPK = &splitAPK{splits: splits, path: path}
+		}
+	}
+}
+
 type handlerType string
 
 const (
@@ -221,10 +285,20 @@ const (
 	arHandlerType      handlerType = "ar"
 	rpmHandlerType     handlerType = "rpm"
 	apkHandlerType     handlerType = "apk"
+	aabHandlerType     handlerType = "aab"
+	ipaHandlerType     handlerType = "ipa"
 	defaultHandlerType handlerType = "default"
 	apkExt                         = ".apk"
+	xapkExt                        = ".xapk"
+	apksExt                        = ".apks"
+	apkmExt                        = ".apkm"
+	aabExt                         = ".aab"
+	ipaExt                         = ".ipa"
 )
 
+// apkExts are the extensions of APKs and of the containers that bundle the APKs of an app.
+var apkExts = map[string]struct{}{apkExt: {}, xapkExt: {}, apksExt: {}, apkmExt: {}}
+
 type mimeType string
 
 const (
@@ -258,6 +332,20 @@ const (
 	tclTextMime  mimeType = "text/x-tcl"
 	tclMime      mimeType = "application/x-tcl"
 	apkMime      mimeType = "application/vnd.android.package-archive"
+	aabMime      mimeType = "application/x-android-app-bundle"
+	ipaMime      mimeType = "application/x-ios-app"
+	plistMime    mimeType = "application/x-plist"
+	bplistMime   mimeType = "application/x-bplist"
+	machoMime    mimeType = "application/x-mach-binary"
+	elfMime      mimeType = "application/x-elf"
+	elfExeMime   mimeType = "application/x-executable"
+	elfLibMime   mimeType = "application/x-sharedlib"
+	hermesMime   mimeType = "application/x-hermes-bytecode"
+	peMime       mimeType = "application/vnd.microsoft.portable-executable"
+	xalzMime     mimeType = "application/x-xamarin-compressed-assembly"
+	asmStoreMime mimeType = "application/x-xamarin-assembly-store"
+	il2cppMime   mimeType = "application/x-il2cpp-metadata"
+	srcMapMime   mimeType = "application/x-sourcemap"
 	zipMime      mimeType = "application/zip"
 	jarMime      mimeType = "application/java-archive"
 )
@@ -295,6 +383,20 @@ var skipArchiverMimeTypes = map[mimeType]struct{}{
 	tclTextMime:  {},
 	tclMime:      {},
 	apkMime:      {},
+	aabMime:      {},
+	ipaMime:      {},
+	plistMime:    {},
+	bplistMime:   {},
+	machoMime:    {},
+	elfMime:      {},
+	elfExeMime:   {},
+	elfLibMime:   {},
+	hermesMime:   {},
+	peMime:       {},
+	xalzMime:     {},
+	asmStoreMime: {},
+	il2cppMime:   {},
+	srcMapMime:   {},
 }
 
 // selectHandler dynamically selects and configures a FileHandler based on the provided |mimetype| type and archive flag.
@@ -303,6 +405,8 @@ var skipArchiverMimeTypes = map[mimeType]struct{}{
 // - arHandler is used for Unix archives and Debian packages ('arMime', 'unixArMime', and 'debMime').
 // - rpmHandler is used for RPM and CPIO archives ('rpmMime' and 'cpioMime').
 // - apkHandler is used for APK archives ('apkMime').
+// - aabHandler is used for Android App Bundles ('aabMime').
+// - ipaHandler is used for iOS app archives ('ipaMime').
 // - archiveHandler is used for common archive formats supported by the archiver library (.zip, .tar, .gz, etc.).
 // - defaultHandler is used for non-archive files.
 // The selected handler is then returned, ready to handle the file according to its specific format and requirements.
@@ -314,6 +418,10 @@ func selectHandler(mimeT mimeType, isGenericArchive bool) FileHandler {
 		return newRPMHandler()
 	case apkMime:
 		return newAPKHandler()
+	case aabMime:
+		return newAABHandler()
+	case ipaMime:
+		return newIPAHandler()
 	default:
 		if isGenericArchive {
 			return newArchiveHandler()
@@ -336,6 +444,7 @@ func SetArchiveMaxTimeout(timeout time.Duration) { maxTimeout = timeout }
 // The function will return nil (success) in the following cases:
 // - If the reader is empty (ErrEmptyReader)
 // - If skipArchives option is true and the file is detected as an archive
+// - If the file's class is excluded, or isn't included and the file isn't an archive
 // - If all chunks are processed successfully without critical errors
 //
 // The function will return an error in the following cases:
@@ -387,15 +496,55 @@ func HandleFile(
 		return nil
 	}
 
+	// Sources may classify the file themselves, otherwise it's classified by its name and detected MIME type.
+	if chunkSkel != nil && chunkSkel.SourceMetadata != nil && chunkSkel.SourceMetadata.GetFileClass() == "" {
+		classified := *chunkSkel
+		classified.SourceMetadata = withFileClass(
+			chunkSkel.SourceMetadata, fileclass.Classify(getFileName(chunkSkel), rdr.mime.String()),
+		)
+		chunkSkel = &classified
+	}
+
+	handler := selectHandler(mimeT, rdr.isGenericArchive)
+	if config.classFilter != nil {
+		// Archives are opened unless their class is excluded, their entries being filtered by their own class.
+		_, isDefault := handler.(*defaultHandler)
+		var class fileclass.Class
+		if chunkSkel != nil {
+			class = fileclass.Class(chunkSkel.SourceMetadata.GetFileClass())
+		}
+		if class != "" && (config.classFilter.Excludes(class) || (isDefault && !config.classFilter.Pass(class))) {
+			ctx.Logger().V(5).Info("skipping file of filtered class", "class", class)
+			return nil
+		}
+		reporter = classFilterReporter{ChunkReporter: reporter, filter: config.classFilter}
+	}
+
 	processingCtx, cancel := logContext.WithTimeout(ctx, maxTimeout)
 	defer cancel()
+	if config.splitAPK != nil {
+		processingCtx = logContext.WithValue(processingCtx, splitAPKKey, *config.splitAPK)
+	}
 
-	handler := selectHandler(mimeT, rdr.isGenericArchive)
 	dataOrErrChan := handler.HandleFile(processingCtx, rdr) // Delegate to the specific handler to process the file.
 
 	return handleChunksWithError(processingCtx, dataOrErrChan, chunkSkel, reporter)
 }
 
+// classFilterReporter is a ChunkReporter that drops the chunks of archive entries of the classes its filter
+// doesn't pass.
+type classFilterReporter struct {
+	sources.ChunkReporter
+	filter *fileclass.Filter
+}
+
+func (r classFilterReporter) ChunkOk(ctx logContext.Context, chunk sources.Chunk) error {
+	if class := fileclass.Class(chunk.SourceMetadata.GetFileClass()); class != "" && !r.filter.Pass(class) {
+		return nil
+	}
+	return r.ChunkReporter.ChunkOk(ctx, chunk)
+}
+
 // handleChunksWithError processes data and errors received from the dataErrChan channel.
 // For each DataOrErr received:
 // - If it contains data, the function creates a chunk based on chunkSkel and reports it through the reporter.
@@ -428,6 +577,9 @@ func handleChunksWithError(
 			if len(dataOrErr.Data) > 0 {
 				chunk := *chunkSkel
 				chunk.Data = dataOrErr.Data
+				if dataOrErr.Metadata != nil {
+					chunk.SourceMetadata = mergeSourceMetadata(chunkSkel.SourceMetadata, withEntryClass(dataOrErr.Metadata))
+				}
 				if err := reporter.ChunkOk(ctx, chunk); err != nil {
 					return fmt.Errorf("error reporting chunk: %w", err)
 				}
@@ -438,6 +590,74 @@ func handleChunksWithError(
 	}
 }
 
+// mergeSourceMetadata returns a copy of the chunk's source metadata with the handler provided metadata merged into it.
+// The chunk skeleton's metadata is shared by every chunk of the file, so it must not be modified in place.
+func mergeSourceMetadata(base, metadata *source_metadatapb.MetaData) *source_metadatapb.MetaData {
+	merged := &source_metadatapb.MetaData{}
+	if base != nil {
+		merged = proto.Clone(base).(*source_metadatapb.MetaData)
+	}
+	proto.Merge(merged, metadata)
+	return merged
+}
+
+// withEntryClass returns metadata recording the class of the archive entry the data was extracted from. Entries
+// that handlers didn't classify by their MIME type are classified by their name.
+func withEntryClass(metadata *source_metadatapb.MetaData) *source_metadatapb.MetaData {
+	entryPath := metadata.GetExtraction().GetEntryPath()
+	if entryPath == "" || metadata.GetFileClass() != "" {
+		return metadata
+	}
+	return withFileClass(metadata, fileclass.Classify(entryPath, ""))
+}
+
+// withFileClass returns a copy of metadata recording the class of the file the data was read from.
+func withFileClass(metadata *source_metadatapb.MetaData, class fileclass.Class) *source_metadatapb.MetaData {
+	classified := &source_metadatapb.MetaData{}
+	if metadata != nil {
+		classified = proto.Clone(metadata).(*source_metadatapb.MetaData)
+	}
+	classified.FileClass = string(class)
+	return classified
+}
+
+// HasKeyPaths returns true if the handlers decode the data of an extraction stage into "key.path = value" lines, whose
+// key paths locate the results found in them. The other stages extract raw strings and literals.
+func HasKeyPaths(stage string) bool {
+	switch stage {
+	case plistStage, apkResourcesStage, dexStringPoolStage, dotnetStage:
+		return true
+	default:
+		return false
+	}
+}
+
+// withExtractionStage returns a copy of metadata recording the stage that decoded the data.
+func withExtractionStage(metadata *source_metadatapb.MetaData, stage string) *source_metadatapb.MetaData {
+	decoded := cloneWithExtraction(metadata)
+	decoded.Extraction.Stage = stage
+	return decoded
+}
+
+// withEntryPath returns a copy of metadata recording the path of the archive entry the data was extracted from.
+func withEntryPath(metadata *source_metadatapb.MetaData, entryPath string) *source_metadatapb.MetaData {
+	extracted := cloneWithExtraction(metadata)
+	extracted.Extraction.EntryPath = entryPath
+	return extracted
+}
+
+// cloneWithExtraction returns a copy of metadata, which may be nil, with its extraction details set.
+func cloneWithExtraction(metadata *source_metadatapb.MetaData) *source_metadatapb.MetaData {
+	clone := &source_metadatapb.MetaData{}
+	if metadata != nil {
+		clone = proto.Clone(metadata).(*source_metadatapb.MetaData)
+	}
+	if clone.Extraction == nil {
+		clone.Extraction = &source_metadatapb.Extraction{}
+	}
+	return clone
+}
+
 // isFatal determines whether the given error is a fatal error that should
 // terminate processing the current file, or a non-critical error that can be logged and ignored.
 // "Fatal" errors include context cancellation, deadline exceeded, and the
@@ -457,10 +677,16 @@ func isFatal(err error) bool {
 }
 
 // getFileExtension extracts the file extension from the chunk's SourceMetadata.
-// It considers all sources defined in the MetaData message.
 // Note: Probably should add this as a method to the source_metadatapb object.
 // then it'd just be chunkSkel.SourceMetadata.GetFileExtension()
 func getFileExtension(chunkSkel *sources.Chunk) string {
+	// Use filepath.Ext to extract the file extension from the file name
+	return filepath.Ext(getFileName(chunkSkel))
+}
+
+// getFileName extracts the file name, or the link of sources without files, from the chunk's SourceMetadata.
+// It considers all sources defined in the MetaData message.
+func getFileName(chunkSkel *sources.Chunk) string {
 	if chunkSkel == nil || chunkSkel.SourceMetadata == nil {
 		return ""
 	}
@@ -528,10 +754,7 @@ func getFileExtension(chunkSkel *sources.Chunk) string {
 	default:
 		return ""
 	}
-
-	// Use filepath.Ext to extract the file extension from the file name
-	ext := filepath.Ext(fileName)
-	return ext
+	return fileName
 }
 
 // shouldHandleAsAPK checks if the file should be handled as an APK based on config and MIME type.
@@ -539,35 +762,29 @@ func getFileExtension(chunkSkel *sources.Chunk) string {
 // so that all files are fully read into a byte slice for detection (mimetype.SetLimit(0)), which would bloat memory.
 // Instead we call the isAPKFile function in here after ensuring it's a zip/jar file and has an .apk extension.
 func shouldHandleAsAPK(cfg readerConfig, fReader fileReader) bool {
+	_, isAPKExt := apkExts[cfg.fileExtension]
 	return feature.EnableAPKHandler.Load() &&
-		cfg.fileExtension == apkExt &&
+		isAPKExt &&
 		(fReader.mime.String() == string(zipMime) || fReader.mime.String() == string(jarMime))
 }
 
-func isAPKFile(r *fileReader) (bool, error) {
+// isAPKFile checks whether a zip file is an APK, or an apk container holding the APKs of an app.
+// Split APKs have a manifest but no code of their own, so a manifest is enough for files with an .apk extension.
+func isAPKFile(cfg readerConfig, r *fileReader) (bool, error) {
 	size, _ := r.Size()
 	zipReader, err := zip.NewReader(r, size)
 	if err != nil {
 		return false, fmt.Errorf("error creating zip reader: %w", err)
 	}
 
-	hasManifest := false
-	hasClasses := false
-
+	if cfg.fileExtension != apkExt {
+		return isAPKContainer(zipReader), nil
+	}
 	for _, file := range zipReader.File {
-		switch file.Name {
-		case "AndroidManifest.xml":
-			hasManifest = true
-		case "classes.dex":
-			hasClasses = true
-		default:
-			// Skip other files.
-		}
-		if hasManifest && hasClasses {
+		if file.Name == "AndroidManifest.xml" {
 			return true, nil
 		}
 	}
-
 	return false, nil
 }
 
@@ -583,3 +800,86 @@ func handleAPKFile(fReader *fileReader) (fileReader, error) {
 	}
 	return *fReader, nil
 }
+
+// appArchiveEntryExts are the extensions of archive entries that are checked for an app bundle or IPA layout:
+// the apps' own extensions, and none or a generic one, which an app archive could have been renamed to.
+var appArchiveEntryExts = map[string]struct{}{"": {}, ".zip": {}, aabExt: {}, ipaExt: {}}
+
+// shouldHandleAsAppArchive checks if the file should be checked for an app bundle or IPA layout based on config
+// and MIME type. Like APKs, app bundles are only checked if they have their .aab extension. Unlike APKs, IPAs are
+// also detected without theirs, since their layout can be recognized from the zip central directory alone. Files
+// extracted from an archive are only checked if their extension is missing or generic, so the jars and zips nested
+// in other archives aren't opened twice.
+func shouldHandleAsAppArchive(cfg readerConfig, fReader fileReader) bool {
+	isAABExt := feature.EnableAPKHandler.Load() && cfg.appArchiveExtension() == aabExt
+	if !isAABExt && !feature.EnableIPAHandler.Load() {
+		return false
+	}
+	if fReader.mime.String() != string(zipMime) && fReader.mime.String() != string(jarMime) {
+		return false
+	}
+	if cfg.entryName == "" {
+		return true
+	}
+	_, ok := appArchiveEntryExts[cfg.appArchiveExtension()]
+	return ok
+}
+
+// detectAppArchive reads the zip central directory once to check whether the file is an app bundle or an IPA,
+// and returns the MIME type of the one it is, if any, with the zip reader for its handler. Files whose central
+// directory can't be read are neither; the archive handler reports the error if it can't read them either.
+func detectAppArchive(cfg readerConfig, r *fileReader) (mimeType, *zip.Reader) {
+	size, err := r.Size()
+	if err != nil {
+		logContext.Background().Logger().V(3).Info("unable to check for app archive, error getting file size", "error", err)
+		return "", nil
+	}
+	zipReader, err := zip.NewReader(r, size)
+	if err != nil {
+		logContext.Background().Logger().V(3).Info("unable to check for app archive, error creating zip reader", "error", err)
+		return "", nil
+	}
+
+	switch {
+	case feature.EnableAPKHandler.Load() && cfg.appArchiveExtension() == aabExt && isAABLayout(zipReader):
+		return aabMime, zipReader
+	case feature.EnableIPAHandler.Load() && isIPALayout(cfg, zipReader):
+		return ipaMime, zipReader
+	default:
+		return "", nil
+	}
+}
+
+var extendAABMimeOnce sync.Once
+
+// handleAABFile configures the MIME type for an app bundle and resets the reader.
+func handleAABFile(fReader *fileReader) (fileReader, error) {
+	// Extend the MIME type to recognize AAB files
+	extendAABMimeOnce.Do(func() {
+		mimetype.Lookup(string(zipMime)).Extend(func(r []byte, l uint32) bool { return false }, string(aabMime), aabExt)
+	})
+	fReader.mime = mimetype.Lookup(string(aabMime))
+
+	// Reset reader for further handling
+	if _, err := fReader.Seek(0, io.SeekStart); err != nil {
+		return *fReader, fmt.Errorf("error resetting reader after AAB detection: %w", err)
+	}
+	return *fReader, nil
+}
+
+var extendIPAMimeOnce sync.Once
+
+// handleIPAFile configures the MIME type for an IPA and resets the reader.
+func handleIPAFile(fReader *fileReader) (fileReader, error) {
+	// Extend the MIME type to recognize IPA files
+	extendIPAMimeOnce.Do(func() {
+		mimetype.Lookup(string(zipMime)).Extend(func(r []byte, l uint32) bool { return false }, string(ipaMime), ipaExt)
+	})
+	fReader.mime = mimetype.Lookup(string(ipaMime))
+
+	// Reset reader for further handling
+	if _, err := fReader.Seek(0, io.SeekStart); err != nil {
+		return *fReader, fmt.Errorf("error resetting reader after IPA detection: %w", err)
+	}
+	return *fReader, nil
+}
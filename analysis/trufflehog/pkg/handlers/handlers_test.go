@@ -20,6 +20,8 @@ import (
 	diskbufferreader "github.com/trufflesecurity/disk-buffer-reader"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/fileclass"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
 )
 
@@ -858,3 +860,81 @@ func TestHandleChunksWithError(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleFileClass(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range map[string]string{
+		"config/app.json": `{"apiKey": "secret"}`,
+		"src/main.py":     "API_KEY = 'secret'\n",
+		"settings":        `{"token": "secret"}`,
+		"README":          "Set the API key in config/app.json.\n",
+	} {
+		w, err := zw.Create(name)
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+
+	tests := []struct {
+		name    string
+		file    string
+		include []string
+		exclude []string
+		want    map[string]string
+	}{
+		{
+			name: "no filter",
+			file: "bundle.zip",
+			want: map[string]string{
+				"config/app.json": "config",
+				"src/main.py":     "code",
+				"settings":        "config",
+				"README":          "document",
+			},
+		},
+		{
+			name:    "included entries",
+			file:    "bundle.zip",
+			include: []string{"config"},
+			want:    map[string]string{"config/app.json": "config", "settings": "config"},
+		},
+		{
+			name:    "excluded entries",
+			file:    "bundle.zip",
+			exclude: []string{"config", "document"},
+			want:    map[string]string{"src/main.py": "code"},
+		},
+		{
+			name:    "excluded archive",
+			file:    "bundle.zip",
+			exclude: []string{"archive"},
+			want:    map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := fileclass.NewFilter(tt.include, tt.exclude)
+			assert.NoError(t, err)
+
+			chunkSkel := &sources.Chunk{SourceMetadata: &source_metadatapb.MetaData{
+				Data: &source_metadatapb.MetaData_Filesystem{Filesystem: &source_metadatapb.Filesystem{File: tt.file}},
+			}}
+			chunkCh := make(chan *sources.Chunk, 8)
+			err = HandleFile(
+				context.Background(), bytes.NewReader(buf.Bytes()), chunkSkel, sources.ChanReporter{Ch: chunkCh},
+				WithFileClassFilter(filter),
+			)
+			assert.NoError(t, err)
+			close(chunkCh)
+
+			got := make(map[string]string)
+			for chunk := range chunkCh {
+				got[chunk.SourceMetadata.GetExtraction().GetEntryPath()] = chunk.SourceMetadata.GetFileClass()
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Empty(t, chunkSkel.SourceMetadata.GetFileClass(), "the chunk skeleton should not be modified")
+		})
+	}
+}
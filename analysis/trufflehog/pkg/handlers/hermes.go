@@ -0,0 +1,222 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf16"
+
+	"github.com/gabriel-vasile/mimetype"
+
+	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+)
+
+// React Native apps built with the Hermes engine ship their JavaScript bundle (index.android.bundle on Android,
+// main.jsbundle on iOS) compiled to Hermes bytecode, whose string literals are only readable through the string
+// table of the bytecode file. The literals are decoded from it and written one per line before scanning.
+//
+// A bytecode file starts with a 128 byte header, followed by 4 byte aligned tables: the function headers, the
+// string kinds, the identifier hashes, the small string table, the overflow string table and the string storage.
+// This layout is shared by the bytecode versions of every public Hermes release.
+
+const (
+	// hermesStage is the extraction stage of chunks decoded from Hermes bytecode.
+	hermesStage = "hermes"
+
+	hermesMagic = 0x1F1903C103BC1FC6
+
+	// hermesMinVersion is the oldest bytecode version that is decoded, that of the first public Hermes release.
+	hermesMinVersion = 59
+
+	hermesHeaderSize = 128
+	// hermesFuncHeaderSize is the size of a small function header.
+	hermesFuncHeaderSize = 16
+
+	// hermesOverflowLength is the length of a small string table entry whose string is in the overflow table.
+	hermesOverflowLength = 0xff
+
+	// maxHermesSize is the largest bytecode file that is decoded. Larger ones are scanned as is.
+	maxHermesSize = 256 << 20 // 256 MB
+)
+
+func init() {
+	mimetype.Lookup("application/octet-stream").Extend(isHermesBytecode, string(hermesMime), ".hbc")
+}
+
+func isHermesBytecode(raw []byte, _ uint32) bool {
+	return len(raw) >= 8 && binary.LittleEndian.Uint64(raw) == hermesMagic
+}
+
+// decodeHermesContent decodes the string literals of the Hermes bytecode read from reader. It returns them along
+// with metadata recording the hermes extraction stage. If the bytecode can't be decoded, its raw content is
+// returned with the metadata unchanged.
+func decodeHermesContent(
+	ctx logContext.Context,
+	reader mimeTypeReader,
+	metadata *source_metadatapb.MetaData,
+) (mimeTypeReader, *source_metadatapb.MetaData, error) {
+	data, err := io.ReadAll(io.LimitReader(reader, maxHermesSize+1))
+	if err != nil {
+		return reader, metadata, fmt.Errorf("error reading Hermes bytecode: %w", err)
+	}
+	raw := mimeTypeReader{mimeExt: reader.mimeExt, mimeName: reader.mimeName, Reader: bytes.NewReader(data)}
+	if len(data) > maxHermesSize {
+		ctx.Logger().V(3).Info("Hermes bytecode exceeds max size, scanning as is", "limit", maxHermesSize)
+		raw.Reader = io.MultiReader(bytes.NewReader(data), reader)
+		return raw, metadata, nil
+	}
+
+	literals, err := extractHermesStrings(data)
+	if err != nil {
+		ctx.Logger().V(3).Info("failed to decode Hermes bytecode, scanning as is", "error", err)
+		return raw, metadata, nil
+	}
+
+	decoded := withExtractionStage(metadata, hermesStage)
+	return mimeTypeReader{mimeExt: ".txt", mimeName: textMime, Reader: bytes.NewReader(literals)}, decoded, nil
+}
+
+// hermesHeader holds the fields of a bytecode file header that locate its string table.
+type hermesHeader struct {
+	Magic               uint64
+	Version             uint32
+	SourceHash          [20]byte
+	FileLength          uint32
+	GlobalCodeIndex     uint32
+	FunctionCount       uint32
+	StringKindCount     uint32
+	IdentifierCount     uint32
+	StringCount         uint32
+	OverflowStringCount uint32
+	StringStorageSize   uint32
+}
+
+var errHermesTruncated = errors.New("truncated Hermes bytecode")
+
+// extractHermesStrings returns the string literals of a bytecode file, one per line. Identifiers, the names of
+// properties and variables, are left out.
+func extractHermesStrings(data []byte) ([]byte, error) {
+	var hdr hermesHeader
+	if len(data) < hermesHeaderSize {
+		return nil, errHermesTruncated
+	}
+	if err := binary.Read(bytes.NewReader(data), binary.LittleEndian, &hdr); err != nil {
+		return nil, err
+	}
+	if hdr.Magic != hermesMagic {
+		return nil, errors.New("not Hermes bytecode")
+	}
+	if hdr.Version < hermesMinVersion {
+		return nil, fmt.Errorf("unsupported Hermes bytecode version %d", hdr.Version)
+	}
+
+	// The tables are read at offsets computed in 64 bits, so that counts read from a corrupt header can't overflow.
+	table := func(off uint64, count uint32, size uint64) ([]byte, uint64, error) {
+		end := off + uint64(count)*size
+		if end > uint64(len(data)) {
+			return nil, 0, errHermesTruncated
+		}
+		return data[off:end], end, nil
+	}
+	off := uint64(hermesHeaderSize) + uint64(hdr.FunctionCount)*hermesFuncHeaderSize
+	kinds, off, err := table(off, hdr.StringKindCount, 4)
+	if err != nil {
+		return nil, err
+	}
+	off += uint64(hdr.IdentifierCount) * 4
+	small, off, err := table(off, hdr.StringCount, 4)
+	if err != nil {
+		return nil, err
+	}
+	overflow, off, err := table(off, hdr.OverflowStringCount, 8)
+	if err != nil {
+		return nil, err
+	}
+	storage, _, err := table(off, hdr.StringStorageSize, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	literals, err := hermesLiteralStrings(kinds, hdr.StringCount)
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	for id := uint32(0); id < hdr.StringCount; id++ {
+		if !literals[id] {
+			continue
+		}
+		str, err := hermesString(small, overflow, storage, id)
+		if err != nil {
+			return nil, fmt.Errorf("string %d: %w", id, err)
+		}
+		if str == "" {
+			continue
+		}
+		out.WriteString(str)
+		out.WriteByte('\n')
+	}
+	return out.Bytes(), nil
+}
+
+// hermesLiteralStrings decodes the string kinds table, the run lengths of the kinds of the strings in table order,
+// and returns which strings are literals rather than identifiers. The kind is held by the top bits of each entry:
+// older bytecode versions use two bits, for the string, identifier and predefined kinds, and later ones a single
+// bit, for the string and identifier kinds. Literals are the string kind, zero in both.
+func hermesLiteralStrings(kinds []byte, stringCount uint32) ([]bool, error) {
+	const countMask = 1<<30 - 1
+
+	var total uint64
+	for i := 0; i+4 <= len(kinds); i += 4 {
+		total += uint64(binary.LittleEndian.Uint32(kinds[i:]) & countMask)
+	}
+	if total != uint64(stringCount) {
+		return nil, fmt.Errorf("string kinds cover %d strings, not %d", total, stringCount)
+	}
+
+	literals := make([]bool, 0, stringCount)
+	for i := 0; i+4 <= len(kinds); i += 4 {
+		entry := binary.LittleEndian.Uint32(kinds[i:])
+		for n := entry & countMask; n > 0; n-- {
+			literals = append(literals, entry&^countMask == 0)
+		}
+	}
+	return literals, nil
+}
+
+// hermesString decodes a string of the string table. A small string table entry packs, from its lowest bit, a
+// UTF-16 flag, a 23 bit offset into the string storage and an 8 bit length, in code units. Longer strings have the
+// index of their overflow table entry, which holds their offset and length, as offset.
+func hermesString(small, overflow, storage []byte, id uint32) (string, error) {
+	entry := binary.LittleEndian.Uint32(small[4*id:])
+	isUTF16 := entry&1 != 0
+	offset := uint64(entry >> 1 & (1<<23 - 1))
+	length := uint64(entry >> 24)
+	if length == hermesOverflowLength {
+		idx := offset
+		if (idx+1)*8 > uint64(len(overflow)) {
+			return "", errHermesTruncated
+		}
+		offset = uint64(binary.LittleEndian.Uint32(overflow[idx*8:]))
+		length = uint64(binary.LittleEndian.Uint32(overflow[idx*8+4:]))
+	}
+
+	if !isUTF16 {
+		if offset+length > uint64(len(storage)) {
+			return "", errHermesTruncated
+		}
+		return string(storage[offset : offset+length]), nil
+	}
+	if offset+2*length > uint64(len(storage)) {
+		return "", errHermesTruncated
+	}
+	units := make([]uint16, length)
+	for i := range units {
+		units[i] = binary.LittleEndian.Uint16(storage[offset+2*uint64(i):])
+	}
+	return string(utf16.Decode(units)), nil
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+type testHermesString struct {
+	value      string
+	identifier bool
+	utf16      bool
+}
+
+// buildTestHBC builds a bytecode file of the given version with one function and the given strings.
+func buildTestHBC(t *testing.T, version uint32, strs []testHermesString) []byte {
+	t.Helper()
+	le := binary.LittleEndian
+
+	var kinds, small, overflow, storage []byte
+	for _, s := range strs {
+		offset, length := uint32(len(storage)), uint32(len(s.value))
+		var isUTF16 uint32
+		if s.utf16 {
+			isUTF16 = 1
+			units := utf16.Encode([]rune(s.value))
+			length = uint32(len(units))
+			for _, u := range units {
+				storage = le.AppendUint16(storage, u)
+			}
+		} else {
+			storage = append(storage, s.value...)
+		}
+		if length >= hermesOverflowLength {
+			overflow = le.AppendUint32(overflow, offset)
+			overflow = le.AppendUint32(overflow, length)
+			offset, length = uint32(len(overflow)/8-1), hermesOverflowLength
+		}
+		small = le.AppendUint32(small, isUTF16|offset<<1|length<<24)
+
+		// Each string gets its own run of the string kinds table.
+		var kind uint32
+		if s.identifier {
+			kind = 1 << 31
+		}
+		kinds = le.AppendUint32(kinds, kind|1)
+	}
+
+	header := make([]byte, hermesHeaderSize)
+	le.PutUint64(header, hermesMagic)
+	le.PutUint32(header[8:], version)
+	for off, v := range map[int]uint32{
+		40: 1, // functionCount
+		44: uint32(len(kinds) / 4),
+		48: 0, // identifierCount
+		52: uint32(len(strs)),
+		56: uint32(len(overflow) / 8),
+		60: uint32(len(storage)),
+	} {
+		le.PutUint32(header[off:], v)
+	}
+
+	out := append(header, make([]byte, hermesFuncHeaderSize)...)
+	for _, table := range [][]byte{kinds, small, overflow, storage} {
+		out = append(out, table...)
+	}
+	le.PutUint32(out[32:], uint32(len(out)))
+	return out
+}
+
+func TestHandleFileHermes(t *testing.T) {
+	long := "long-" + strings.Repeat("x", 300) + "-secret"
+	hbc := buildTestHBC(t, 96, []testHermesString{
+		{value: "apiKey", identifier: true},
+		{value: "sk-literal-secret"},
+		{value: "clé-secrète", utf16: true},
+		{value: long},
+		{value: "fetch", identifier: true},
+	})
+
+	chunkCh := make(chan *sources.Chunk, 8)
+	err := HandleFile(context.Background(), bytes.NewReader(hbc), &sources.Chunk{}, sources.ChanReporter{Ch: chunkCh})
+	require.NoError(t, err)
+	close(chunkCh)
+
+	var chunks []*sources.Chunk
+	for chunk := range chunkCh {
+		chunks = append(chunks, chunk)
+	}
+	require.Len(t, chunks, 1)
+	assert.Equal(t, "sk-literal-secret\nclé-secrète\n"+long+"\n", string(chunks[0].Data))
+	assert.Equal(t, hermesStage, chunks[0].SourceMetadata.GetExtraction().GetStage())
+}
+
+func TestExtractHermesStrings(t *testing.T) {
+	strs := []testHermesString{{value: "literal"}, {value: "ident", identifier: true}}
+
+	t.Run("unsupported version", func(t *testing.T) {
+		_, err := extractHermesStrings(buildTestHBC(t, 51, strs))
+		assert.Error(t, err)
+	})
+
+	t.Run("truncated string table", func(t *testing.T) {
+		hbc := buildTestHBC(t, 84, strs)
+		_, err := extractHermesStrings(hbc[:len(hbc)-8])
+		assert.Error(t, err)
+	})
+
+	t.Run("two bit kinds", func(t *testing.T) {
+		// Older versions mark identifiers with the second highest bit.
+		hbc := buildTestHBC(t, 59, strs)
+		kindsOff := hermesHeaderSize + hermesFuncHeaderSize
+		binary.LittleEndian.PutUint32(hbc[kindsOff+4:], 1<<30|1)
+		out, err := extractHermesStrings(hbc)
+		require.NoError(t, err)
+		assert.Equal(t, "literal\n", string(out))
+	})
+}
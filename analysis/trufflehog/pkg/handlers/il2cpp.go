@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/gabriel-vasile/mimetype"
+
+	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+)
+
+// Unity games built with IL2CPP keep every C# string literal in global-metadata.dat, as a table of string literal
+// entries pointing into a blob of UTF-8 data in which the literals follow each other without separators. Scanning
+// the raw file splices unrelated literals together, so the literals are decoded and written one per line.
+//
+// The header starts with the magic and the metadata version, followed by the offset and size of each table, the
+// string literal table and its data being the first two in every version. Up to version 31, a string literal entry
+// is its length and the index of its data. Later versions only keep the index, the length being the distance to the
+// data of the next literal.
+
+const (
+	// il2cppStage is the extraction stage of chunks decoded from IL2CPP metadata.
+	il2cppStage = "il2cpp"
+
+	il2cppMetadataMagic = 0xfab11baf
+
+	// il2cppMinVersion is the oldest metadata version that is decoded, that of Unity 5.3.
+	il2cppMinVersion = 16
+	// il2cppMaxLengthVersion is the last metadata version whose string literal entries record their length.
+	il2cppMaxLengthVersion = 31
+
+	// maxIL2CPPSize is the largest metadata file that is decoded. Larger ones are scanned as is.
+	maxIL2CPPSize = 256 << 20 // 256 MB
+)
+
+func init() {
+	mimetype.Lookup("application/octet-stream").Extend(isIL2CPPMetadata, string(il2cppMime), ".dat")
+}
+
+func isIL2CPPMetadata(raw []byte, _ uint32) bool {
+	return len(raw) >= 8 && binary.LittleEndian.Uint32(raw) == il2cppMetadataMagic
+}
+
+// decodeIL2CPPContent decodes the string literals of the IL2CPP metadata read from reader. It returns them along
+// with metadata recording the il2cpp extraction stage and the metadata version. If the metadata can't be decoded,
+// its raw content is returned with the metadata unchanged.
+func decodeIL2CPPContent(
+	ctx logContext.Context,
+	reader mimeTypeReader,
+	metadata *source_metadatapb.MetaData,
+) (mimeTypeReader, *source_metadatapb.MetaData, error) {
+	data, err := io.ReadAll(io.LimitReader(reader, maxIL2CPPSize+1))
+	if err != nil {
+		return reader, metadata, fmt.Errorf("error reading IL2CPP metadata: %w", err)
+	}
+	raw := mimeTypeReader{mimeExt: reader.mimeExt, mimeName: reader.mimeName, Reader: bytes.NewReader(data)}
+	if len(data) > maxIL2CPPSize {
+		ctx.Logger().V(3).Info("IL2CPP metadata exceeds max size, scanning as is", "limit", maxIL2CPPSize)
+		raw.Reader = io.MultiReader(bytes.NewReader(data), reader)
+		return raw, metadata, nil
+	}
+
+	literals, version, err := extractIL2CPPStrings(data)
+	if err != nil {
+		ctx.Logger().V(3).Info("failed to decode IL2CPP metadata, scanning as is", "error", err)
+		return raw, metadata, nil
+	}
+
+	decoded := withExtractionStage(metadata, il2cppStage)
+	decoded.Extraction.FormatVersion = fmt.Sprint(version)
+	return mimeTypeReader{mimeExt: ".txt", mimeName: textMime, Reader: bytes.NewReader(literals)}, decoded, nil
+}
+
+var errIL2CPPTruncated = errors.New("truncated IL2CPP metadata")
+
+// extractIL2CPPStrings returns the string literals of a metadata file, one per line, along with its version.
+func extractIL2CPPStrings(data []byte) ([]byte, uint32, error) {
+	if len(data) < 24 || binary.LittleEndian.Uint32(data) != il2cppMetadataMagic {
+		return nil, 0, errors.New("not IL2CPP metadata")
+	}
+	le := binary.LittleEndian
+	version := le.Uint32(data[4:])
+	if version < il2cppMinVersion {
+		return nil, version, fmt.Errorf("unsupported IL2CPP metadata version %d", version)
+	}
+
+	section := func(off int) ([]byte, error) {
+		start, size := uint64(le.Uint32(data[off:])), uint64(le.Uint32(data[off+4:]))
+		if start+size > uint64(len(data)) {
+			return nil, errIL2CPPTruncated
+		}
+		return data[start : start+size], nil
+	}
+	table, err := section(8)
+	if err != nil {
+		return nil, version, err
+	}
+	strData, err := section(16)
+	if err != nil {
+		return nil, version, err
+	}
+
+	var out bytes.Buffer
+	write := func(start, end uint64) error {
+		if start > end || end > uint64(len(strData)) {
+			return errIL2CPPTruncated
+		}
+		if start < end {
+			out.Write(strData[start:end])
+			out.WriteByte('\n')
+		}
+		return nil
+	}
+
+	if version <= il2cppMaxLengthVersion {
+		for i := 0; i+8 <= len(table); i += 8 {
+			length, start := uint64(le.Uint32(table[i:])), uint64(le.Uint32(table[i+4:]))
+			if err := write(start, start+length); err != nil {
+				return nil, version, err
+			}
+		}
+		return out.Bytes(), version, nil
+	}
+
+	for i := 0; i+4 <= len(table); i += 4 {
+		start, end := uint64(le.Uint32(table[i:])), uint64(len(strData))
+		if i+8 <= len(table) {
+			end = uint64(le.Uint32(table[i+4:]))
+		}
+		if err := write(start, end); err != nil {
+			return nil, version, err
+		}
+	}
+	return out.Bytes(), version, nil
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+// buildTestIL2CPPMetadata builds a metadata file of the given version whose string literal table holds literals.
+func buildTestIL2CPPMetadata(version uint32, literals ...string) []byte {
+	le := binary.LittleEndian
+
+	var table, data []byte
+	for _, literal := range literals {
+		if version <= il2cppMaxLengthVersion {
+			table = le.AppendUint32(table, uint32(len(literal)))
+		}
+		table = le.AppendUint32(table, uint32(len(data)))
+		data = append(data, literal...)
+	}
+
+	const headerSize = 32
+	out := le.AppendUint32(nil, il2cppMetadataMagic)
+	out = le.AppendUint32(out, version)
+	for _, v := range []int{headerSize, len(table), headerSize + len(table), len(data)} {
+		out = le.AppendUint32(out, uint32(v))
+	}
+	// The identifier strings, which aren't scanned.
+	out = le.AppendUint32(out, 0)
+	out = le.AppendUint32(out, 0)
+	return append(append(out, table...), data...)
+}
+
+func TestHandleFileIL2CPPMetadata(t *testing.T) {
+	tests := map[string]uint32{"version 24": 24, "version 29": 29, "version 31": 31, "version 35": 35}
+	for name, version := range tests {
+		t.Run(name, func(t *testing.T) {
+			metadata := buildTestIL2CPPMetadata(version, "https://api.example.com", "unity-secret", "", "Hello")
+
+			chunkCh := make(chan *sources.Chunk, 8)
+			err := HandleFile(context.Background(), bytes.NewReader(metadata), &sources.Chunk{}, sources.ChanReporter{Ch: chunkCh})
+			require.NoError(t, err)
+			close(chunkCh)
+
+			var chunks []*sources.Chunk
+			for chunk := range chunkCh {
+				chunks = append(chunks, chunk)
+			}
+			require.Len(t, chunks, 1)
+			assert.Equal(t, "https://api.example.com\nunity-secret\nHello\n", string(chunks[0].Data))
+			extraction := chunks[0].SourceMetadata.GetExtraction()
+			assert.Equal(t, il2cppStage, extraction.GetStage())
+			assert.Equal(t, name[len("version "):], extraction.GetFormatVersion())
+		})
+	}
+}
+
+func TestExtractIL2CPPStrings(t *testing.T) {
+	_, _, err := extractIL2CPPStrings(buildTestIL2CPPMetadata(15, "old"))
+	assert.Error(t, err)
+
+	truncated := buildTestIL2CPPMetadata(24, "unity-secret")
+	_, _, err = extractIL2CPPStrings(truncated[:len(truncated)-4])
+	assert.Error(t, err)
+}
@@ -0,0 +1,313 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+	"howett.net/plist"
+
+	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+)
+
+// An IPA is a zip archive with the app bundle stored under Payload/<name>.app/. The bundle holds the main executable
+// and Info.plist at its root, and can nest further bundles: frameworks in Frameworks/*.framework and app extensions
+// in PlugIns/*.appex, each with its own Info.plist and executable.
+
+var (
+	// ipaAppRootRegex matches the app bundle directory at the start of the paths within it.
+	ipaAppRootRegex = regexp.MustCompile(`^Payload/[^/]+\.app/`)
+	// ipaAppInfoRegex matches the Info.plist of the app bundle.
+	ipaAppInfoRegex = regexp.MustCompile(`^Payload/[^/]+\.app/Info\.plist$`)
+)
+
+// ipaNestedBundleExts are the extensions of the bundles that can be nested within the app bundle.
+var ipaNestedBundleExts = []string{".app", ".appex", ".framework", ".bundle"}
+
+// maxInfoPlistSize is the largest Info.plist that is parsed for the identity of a bundle.
+const maxInfoPlistSize = 4 << 20 // 4 MB
+
+// ipaBundle is the identity of an app bundle, or of a bundle nested within it.
+type ipaBundle struct {
+	// root is the path of the bundle directory within the IPA, including the trailing slash.
+	root       string
+	id         string
+	version    string
+	executable string
+}
+
+// ipaInfoPlist holds the Info.plist keys that identify a bundle.
+type ipaInfoPlist struct {
+	BundleIdentifier   string `plist:"CFBundleIdentifier"`
+	BundleShortVersion string `plist:"CFBundleShortVersionString"`
+	BundleVersion      string `plist:"CFBundleVersion"`
+	BundleExecutable   string `plist:"CFBundleExecutable"`
+}
+
+// ipaHandler handles iOS app archives.
+type ipaHandler struct{ *defaultHandler }
+
+// newIPAHandler creates an ipaHandler.
+func newIPAHandler() *ipaHandler {
+	return &ipaHandler{defaultHandler: newDefaultHandler(ipaHandlerType)}
+}
+
+// HandleFile processes ipa formatted files.
+// Fatal errors that will stop processing:
+// - Unable to create ZIP reader from input
+// - No app bundle found in the archive
+// - Panics during processing (recovered but returned as errors)
+//
+// Non-fatal errors that will be logged and continue processing:
+// - Failed to parse the Info.plist of a bundle
+// - Failed to process individual files within the IPA
+func (h *ipaHandler) HandleFile(ctx logContext.Context, input fileReader) chan DataOrErr {
+	ipaChan := make(chan DataOrErr, defaultBufferSize)
+
+	go func() {
+		defer close(ipaChan)
+
+		// Defer a panic recovery to handle any panics that occur during the IPA processing.
+		defer func() {
+			if r := recover(); r != nil {
+				// Return the panic as an error.
+				var panicErr error
+				if e, ok := r.(error); ok {
+					panicErr = e
+				} else {
+					panicErr = fmt.Errorf("panic occurred: %v", r)
+				}
+				ctx.Logger().Error(panicErr, "Panic occurred when reading ipa archive")
+			}
+		}()
+
+		start := time.Now()
+		err := h.processIPA(ctx, input, ipaChan)
+		if err == nil {
+			h.metrics.incFilesProcessed()
+		}
+
+		h.measureLatencyAndHandleErrors(ctx, start, err, ipaChan)
+	}()
+
+	return ipaChan
+}
+
+// processIPA processes the ipa file and sends the extracted data to the provided channel.
+func (h *ipaHandler) processIPA(ctx logContext.Context, input fileReader, ipaChan chan DataOrErr) error {
+	zipReader, err := createZipReader(input)
+	if err != nil {
+		return err
+	}
+
+	bundles, err := h.parseBundles(ctx, zipReader)
+	if err != nil {
+		return err
+	}
+	app := bundles[len(bundles)-1]
+	ctx = logContext.WithValues(ctx, "bundle_id", app.id, "bundle_version", app.version)
+
+	for _, file := range zipReader.File {
+		if file.FileInfo().IsDir() || file.UncompressedSize64 == 0 {
+			continue
+		}
+		if err := h.processFile(ctx, file, app, bundles, ipaChan); err != nil {
+			ctx.Logger().V(2).Info(fmt.Sprintf("failed to process file: %s", file.Name), "error", err)
+		}
+	}
+	return nil
+}
+
+// parseBundles parses the Info.plist of the app bundle and of the bundles nested within it.
+// The bundles are returned with the most deeply nested first, so the first bundle whose root
+// prefixes a path is the innermost bundle containing it. The app bundle is always last.
+func (h *ipaHandler) parseBundles(ctx logContext.Context, zipReader *zip.Reader) ([]ipaBundle, error) {
+	var appRoot string
+	var infoFiles []*zip.File
+	for _, file := range zipReader.File {
+		if appRoot == "" {
+			appRoot = ipaAppRootRegex.FindString(file.Name)
+		}
+		dir, name := path.Split(file.Name)
+		if name == "Info.plist" && strings.HasPrefix(dir, "Payload/") && isIPABundleDir(dir) {
+			infoFiles = append(infoFiles, file)
+		}
+	}
+	if appRoot == "" {
+		return nil, errors.New("app bundle not found in the IPA archive")
+	}
+
+	app := ipaBundle{root: appRoot}
+	var nested []ipaBundle
+	for _, file := range infoFiles {
+		if !strings.HasPrefix(file.Name, appRoot) {
+			continue
+		}
+		bundle, err := parseIPABundle(file)
+		if err != nil {
+			ctx.Logger().V(2).Info("failed to parse bundle Info.plist", "filename", file.Name, "error", err)
+			continue
+		}
+		if bundle.root == appRoot {
+			app = bundle
+			continue
+		}
+		nested = append(nested, bundle)
+	}
+
+	sort.SliceStable(nested, func(i, j int) bool { return len(nested[i].root) > len(nested[j].root) })
+	return append(nested, app), nil
+}
+
+// isIPABundleDir returns true if dir, a directory path with a trailing slash, is a bundle directory.
+func isIPABundleDir(dir string) bool {
+	ext := path.Ext(strings.TrimSuffix(dir, "/"))
+	for _, bundleExt := range ipaNestedBundleExts {
+		if ext == bundleExt {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIPABundle reads the identity of a bundle from its Info.plist, which can be in either the XML or binary format.
+func parseIPABundle(file *zip.File) (ipaBundle, error) {
+	f, err := openFile(file)
+	if err != nil {
+		return ipaBundle{}, err
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxInfoPlistSize))
+	if err != nil {
+		return ipaBundle{}, err
+	}
+
+	var info ipaInfoPlist
+	if _, err := plist.Unmarshal(data, &info); err != nil {
+		return ipaBundle{}, err
+	}
+
+	version := info.BundleShortVersion
+	if version == "" {
+		version = info.BundleVersion
+	}
+	return ipaBundle{
+		root:       path.Dir(file.Name) + "/",
+		id:         info.BundleIdentifier,
+		version:    version,
+		executable: info.BundleExecutable,
+	}, nil
+}
+
+// processFile decodes a file of the IPA based on its place in the bundle structure,
+// and sends the extracted data to the provided channel.
+func (h *ipaHandler) processFile(
+	ctx logContext.Context,
+	file *zip.File,
+	app ipaBundle,
+	bundles []ipaBundle,
+	ipaChan chan DataOrErr,
+) error {
+	// Files outside the app bundle, such as iTunesMetadata.plist and SwiftSupport/, keep their archive path.
+	bundlePath := strings.TrimPrefix(file.Name, app.root)
+	bundle := app
+	for _, b := range bundles {
+		if strings.HasPrefix(file.Name, b.root) {
+			bundle = b
+			break
+		}
+	}
+
+	f, err := openFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", file.Name, err)
+	}
+	defer f.Close()
+
+	var contentReader io.Reader
+	switch {
+	case path.Base(file.Name) == "embedded.mobileprovision":
+		contentReader, err = decodeMobileProvision(f)
+		if err != nil {
+			return fmt.Errorf("failed to decode provisioning profile %s: %w", file.Name, err)
+		}
+	case path.Ext(file.Name) == ".strings" && path.Ext(path.Dir(file.Name)) == ".lproj":
+		contentReader = decodeStringsFile(f)
+	default:
+		contentReader = f
+	}
+
+	mimeReader, err := newMimeTypeReader(contentReader)
+	if err != nil {
+		return fmt.Errorf("failed to create mimeTypeReader for file %s: %w", file.Name, err)
+	}
+	ctx = logContext.WithValues(
+		ctx,
+		"filename", bundlePath,
+		"executable", file.Name == bundle.root+bundle.executable,
+	)
+	metadata := &source_metadatapb.MetaData{
+		AppBundle: &source_metadatapb.AppBundle{
+			BundleId:      bundle.id,
+			BundleVersion: bundle.version,
+			BundlePath:    bundlePath,
+		},
+		Extraction: &source_metadatapb.Extraction{EntryPath: file.Name},
+	}
+	return h.handleNonArchiveContent(ctx, mimeReader, metadata, ipaChan)
+}
+
+// decodeMobileProvision extracts the XML property list of a provisioning profile from its signed CMS envelope.
+// The profile is returned unchanged if it does not contain one.
+func decodeMobileProvision(rdr io.Reader) (io.Reader, error) {
+	data, err := io.ReadAll(rdr)
+	if err != nil {
+		return nil, err
+	}
+
+	start := bytes.Index(data, []byte("<?xml"))
+	end := bytes.LastIndex(data, []byte("</plist>"))
+	if start < 0 || end < start {
+		return bytes.NewReader(data), nil
+	}
+	return bytes.NewReader(data[start : end+len("</plist>")]), nil
+}
+
+// decodeStringsFile decodes a localized .strings file written as UTF-16 text, which Xcode does by default,
+// to UTF-8. Other .strings files, such as compiled binary property lists, are returned unchanged.
+func decodeStringsFile(rdr io.Reader) io.Reader {
+	bufReader := bufio.NewReader(rdr)
+	bom, _ := bufReader.Peek(2)
+	if !bytes.Equal(bom, []byte{0xff, 0xfe}) && !bytes.Equal(bom, []byte{0xfe, 0xff}) {
+		return bufReader
+	}
+	decoder := unicode.UTF16(unicode.LittleEndian, unicode.ExpectBOM).NewDecoder()
+	return transform.NewReader(bufReader, decoder)
+}
+
+// isIPALayout checks whether a zip file is an IPA by its Payload/<name>.app layout.
+// Files with an .ipa extension only need to contain an app bundle, while other zip files need its Info.plist.
+func isIPALayout(cfg readerConfig, zipReader *zip.Reader) bool {
+	layout := ipaAppInfoRegex
+	if cfg.appArchiveExtension() == ipaExt {
+		layout = ipaAppRootRegex
+	}
+	for _, file := range zipReader.File {
+		if layout.MatchString(file.Name) {
+			return true
+		}
+	}
+	return false
+}
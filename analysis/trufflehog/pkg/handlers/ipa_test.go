@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/unicode"
+	"howett.net/plist"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/feature"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+func buildTestZip(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, data := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write(data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func buildTestInfoPlist(t *testing.T, format int, id, version, executable string) []byte {
+	t.Helper()
+
+	data, err := plist.Marshal(map[string]string{
+		"CFBundleIdentifier":         id,
+		"CFBundleShortVersionString": version,
+		"CFBundleVersion":            "42",
+		"CFBundleExecutable":         executable,
+	}, format)
+	require.NoError(t, err)
+	return data
+}
+
+func buildTestIPA(t *testing.T) []byte {
+	t.Helper()
+
+	macho := []byte("\xcf\xfa\xed\xfe\x0c\x00\x00\x01\x00\x00\x00\x00")
+	localizable, err := unicode.UTF16(unicode.LittleEndian, unicode.UseBOM).NewEncoder().
+		Bytes([]byte(`"api_key" = "strings-secret";`))
+	require.NoError(t, err)
+	profile := append([]byte("\x30\x82\x10\x00\x06\x09"),
+		[]byte(`<?xml version="1.0" encoding="UTF-8"?><plist version="1.0"><dict><key>TeamName</key><string>profile-secret</string></dict></plist>`)...)
+	profile = append(profile, 0xa0, 0x82, 0x00)
+	compiledStrings, err := plist.Marshal(map[string]string{"api_key": "compiled-secret"}, plist.BinaryFormat)
+	require.NoError(t, err)
+
+	return buildTestZip(t, map[string][]byte{
+		"iTunesMetadata.plist":                                  []byte("itunes-secret"),
+		"Payload/Leaky.app/Info.plist":                          buildTestInfoPlist(t, plist.BinaryFormat, "com.example.leaky", "1.2.3", "Leaky"),
+		"Payload/Leaky.app/Leaky":                               append(macho, []byte("executable-secret")...),
+		"Payload/Leaky.app/embedded.mobileprovision":            profile,
+		"Payload/Leaky.app/en.lproj/Localizable.strings":        localizable,
+		"Payload/Leaky.app/fr.lproj/Localizable.strings":        compiledStrings,
+		"Payload/Leaky.app/Frameworks/Kit.framework/Info.plist": buildTestInfoPlist(t, plist.XMLFormat, "com.example.kit", "4.5", "Kit"),
+		"Payload/Leaky.app/Frameworks/Kit.framework/Kit":        append(macho, []byte("framework-secret")...),
+		"Payload/Leaky.app/PlugIns/Widget.appex/Info.plist":     buildTestInfoPlist(t, plist.XMLFormat, "com.example.leaky.widget", "1.2.3", "Widget"),
+		"Payload/Leaky.app/PlugIns/Widget.appex/Widget":         append(macho, []byte("plugin-secret")...),
+	})
+}
+
+func TestHandleFileIPA(t *testing.T) {
+	feature.EnableIPAHandler.Store(true)
+	t.Cleanup(func() { feature.EnableIPAHandler.Store(false) })
+
+	chunkSkel := &sources.Chunk{
+		SourceMetadata: &source_metadatapb.MetaData{
+			Data: &source_metadatapb.MetaData_Filesystem{Filesystem: &source_metadatapb.Filesystem{File: "Leaky.zip"}},
+		},
+	}
+	chunkCh := make(chan *sources.Chunk, 32)
+	err := HandleFile(context.Background(), bytes.NewReader(buildTestIPA(t)), chunkSkel, sources.ChanReporter{Ch: chunkCh})
+	require.NoError(t, err)
+	close(chunkCh)
+
+	chunks := make(map[string]*sources.Chunk)
+	for chunk := range chunkCh {
+		bundle := chunk.SourceMetadata.GetAppBundle()
+		require.NotNil(t, bundle)
+		assert.Equal(t, "Leaky.zip", chunk.SourceMetadata.GetFilesystem().GetFile())
+		chunks[bundle.GetBundlePath()] = chunk
+	}
+
+	tests := []struct {
+		path     string
+		bundleID string
+		version  string
+		contains string
+	}{
+		{path: "Info.plist", bundleID: "com.example.leaky", version: "1.2.3", contains: "CFBundleIdentifier = com.example.leaky"},
+		{path: "Leaky", bundleID: "com.example.leaky", version: "1.2.3", contains: "executable-secret"},
+		{path: "embedded.mobileprovision", bundleID: "com.example.leaky", version: "1.2.3", contains: "<string>profile-secret</string></dict></plist>"},
+		{path: "en.lproj/Localizable.strings", bundleID: "com.example.leaky", version: "1.2.3", contains: `"api_key" = "strings-secret";`},
+		{path: "fr.lproj/Localizable.strings", bundleID: "com.example.leaky", version: "1.2.3", contains: "api_key = compiled-secret"},
+		{path: "Frameworks/Kit.framework/Kit", bundleID: "com.example.kit", version: "4.5", contains: "framework-secret"},
+		{path: "PlugIns/Widget.appex/Widget", bundleID: "com.example.leaky.widget", version: "1.2.3", contains: "plugin-secret"},
+		{path: "iTunesMetadata.plist", bundleID: "com.example.leaky", version: "1.2.3", contains: "itunes-secret"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			chunk, ok := chunks[tt.path]
+			require.True(t, ok, "no chunk for %s", tt.path)
+			assert.Equal(t, tt.bundleID, chunk.SourceMetadata.GetAppBundle().GetBundleId())
+			assert.Equal(t, tt.version, chunk.SourceMetadata.GetAppBundle().GetBundleVersion())
+			assert.Contains(t, string(chunk.Data), tt.contains)
+		})
+	}
+	// The profile's signature is stripped.
+	assert.True(t, bytes.HasPrefix(chunks["embedded.mobileprovision"].Data, []byte("<?xml")))
+	// The skeleton's metadata is shared, so it must not be modified.
+	assert.Nil(t, chunkSkel.SourceMetadata.GetAppBundle())
+}
+
+func TestIsIPAFile(t *testing.T) {
+	feature.EnableIPAHandler.Store(true)
+	t.Cleanup(func() { feature.EnableIPAHandler.Store(false) })
+
+	tests := map[string]struct {
+		files   map[string][]byte
+		options []readerOption
+		want    bool
+	}{
+		"app bundle": {
+			files: map[string][]byte{"Payload/Leaky.app/Info.plist": []byte("<plist/>")},
+			want:  true,
+		},
+		"app bundle without Info.plist": {
+			files: map[string][]byte{"Payload/Leaky.app/Leaky": []byte("binary")},
+			want:  false,
+		},
+		"app bundle without Info.plist with ipa extension": {
+			files:   map[string][]byte{"Payload/Leaky.app/Leaky": []byte("binary")},
+			options: []readerOption{withFileExtension(ipaExt)},
+			want:    true,
+		},
+		"plain zip with ipa extension": {
+			files:   map[string][]byte{"README.md": []byte("readme")},
+			options: []readerOption{withFileExtension(ipaExt)},
+			want:    false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			rdr, err := newFileReader(bytes.NewReader(buildTestZip(t, tt.files)), tt.options...)
+			require.NoError(t, err)
+			defer rdr.Close()
+
+			assert.Equal(t, tt.want, rdr.mime.String() == string(ipaMime))
+			if !tt.want {
+				// The reader must be rewound for the archive handler.
+				assert.True(t, rdr.isGenericArchive)
+			}
+		})
+	}
+}
+
+func TestNewFileReaderAppArchiveEntry(t *testing.T) {
+	feature.EnableIPAHandler.Store(true)
+	t.Cleanup(func() { feature.EnableIPAHandler.Store(false) })
+
+	data := buildTestZip(t, map[string][]byte{"Payload/Leaky.app/Info.plist": []byte("<plist/>")})
+	tests := map[string]struct {
+		options []readerOption
+		want    bool
+	}{
+		"file with jar extension":          {options: []readerOption{withFileExtension(".jar")}, want: true},
+		"entry with jar extension":         {options: []readerOption{withEntryName("lib/leaky.jar")}, want: false},
+		"entry with zip extension":         {options: []readerOption{withEntryName("Leaky.ZIP")}, want: true},
+		"entry with ipa extension":         {options: []readerOption{withEntryName("Leaky.ipa")}, want: true},
+		"entry without extension":          {options: []readerOption{withEntryName("Leaky")}, want: true},
+		"entry of file with ipa extension": {options: []readerOption{withFileExtension(ipaExt), withEntryName("leaky.jar")}, want: false},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			rdr, err := newFileReader(bytes.NewReader(data), tt.options...)
+			require.NoError(t, err)
+			defer rdr.Close()
+
+			assert.Equal(t, tt.want, rdr.mime.String() == string(ipaMime))
+			if !tt.want {
+				// Nested jars aren't opened to check for an app layout.
+				assert.Nil(t, rdr.zipReader)
+				return
+			}
+			// The handler reuses the zip reader opened to detect the IPA.
+			zipReader, err := createZipReader(rdr)
+			require.NoError(t, err)
+			assert.Same(t, rdr.zipReader, zipReader)
+		})
+	}
+}
+
+func TestNewFileReaderUnreadableZip(t *testing.T) {
+	feature.EnableAPKHandler.Store(true)
+	feature.EnableIPAHandler.Store(true)
+	t.Cleanup(func() {
+		feature.EnableAPKHandler.Store(false)
+		feature.EnableIPAHandler.Store(false)
+	})
+
+	// Without its end of central directory record, the zip can't be checked for an app layout.
+	data := buildTestZip(t, map[string][]byte{"Payload/Leaky.app/Info.plist": []byte("<plist/>")})
+	data = data[:len(data)-22]
+
+	for _, ext := range []string{"", aabExt, ipaExt} {
+		rdr, err := newFileReader(bytes.NewReader(data), withFileExtension(ext))
+		require.NoError(t, err, ext)
+		assert.Equal(t, string(zipMime), rdr.mime.String(), ext)
+		rdr.Close()
+	}
+}
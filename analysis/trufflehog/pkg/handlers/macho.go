@@ -0,0 +1,292 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"debug/macho"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf16"
+
+	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/iobuf"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+)
+
+// Mach-O executables, such as the ones in iOS and macOS app bundles, keep their string literals in dedicated
+// sections: C and Objective-C strings in __TEXT,__cstring, non-ASCII literals as UTF-16 in __TEXT,__ustring and
+// Swift type and field names in __TEXT,__swift5_reflstr. NSString literals are __cfstring structures pointing at
+// those strings. Scanning the strings rather than the whole binary avoids the noise of machine code, and lets
+// results be attributed to the section and address of the literal they were found in.
+
+// machoStage is the extraction stage of chunks extracted from Mach-O string sections.
+const machoStage = "macho"
+
+// machoCStringSections are the sections holding NUL terminated strings.
+var machoCStringSections = map[string]struct{}{
+	"__cstring":        {},
+	"__swift5_reflstr": {},
+}
+
+const machoUStringSection = "__ustring"
+
+// machoCFStringSection holds the CFString structures of NSString literals. Each one is four pointer sized fields:
+// the class, the flags, a pointer to the characters and their count.
+const machoCFStringSection = "__cfstring"
+
+// machoCFStringUTF16 is the flags value of a CFString literal whose characters are UTF-16.
+const machoCFStringUTF16 = 0x7d0
+
+// handleMachOContent scans the strings of a thin or universal Mach-O file. Each chunk is tagged with the section and
+// address of its first byte, from which the engine locates the string of each result.
+// It returns false, with rdr rewound, if the content couldn't be parsed as a Mach-O file.
+func (h *defaultHandler) handleMachOContent(
+	ctx logContext.Context,
+	rdr *iobuf.BufferedReadSeeker,
+	metadata *source_metadatapb.MetaData,
+	dataOrErrChan chan DataOrErr,
+) (bool, error) {
+	files, closer, err := openMachO(rdr)
+	if err != nil {
+		ctx.Logger().V(3).Info("failed to parse Mach-O file, scanning as is", "error", err)
+		if _, err := rdr.Seek(0, io.SeekStart); err != nil {
+			return true, fmt.Errorf("%w: error resetting Mach-O reader: %v", ErrProcessingWarning, err)
+		}
+		return false, nil
+	}
+	defer closer.Close()
+
+	// The strings of Flutter apps are objects of their Dart snapshots rather than strings of their sections.
+	var snapshots []dartSnapshot
+	for _, f := range files {
+		snapshots = append(snapshots, machoDartSnapshots(ctx, f)...)
+	}
+	if len(snapshots) > 0 {
+		if parsed, err := h.handleDartSnapshots(ctx, snapshots, metadata, dataOrErrChan); parsed {
+			return true, err
+		}
+	}
+
+	// The architectures of a universal binary mostly share their strings, so each one is only scanned once.
+	seen := make(map[[sha256.Size]byte]struct{})
+	for _, f := range files {
+		for _, strs := range extractMachOStrings(ctx, f) {
+			key := sha256.Sum256(append([]byte(strs.section+"\x00"), strs.data...))
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+
+			if err := h.writeSectionStrings(ctx, machoStage, strs, metadata, dataOrErrChan); err != nil {
+				return true, err
+			}
+		}
+	}
+	return true, nil
+}
+
+// openMachO parses every architecture of a universal binary, or the single one of a thin binary.
+func openMachO(r io.ReaderAt) ([]*macho.File, io.Closer, error) {
+	fat, err := macho.NewFatFile(r)
+	if err == nil {
+		files := make([]*macho.File, 0, len(fat.Arches))
+		for _, arch := range fat.Arches {
+			files = append(files, arch.File)
+		}
+		return files, fat, nil
+	}
+	if !errors.Is(err, macho.ErrNotFat) {
+		return nil, nil, err
+	}
+
+	f, err := macho.NewFile(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	return []*macho.File{f}, f, nil
+}
+
+// machoImage is a single architecture of a Mach-O file.
+type machoImage struct {
+	*macho.File
+	// base is the address the file is loaded at, which relative pointers are based on.
+	base     uint64
+	sections map[*macho.Section][]byte
+}
+
+// extractMachOStrings extracts the strings of a Mach-O file's string sections, and the NSString literals stored
+// elsewhere.
+//
+// NUL terminated strings are returned as a copy of their section with each NUL replaced by a newline, so the
+// offset of a string within the data is its offset within the section. UTF-16 strings and NSString literals are
+// decoded and batched into one run per section, one per line, along with the address of each line.
+func extractMachOStrings(ctx logContext.Context, f *macho.File) []sectionStrings {
+	img := &machoImage{File: f, sections: make(map[*macho.Section][]byte)}
+	if text := f.Segment("__TEXT"); text != nil {
+		img.base = text.Addr
+	}
+
+	var out []sectionStrings
+	var cfstrings []*macho.Section
+	for _, sec := range f.Sections {
+		if sec.Name == machoCFStringSection {
+			cfstrings = append(cfstrings, sec)
+			continue
+		}
+		if !isMachOStringSection(sec) {
+			continue
+		}
+
+		name := sec.Seg + "," + sec.Name
+		data, err := img.sectionData(sec)
+		if err != nil {
+			ctx.Logger().V(3).Info("failed to read Mach-O section", "section", name, "error", err)
+			continue
+		}
+		if sec.Name == machoUStringSection {
+			if strs := img.utf16Strings(name, sec.Addr, data); len(strs.data) > 0 {
+				out = append(out, strs)
+			}
+			continue
+		}
+		out = append(out, sectionStrings{
+			section: name,
+			address: sec.Addr,
+			data:    bytes.ReplaceAll(data, []byte{0}, []byte{'\n'}),
+		})
+	}
+
+	for _, sec := range cfstrings {
+		data, err := img.sectionData(sec)
+		if err != nil {
+			ctx.Logger().V(3).Info("failed to read Mach-O section", "section", sec.Seg+","+sec.Name, "error", err)
+			continue
+		}
+		out = append(out, img.cfStrings(data)...)
+	}
+	return out
+}
+
+// isMachOStringSection returns true if the section is one whose strings are scanned in full.
+func isMachOStringSection(sec *macho.Section) bool {
+	if _, ok := machoCStringSections[sec.Name]; ok {
+		return true
+	}
+	return sec.Name == machoUStringSection
+}
+
+// sectionData returns the content of a section, which is read once.
+func (img *machoImage) sectionData(sec *macho.Section) ([]byte, error) {
+	if data, ok := img.sections[sec]; ok {
+		return data, nil
+	}
+	// Zero filled sections have no content in the file.
+	if sec.Offset == 0 {
+		return nil, nil
+	}
+	data, err := sec.Data()
+	if err != nil {
+		return nil, err
+	}
+	img.sections[sec] = data
+	return data, nil
+}
+
+// utf16Strings splits a section of NUL terminated UTF-16 strings and decodes each one to UTF-8.
+func (img *machoImage) utf16Strings(section string, addr uint64, data []byte) sectionStrings {
+	out := sectionStrings{section: section}
+	start := 0
+	for i := 0; i+1 < len(data); i += 2 {
+		if img.ByteOrder.Uint16(data[i:]) != 0 {
+			continue
+		}
+		if i > start {
+			out.addString(addr+uint64(start), img.decodeUTF16(data[start:i]))
+		}
+		start = i + 2
+	}
+	return out
+}
+
+func (img *machoImage) decodeUTF16(data []byte) []byte {
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = img.ByteOrder.Uint16(data[2*i:])
+	}
+	return []byte(string(utf16.Decode(units)))
+}
+
+// cfStrings returns the NSString literals whose characters are outside the string sections, which are already
+// scanned in full, batched by the section holding them.
+func (img *machoImage) cfStrings(data []byte) []sectionStrings {
+	ptrSize := 4
+	if img.Magic == macho.Magic64 {
+		ptrSize = 8
+	}
+	readPtr := func(b []byte) uint64 {
+		if ptrSize == 8 {
+			return img.ByteOrder.Uint64(b)
+		}
+		return uint64(img.ByteOrder.Uint32(b))
+	}
+
+	var out []sectionStrings
+	bySection := make(map[*macho.Section]int)
+	entrySize := 4 * ptrSize
+	for i := 0; i+entrySize <= len(data); i += entrySize {
+		entry := data[i : i+entrySize]
+		flags := img.ByteOrder.Uint32(entry[ptrSize:])
+		length := readPtr(entry[3*ptrSize:])
+
+		sec, addr, ok := img.resolvePointer(readPtr(entry[2*ptrSize:]))
+		if !ok || isMachOStringSection(sec) || length == 0 {
+			continue
+		}
+		content, err := img.sectionData(sec)
+		if err != nil || content == nil {
+			continue
+		}
+
+		size := length
+		if flags == machoCFStringUTF16 {
+			size *= 2
+		}
+		start := addr - sec.Addr
+		if start+size > uint64(len(content)) || start+size < start {
+			continue
+		}
+
+		literal := content[start : start+size]
+		if flags == machoCFStringUTF16 {
+			literal = img.decodeUTF16(literal)
+		}
+		idx, seen := bySection[sec]
+		if !seen {
+			idx = len(out)
+			bySection[sec] = idx
+			out = append(out, sectionStrings{section: sec.Seg + "," + sec.Name})
+		}
+		out[idx].addString(addr, literal)
+	}
+	return out
+}
+
+// resolvePointer finds the section a pointer stored in the file points into. Pointers are either plain addresses,
+// or, in files using chained fixups, addresses or offsets from the base address packed with fixup fields in their
+// high bits.
+func (img *machoImage) resolvePointer(ptr uint64) (*macho.Section, uint64, bool) {
+	if ptr == 0 {
+		return nil, 0, false
+	}
+	for _, target := range []uint64{ptr, ptr & (1<<43 - 1), ptr & (1<<36 - 1), ptr & (1<<32 - 1)} {
+		for _, addr := range []uint64{target, img.base + target} {
+			for _, sec := range img.Sections {
+				if addr >= sec.Addr && addr-sec.Addr < sec.Size {
+					return sec, addr, true
+				}
+			}
+		}
+	}
+	return nil, 0, false
+}
@@ -0,0 +1,222 @@
+package handlers
+
+import (
+	"bytes"
+	"debug/macho"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/unicode"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+const testMachOBase = 0x100000000
+
+type testMachOSection struct {
+	seg, name string
+	addr      uint64
+	data      []byte
+}
+
+// buildTestMachO builds a 64-bit little endian executable with a __TEXT segment mapping the first page of the
+// file at testMachOBase, and a __DATA segment mapping the second page right after it.
+func buildTestMachO(t *testing.T, cpu macho.Cpu) []byte {
+	t.Helper()
+
+	ustring, err := unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM).NewEncoder().
+		Bytes([]byte("héllo\x00ustring-secret\x00"))
+	require.NoError(t, err)
+
+	cfstring := make([]byte, 96)
+	// A literal outside the string sections, referenced by a chained fixup holding its offset from the base
+	// address along with the offset of the next fixup in the high bits.
+	binary.LittleEndian.PutUint64(cfstring[8:], 0x7c8)
+	binary.LittleEndian.PutUint64(cfstring[16:], 1<<51|0x700)
+	binary.LittleEndian.PutUint64(cfstring[24:], uint64(len("const-secret")))
+	// A literal in __cstring, which is already scanned in full.
+	binary.LittleEndian.PutUint64(cfstring[40:], 0x7c8)
+	binary.LittleEndian.PutUint64(cfstring[48:], testMachOBase+0x406)
+	binary.LittleEndian.PutUint64(cfstring[56:], uint64(len("api_key=cstring-secret")))
+	// Another literal of __const, which is batched with the first one.
+	binary.LittleEndian.PutUint64(cfstring[72:], 0x7c8)
+	binary.LittleEndian.PutUint64(cfstring[80:], testMachOBase+0x70d)
+	binary.LittleEndian.PutUint64(cfstring[88:], uint64(len("other-secret")))
+
+	segments := []struct {
+		name     string
+		addr     uint64
+		sections []testMachOSection
+	}{
+		{name: "__TEXT", addr: testMachOBase, sections: []testMachOSection{
+			{seg: "__TEXT", name: "__cstring", addr: testMachOBase + 0x400, data: []byte("hello\x00api_key=cstring-secret\x00")},
+			{seg: "__TEXT", name: "__ustring", addr: testMachOBase + 0x500, data: ustring},
+			{seg: "__TEXT", name: "__swift5_reflstr", addr: testMachOBase + 0x600, data: []byte("reflstr-secret\x00")},
+			{seg: "__TEXT", name: "__const", addr: testMachOBase + 0x700, data: []byte("const-secret\x00other-secret")},
+		}},
+		{name: "__DATA", addr: testMachOBase + 0x1000, sections: []testMachOSection{
+			{seg: "__DATA", name: "__cfstring", addr: testMachOBase + 0x1000, data: cfstring},
+		}},
+	}
+
+	var cmds bytes.Buffer
+	file := make([]byte, 0x2000)
+	for i, seg := range segments {
+		fileOff := uint64(i * 0x1000)
+		segCmd := macho.Segment64{
+			Cmd:     macho.LoadCmdSegment64,
+			Len:     uint32(72 + 80*len(seg.sections)),
+			Addr:    seg.addr,
+			Memsz:   0x1000,
+			Offset:  fileOff,
+			Filesz:  0x1000,
+			Maxprot: 7,
+			Prot:    5,
+			Nsect:   uint32(len(seg.sections)),
+		}
+		copy(segCmd.Name[:], seg.name)
+		require.NoError(t, binary.Write(&cmds, binary.LittleEndian, segCmd))
+
+		for _, sec := range seg.sections {
+			offset := fileOff + sec.addr - seg.addr
+			secHdr := macho.Section64{Addr: sec.addr, Size: uint64(len(sec.data)), Offset: uint32(offset)}
+			copy(secHdr.Name[:], sec.name)
+			copy(secHdr.Seg[:], sec.seg)
+			require.NoError(t, binary.Write(&cmds, binary.LittleEndian, secHdr))
+			copy(file[offset:], sec.data)
+		}
+	}
+
+	var hdr bytes.Buffer
+	require.NoError(t, binary.Write(&hdr, binary.LittleEndian, macho.FileHeader{
+		Magic: macho.Magic64,
+		Cpu:   cpu,
+		Type:  macho.TypeExec,
+		Ncmd:  uint32(len(segments)),
+		Cmdsz: uint32(cmds.Len()),
+	}))
+	// The 64-bit header has a reserved field after the ones of the 32-bit header.
+	hdr.Write(make([]byte, 4))
+	copy(file, hdr.Bytes())
+	copy(file[hdr.Len():], cmds.Bytes())
+	return file
+}
+
+// buildTestFatMachO builds a universal binary holding an arm64 and an x86_64 executable.
+func buildTestFatMachO(t *testing.T) []byte {
+	t.Helper()
+
+	cpus := []macho.Cpu{macho.CpuArm64, macho.CpuAmd64}
+	out := make([]byte, 0x1000)
+	binary.BigEndian.PutUint32(out[0:], macho.MagicFat)
+	binary.BigEndian.PutUint32(out[4:], uint32(len(cpus)))
+	for i, cpu := range cpus {
+		arch := buildTestMachO(t, cpu)
+		entry := out[8+20*i:]
+		binary.BigEndian.PutUint32(entry[0:], uint32(cpu))
+		binary.BigEndian.PutUint32(entry[8:], uint32(len(out)))
+		binary.BigEndian.PutUint32(entry[12:], uint32(len(arch)))
+		binary.BigEndian.PutUint32(entry[16:], 12)
+		out = append(out, arch...)
+	}
+	return out
+}
+
+func TestHandleFileMachO(t *testing.T) {
+	type machoString struct {
+		section       string
+		address       uint64
+		lineAddresses []uint64
+		data          string
+	}
+	want := []machoString{
+		{section: "__TEXT,__cstring", address: testMachOBase + 0x400, data: "hello\napi_key=cstring-secret\n"},
+		{
+			section:       "__TEXT,__ustring",
+			address:       testMachOBase + 0x500,
+			lineAddresses: []uint64{testMachOBase + 0x500, testMachOBase + 0x50c},
+			data:          "héllo\nustring-secret\n",
+		},
+		{section: "__TEXT,__swift5_reflstr", address: testMachOBase + 0x600, data: "reflstr-secret\n"},
+		{
+			section:       "__TEXT,__const",
+			address:       testMachOBase + 0x700,
+			lineAddresses: []uint64{testMachOBase + 0x700, testMachOBase + 0x70d},
+			data:          "const-secret\nother-secret\n",
+		},
+	}
+
+	tests := map[string][]byte{
+		"thin":      buildTestMachO(t, macho.CpuArm64),
+		"universal": buildTestFatMachO(t),
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			chunkCh := make(chan *sources.Chunk, 32)
+			err := HandleFile(context.Background(), bytes.NewReader(data), &sources.Chunk{}, sources.ChanReporter{Ch: chunkCh})
+			require.NoError(t, err)
+			close(chunkCh)
+
+			var got []machoString
+			for chunk := range chunkCh {
+				extraction := chunk.SourceMetadata.GetExtraction()
+				require.NotNil(t, extraction)
+				assert.Equal(t, machoStage, extraction.GetStage())
+				got = append(got, machoString{
+					section:       extraction.GetSection(),
+					address:       extraction.GetAddress(),
+					lineAddresses: extraction.GetLineAddresses(),
+					data:          string(chunk.Data),
+				})
+			}
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestWriteSectionStrings_LineAddresses(t *testing.T) {
+	// A run of batched strings longer than a chunk is split with the addresses of the lines of each chunk.
+	var strs sectionStrings
+	literal := bytes.Repeat([]byte("a"), 99)
+	for i := range sources.ChunkSize / 50 {
+		strs.addString(uint64(0x1000+i*0x100), literal)
+	}
+
+	h := newDefaultHandler(defaultHandlerType)
+	dataOrErrChan := make(chan DataOrErr, 4)
+	require.NoError(t, h.writeSectionStrings(context.Background(), machoStage, strs, nil, dataOrErrChan))
+	close(dataOrErrChan)
+
+	var chunks []DataOrErr
+	for dataOrErr := range dataOrErrChan {
+		chunks = append(chunks, dataOrErr)
+	}
+	require.Len(t, chunks, 2)
+	for _, chunk := range chunks {
+		extraction := chunk.Metadata.GetExtraction()
+		lineAddresses := extraction.GetLineAddresses()
+		require.NotEmpty(t, lineAddresses)
+		assert.Equal(t, lineAddresses[0], extraction.GetAddress())
+		assert.LessOrEqual(t, bytes.Count(chunk.Data, []byte("\n")), len(lineAddresses))
+	}
+	// The second chunk starts in the middle of the 103rd string.
+	assert.Equal(t, uint64(0x1000+(sources.ChunkSize/100)*0x100), chunks[1].Metadata.GetExtraction().GetAddress())
+}
+
+func TestHandleFileMachO_Invalid(t *testing.T) {
+	// Content that looks like a Mach-O file but doesn't parse is scanned as is.
+	data := append([]byte("\xcf\xfa\xed\xfe\x0c\x00\x00\x01\x00\x00\x00\x00"), []byte("executable-secret")...)
+
+	chunkCh := make(chan *sources.Chunk, 1)
+	err := HandleFile(context.Background(), bytes.NewReader(data), &sources.Chunk{}, sources.ChanReporter{Ch: chunkCh})
+	require.NoError(t, err)
+	close(chunkCh)
+
+	chunk := <-chunkCh
+	require.NotNil(t, chunk)
+	assert.Equal(t, data, chunk.Data)
+	assert.Nil(t, chunk.SourceMetadata.GetExtraction())
+}
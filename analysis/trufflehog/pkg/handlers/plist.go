@@ -0,0 +1,264 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gabriel-vasile/mimetype"
+	"howett.net/plist"
+
+	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+)
+
+// Property lists are how iOS and macOS apps store configuration (Info.plist, GoogleService-Info.plist),
+// localized strings and archived objects such as compiled nibs and storyboards. Most of them are in the
+// binary format, which hides their values from the detectors, so they are flattened into one
+// "key.path = value" line per value before scanning.
+
+const (
+	// plistStage is the extraction stage of chunks decoded from property lists.
+	plistStage = "plist"
+
+	// maxPlistSize is the largest property list that is decoded. Larger ones are scanned as is.
+	maxPlistSize = 64 << 20 // 64 MB
+
+	bplistMagic = "bplist"
+)
+
+func init() {
+	// Binary property lists have no MIME type of their own in the mimetype package, and XML property lists
+	// are only recognized as XML, so both are added to its detection tree.
+	mimetype.Lookup("application/octet-stream").Extend(isBinaryPlist, string(bplistMime), ".plist")
+	mimetype.Lookup("text/xml").Extend(isXMLPlist, string(plistMime), ".plist")
+	mimetype.Lookup("text/plain").Extend(isXMLPlist, string(plistMime), ".plist")
+}
+
+func isBinaryPlist(raw []byte, _ uint32) bool { return bytes.HasPrefix(raw, []byte(bplistMagic)) }
+
+func isXMLPlist(raw []byte, _ uint32) bool {
+	return bytes.Contains(raw, []byte("<!DOCTYPE plist")) || bytes.HasPrefix(bytes.TrimSpace(raw), []byte("<plist"))
+}
+
+// isPlistMime returns true if the MIME type is one of the property list formats.
+func isPlistMime(mime mimeType) bool { return mime == plistMime || mime == bplistMime }
+
+// decodePlistContent flattens the property list read from reader. It returns the flattened text along with
+// metadata recording the plist extraction stage. If the property list can't be decoded, its raw content is
+// returned with the metadata unchanged.
+func decodePlistContent(
+	ctx logContext.Context,
+	reader mimeTypeReader,
+	metadata *source_metadatapb.MetaData,
+) (mimeTypeReader, *source_metadatapb.MetaData, error) {
+	data, err := io.ReadAll(io.LimitReader(reader, maxPlistSize+1))
+	if err != nil {
+		return reader, metadata, fmt.Errorf("error reading property list: %w", err)
+	}
+	raw := mimeTypeReader{mimeExt: reader.mimeExt, mimeName: reader.mimeName, Reader: bytes.NewReader(data)}
+	if len(data) > maxPlistSize {
+		ctx.Logger().V(3).Info("property list exceeds max size, scanning as is", "limit", maxPlistSize)
+		raw.Reader = io.MultiReader(bytes.NewReader(data), reader)
+		return raw, metadata, nil
+	}
+
+	flattened, err := flattenPlist(data)
+	if err != nil {
+		ctx.Logger().V(3).Info("failed to decode property list, scanning as is", "error", err)
+		return raw, metadata, nil
+	}
+
+	decoded := withExtractionStage(metadata, plistStage)
+	return mimeTypeReader{mimeExt: ".txt", mimeName: textMime, Reader: bytes.NewReader(flattened)}, decoded, nil
+}
+
+// flattenPlist decodes a binary, XML or text property list and writes each of its values on its own
+// "key.path = value" line. Objects archived with NSKeyedArchiver are resolved, so their key paths
+// follow the archived object graph rather than its flat object table.
+func flattenPlist(data []byte) ([]byte, error) {
+	var root any
+	if _, err := plist.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+
+	var f plistFlattener
+	if archive, ok := newKeyedArchive(root); ok {
+		f.archive = archive
+		f.flattenValue(archive.top, "")
+	} else {
+		f.flattenValue(root, "")
+	}
+	return f.out.Bytes(), nil
+}
+
+// keyedArchive is a property list written by NSKeyedArchiver. Its objects are stored in a flat table
+// and reference each other by UID, starting from the root objects in $top.
+type keyedArchive struct {
+	top     map[string]any
+	objects []any
+}
+
+func newKeyedArchive(root any) (*keyedArchive, bool) {
+	dict, ok := root.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	if archiver, _ := dict["$archiver"].(string); archiver != "NSKeyedArchiver" {
+		return nil, false
+	}
+	top, _ := dict["$top"].(map[string]any)
+	objects, _ := dict["$objects"].([]any)
+	return &keyedArchive{top: top, objects: objects}, true
+}
+
+type plistFlattener struct {
+	out     bytes.Buffer
+	archive *keyedArchive
+	// visited holds the archived objects that were already written, so objects referenced from several
+	// places are written once, and reference cycles terminate.
+	visited map[plist.UID]struct{}
+}
+
+func (f *plistFlattener) flattenValue(v any, path string) {
+	switch val := v.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			f.flattenValue(val[k], joinKeyPath(path, k))
+		}
+	case []any:
+		for i, item := range val {
+			f.flattenValue(item, path+"["+strconv.Itoa(i)+"]")
+		}
+	case plist.UID:
+		f.flattenArchivedObject(val, path)
+	case []byte:
+		f.flattenData(val, path)
+	case string:
+		f.writeLine(path, val)
+	case time.Time:
+		f.writeLine(path, val.UTC().Format(time.RFC3339))
+	default:
+		f.writeLine(path, fmt.Sprint(val))
+	}
+}
+
+// flattenData writes the value of a data field. Nested property lists are flattened under the field's key
+// path, text is written as is and other binary data is skipped.
+func (f *plistFlattener) flattenData(data []byte, path string) {
+	if bytes.HasPrefix(data, []byte(bplistMagic)) {
+		if nested, err := flattenPlist(data); err == nil {
+			for _, line := range bytes.SplitAfter(nested, []byte("\n")) {
+				if len(line) > 0 {
+					f.out.WriteString(joinKeyPath(path, ""))
+					f.out.Write(line)
+				}
+			}
+			return
+		}
+	}
+	if utf8.Valid(data) && isPrintable(data) {
+		f.writeLine(path, string(data))
+	}
+}
+
+// flattenArchivedObject writes the archived object referenced by uid. Archived strings, data, arrays and
+// dictionaries are written like their plain property list counterparts, and other objects are written
+// as dictionaries of their encoded fields.
+func (f *plistFlattener) flattenArchivedObject(uid plist.UID, path string) {
+	if f.archive == nil || uint64(uid) >= uint64(len(f.archive.objects)) {
+		return
+	}
+	if _, ok := f.visited[uid]; ok {
+		return
+	}
+	if f.visited == nil {
+		f.visited = make(map[plist.UID]struct{})
+	}
+	f.visited[uid] = struct{}{}
+
+	obj := f.archive.objects[uid]
+	if s, ok := obj.(string); ok && s == "$null" {
+		return
+	}
+	fields, ok := obj.(map[string]any)
+	if !ok {
+		f.flattenValue(obj, path)
+		return
+	}
+
+	if s, ok := fields["NS.string"]; ok {
+		f.flattenValue(s, path)
+		return
+	}
+	if b, ok := fields["NS.bytes"]; ok {
+		f.flattenValue(b, path)
+		return
+	}
+	keys, hasKeys := fields["NS.keys"].([]any)
+	values, hasValues := fields["NS.objects"].([]any)
+	switch {
+	case hasKeys && hasValues && len(keys) == len(values):
+		for i, key := range keys {
+			f.flattenValue(values[i], joinKeyPath(path, f.archivedKey(key)))
+		}
+	case hasValues:
+		for i, item := range values {
+			f.flattenValue(item, path+"["+strconv.Itoa(i)+"]")
+		}
+	default:
+		delete(fields, "$class")
+		f.flattenValue(fields, path)
+	}
+}
+
+// archivedKey returns the string form of an archived dictionary key.
+func (f *plistFlattener) archivedKey(key any) string {
+	if uid, ok := key.(plist.UID); ok && uint64(uid) < uint64(len(f.archive.objects)) {
+		key = f.archive.objects[uid]
+		if fields, ok := key.(map[string]any); ok {
+			key = fields["NS.string"]
+		}
+	}
+	if s, ok := key.(string); ok {
+		return s
+	}
+	return fmt.Sprint(key)
+}
+
+func (f *plistFlattener) writeLine(path, value string) {
+	if value == "" {
+		return
+	}
+	f.out.WriteString(path)
+	f.out.WriteString(" = ")
+	f.out.WriteString(value)
+	f.out.WriteByte('\n')
+}
+
+func joinKeyPath(path, key string) string {
+	switch {
+	case path == "":
+		return key
+	case key == "":
+		return path + "."
+	default:
+		return path + "." + key
+	}
+}
+
+// isPrintable returns true if the text contains no control characters other than whitespace.
+func isPrintable(data []byte) bool {
+	return !bytes.ContainsFunc(data, func(r rune) bool {
+		return r < 0x20 && r != '\n' && r != '\r' && r != '\t'
+	})
+}
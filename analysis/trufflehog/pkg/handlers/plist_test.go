@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"howett.net/plist"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+func TestFlattenPlist(t *testing.T) {
+	nested, err := plist.Marshal(map[string]any{"token": "nested-secret"}, plist.BinaryFormat)
+	require.NoError(t, err)
+	config := map[string]any{
+		"API_KEY": "plain-secret",
+		"Servers": []any{
+			map[string]any{"Host": "example.com", "Port": 443},
+		},
+		"Enabled": true,
+		"Nested":  nested,
+		"Binary":  []byte{0x00, 0x01, 0x02},
+		"Empty":   "",
+	}
+	want := "API_KEY = plain-secret\n" +
+		"Enabled = true\n" +
+		"Nested.token = nested-secret\n" +
+		"Servers[0].Host = example.com\n" +
+		"Servers[0].Port = 443\n"
+
+	for name, format := range map[string]int{"binary": plist.BinaryFormat, "xml": plist.XMLFormat} {
+		t.Run(name, func(t *testing.T) {
+			data, err := plist.Marshal(config, format)
+			require.NoError(t, err)
+
+			got, err := flattenPlist(data)
+			require.NoError(t, err)
+			assert.Equal(t, want, string(got))
+		})
+	}
+}
+
+func TestFlattenPlist_KeyedArchive(t *testing.T) {
+	archive, err := plist.Marshal(map[string]any{
+		"$archiver": "NSKeyedArchiver",
+		"$version":  100000,
+		"$top":      map[string]any{"root": plist.UID(1)},
+		"$objects": []any{
+			"$null",
+			// An NSDictionary holding a label and a string.
+			map[string]any{
+				"$class":     plist.UID(7),
+				"NS.keys":    []any{plist.UID(2), plist.UID(3)},
+				"NS.objects": []any{plist.UID(4), plist.UID(6)},
+			},
+			"label",
+			"api_key",
+			// A UILabel whose parent is the dictionary, making a reference cycle.
+			map[string]any{
+				"$class":   plist.UID(8),
+				"UIText":   plist.UID(5),
+				"UIParent": plist.UID(1),
+				"UIHidden": false,
+			},
+			map[string]any{"NS.string": "label-secret"},
+			"plain-secret",
+			map[string]any{"$classname": "NSDictionary", "$classes": []any{"NSDictionary", "NSObject"}},
+			map[string]any{"$classname": "UILabel", "$classes": []any{"UILabel", "UIView", "NSObject"}},
+		},
+	}, plist.BinaryFormat)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		data []byte
+		want string
+	}{
+		"archive": {
+			data: archive,
+			want: "root.label.UIHidden = false\n" +
+				"root.label.UIText = label-secret\n" +
+				"root.api_key = plain-secret\n",
+		},
+		// A UID that overflows an int doesn't reference an object.
+		"uid out of range": {
+			data: []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0"><dict>
+<key>$archiver</key><string>NSKeyedArchiver</string>
+<key>$version</key><integer>100000</integer>
+<key>$top</key><dict><key>root</key><dict><key>CF$UID</key><integer>18446744073709551615</integer></dict></dict>
+<key>$objects</key><array><string>$null</string></array>
+</dict></plist>`),
+			want: "",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := flattenPlist(tt.data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestHandleFilePlist(t *testing.T) {
+	data, err := plist.Marshal(map[string]string{"GOOGLE_API_KEY": "plist-secret"}, plist.BinaryFormat)
+	require.NoError(t, err)
+
+	chunkCh := make(chan *sources.Chunk, 1)
+	err = HandleFile(context.Background(), bytes.NewReader(data), &sources.Chunk{}, sources.ChanReporter{Ch: chunkCh})
+	require.NoError(t, err)
+	close(chunkCh)
+
+	chunk := <-chunkCh
+	require.NotNil(t, chunk)
+	assert.Equal(t, "GOOGLE_API_KEY = plist-secret\n", string(chunk.Data))
+	assert.Equal(t, plistStage, chunk.SourceMetadata.GetExtraction().GetStage())
+}
+
+func TestHandleFilePlist_Invalid(t *testing.T) {
+	// Content that looks like a property list but doesn't decode is scanned as is.
+	data := []byte("bplist00 not really a plist")
+
+	chunkCh := make(chan *sources.Chunk, 1)
+	err := HandleFile(context.Background(), bytes.NewReader(data), &sources.Chunk{}, sources.ChanReporter{Ch: chunkCh})
+	require.NoError(t, err)
+	close(chunkCh)
+
+	chunk := <-chunkCh
+	require.NotNil(t, chunk)
+	assert.Equal(t, data, chunk.Data)
+	assert.Nil(t, chunk.SourceMetadata.GetExtraction())
+}
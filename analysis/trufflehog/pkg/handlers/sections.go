@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+// sectionStrings is a run of strings extracted from a section of an executable, starting at address.
+// The strings are separated by newlines, and the offset of each one within data is its offset from address,
+// unless the run has lineAddresses.
+type sectionStrings struct {
+	section string
+	address uint64
+	data    []byte
+	// lineAddresses is the address of the string on each line of data, for runs of strings that aren't laid out
+	// as they are in the section, such as decoded ones.
+	lineAddresses []uint64
+}
+
+// addString appends a string found at addr to a run whose strings aren't laid out as they are in the section. A
+// string spanning several lines is attributed to its address on each one.
+func (s *sectionStrings) addString(addr uint64, str []byte) {
+	if len(s.lineAddresses) == 0 {
+		s.address = addr
+	}
+	s.data = append(s.data, str...)
+	s.data = append(s.data, '\n')
+	for range bytes.Count(str, []byte{'\n'}) + 1 {
+		s.lineAddresses = append(s.lineAddresses, addr)
+	}
+}
+
+// writeSectionStrings writes the chunks of a run of strings to the data channel. Each chunk is tagged with the
+// extraction stage, the section and address of its first byte and, for runs with line addresses, the address of
+// each of its lines, from which the engine locates the string of each result.
+func (h *defaultHandler) writeSectionStrings(
+	ctx logContext.Context,
+	stage string,
+	strs sectionStrings,
+	metadata *source_metadatapb.MetaData,
+	dataOrErrChan chan DataOrErr,
+) error {
+	chunkReader := sources.NewChunkReader()
+	var offset uint64
+	// line is the line of strs.data that the current chunk starts on.
+	var line int
+	for data := range chunkReader(ctx, bytes.NewReader(strs.data)) {
+		if err := data.Error(); err != nil {
+			return fmt.Errorf("%w: error reading chunk: %v", ErrProcessingWarning, err)
+		}
+
+		chunkMetadata := &source_metadatapb.MetaData{}
+		if metadata != nil {
+			chunkMetadata = proto.Clone(metadata).(*source_metadatapb.MetaData)
+		}
+		if chunkMetadata.Extraction == nil {
+			chunkMetadata.Extraction = &source_metadatapb.Extraction{}
+		}
+		chunkMetadata.Extraction.Stage = stage
+		chunkMetadata.Extraction.Section = strs.section
+		chunkMetadata.Extraction.Address = strs.address + offset
+		if strs.lineAddresses != nil {
+			// Every string of the run ends with a newline, so the chunk starts on one of them.
+			addrs := strs.lineAddresses[line:]
+			addrs = addrs[:min(len(addrs), bytes.Count(data.Bytes(), []byte{'\n'})+1)]
+			chunkMetadata.Extraction.Address = addrs[0]
+			chunkMetadata.Extraction.LineAddresses = addrs
+			line += bytes.Count(data.Bytes()[:min(sources.ChunkSize, len(data.Bytes()))], []byte{'\n'})
+		}
+
+		dataOrErr := DataOrErr{Data: data.Bytes(), Metadata: chunkMetadata}
+		if err := common.CancellableWrite(ctx, dataOrErrChan, dataOrErr); err != nil {
+			return err
+		}
+		h.metrics.incBytesProcessed(len(data.Bytes()))
+		offset += sources.ChunkSize
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gabriel-vasile/mimetype"
+
+	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/iobuf"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+)
+
+// JavaScript source maps shipped next to minified bundles embed the original, unminified sources in their
+// sourcesContent array, as JSON strings in which newlines and quotes are escaped. Scanning the map as is reports
+// findings on a single huge line of the map, if the escaping doesn't hide them altogether, so each source is
+// unescaped and scanned on its own, recording its path and the lines of the original source.
+//
+// Index maps hold their source maps in the map field of their sections instead.
+
+const (
+	// sourceMapStage is the extraction stage of chunks decoded from source maps.
+	sourceMapStage = "sourcemap"
+
+	// maxSourceMapSize is the largest source map that is decoded. Larger ones are scanned as is.
+	maxSourceMapSize = 256 << 20 // 256 MB
+)
+
+func init() {
+	mimetype.Lookup(string(jsonMime)).Extend(isSourceMap, string(srcMapMime), ".map")
+}
+
+// isSourceMap reports whether the JSON object starting raw looks like a source map.
+func isSourceMap(raw []byte, _ uint32) bool {
+	return bytes.Contains(raw, []byte(`"version"`)) &&
+		(bytes.Contains(raw, []byte(`"sources"`)) || bytes.Contains(raw, []byte(`"sections"`)))
+}
+
+// sourceMap is a source map of revision 3 of the format, or an index map when it has sections.
+type sourceMap struct {
+	Version        int       `json:"version"`
+	SourceRoot     string    `json:"sourceRoot"`
+	Sources        []string  `json:"sources"`
+	SourcesContent []*string `json:"sourcesContent"`
+	Sections       []struct {
+		Map *sourceMap `json:"map"`
+	} `json:"sections"`
+}
+
+// sourceMapSource is an original source embedded in a source map.
+type sourceMapSource struct {
+	path    string
+	content string
+}
+
+// embeddedSources returns the sources of the map that have their content embedded, in order.
+func (m *sourceMap) embeddedSources() []sourceMapSource {
+	var sources []sourceMapSource
+	for i, content := range m.SourcesContent {
+		if content == nil || *content == "" {
+			continue
+		}
+		var path string
+		if i < len(m.Sources) {
+			path = m.Sources[i]
+		}
+		if m.SourceRoot != "" && !strings.Contains(path, "://") {
+			path = strings.TrimSuffix(m.SourceRoot, "/") + "/" + strings.TrimPrefix(path, "/")
+		}
+		sources = append(sources, sourceMapSource{path: path, content: *content})
+	}
+	for _, section := range m.Sections {
+		if section.Map != nil {
+			sources = append(sources, section.Map.embeddedSources()...)
+		}
+	}
+	return sources
+}
+
+// handleSourceMapContent scans each source embedded in the source map read from rdr as its own content, recording
+// its path and the sourcemap extraction stage. It returns false if the map can't be decoded or embeds no source,
+// rdr then being rewound for the map to be scanned as is.
+func (h *defaultHandler) handleSourceMapContent(
+	ctx logContext.Context,
+	rdr *iobuf.BufferedReadSeeker,
+	metadata *source_metadatapb.MetaData,
+	dataOrErrChan chan DataOrErr,
+) (bool, error) {
+	data, err := io.ReadAll(io.LimitReader(rdr, maxSourceMapSize+1))
+	if err != nil {
+		return true, fmt.Errorf("%w: error reading source map: %v", ErrProcessingWarning, err)
+	}
+
+	var sources []sourceMapSource
+	if len(data) <= maxSourceMapSize {
+		var m sourceMap
+		if err := json.Unmarshal(data, &m); err != nil {
+			ctx.Logger().V(3).Info("failed to decode source map, scanning as is", "error", err)
+		} else {
+			sources = m.embeddedSources()
+		}
+	} else {
+		ctx.Logger().V(3).Info("source map exceeds max size, scanning as is", "limit", maxSourceMapSize)
+	}
+
+	if len(sources) == 0 {
+		if _, err := rdr.Seek(0, io.SeekStart); err != nil {
+			return true, fmt.Errorf("%w: error resetting source map reader: %v", ErrProcessingWarning, err)
+		}
+		return false, nil
+	}
+
+	for _, source := range sources {
+		sourceMetadata := withExtractionStage(metadata, sourceMapStage)
+		sourceMetadata.Extraction.SourcePath = source.path
+		reader := mimeTypeReader{mimeExt: ".txt", mimeName: textMime, Reader: strings.NewReader(source.content)}
+		if err := h.handleNonArchiveContent(ctx, reader, sourceMetadata, dataOrErrChan); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
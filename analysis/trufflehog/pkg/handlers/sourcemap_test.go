@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+func TestHandleFileSourceMap(t *testing.T) {
+	tests := map[string]struct {
+		sourceMap string
+		want      map[string]string
+		wantStage string
+	}{
+		"sources": {
+			sourceMap: `{"version":3,"file":"main.js","sourceRoot":"src/","sources":["config.ts","vendor.ts","app.ts"],` +
+				`"sourcesContent":["const apiKey = \"map-secret\";\nexport default apiKey;\n",null,"import \"./config\";\n"],` +
+				`"names":["apiKey"],"mappings":"AAAA,MAAMA"}`,
+			want: map[string]string{
+				"src/config.ts": "const apiKey = \"map-secret\";\nexport default apiKey;\n",
+				"src/app.ts":    "import \"./config\";\n",
+			},
+			wantStage: sourceMapStage,
+		},
+		"index map": {
+			sourceMap: `{"version":3,"sections":[{"offset":{"line":0,"column":0},"map":{"version":3,` +
+				`"sources":["webpack:///./src/config.js"],"sourcesContent":["module.exports = \"map-secret\";"],"mappings":"AAAA"}}]}`,
+			want:      map[string]string{"webpack:///./src/config.js": `module.exports = "map-secret";`},
+			wantStage: sourceMapStage,
+		},
+		"no embedded sources": {
+			sourceMap: `{"version":3,"sources":["config.ts"],"names":[],"mappings":"AAAA"}`,
+			want:      map[string]string{"": `{"version":3,"sources":["config.ts"],"names":[],"mappings":"AAAA"}`},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			chunkCh := make(chan *sources.Chunk, 8)
+			err := HandleFile(context.Background(), strings.NewReader(tt.sourceMap), &sources.Chunk{}, sources.ChanReporter{Ch: chunkCh})
+			require.NoError(t, err)
+			close(chunkCh)
+
+			got := make(map[string]string)
+			for chunk := range chunkCh {
+				extraction := chunk.SourceMetadata.GetExtraction()
+				assert.Equal(t, tt.wantStage, extraction.GetStage())
+				if tt.wantStage != "" {
+					assert.Equal(t, int64(1), extraction.GetLine())
+				}
+				got[extraction.GetSourcePath()] = string(chunk.Data)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
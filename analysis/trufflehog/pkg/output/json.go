@@ -39,6 +39,8 @@ func (p *JSONPrinter) Print(_ context.Context, r *detectors.ResultWithMetadata)
 		DetectorName string
 		// DetectorDescription is the description of the Detector.
 		DetectorDescription string
+		// Generic is true if the result was found by a generic detector rather than a vendor detector.
+		Generic bool `json:",omitempty"`
 		// DecoderName is the string name of the DecoderType.
 		DecoderName       string
 		Verified          bool
@@ -48,6 +50,8 @@ func (p *JSONPrinter) Print(_ context.Context, r *detectors.ResultWithMetadata)
 		// RawV2 contains the raw secret identifier that is a combination of both the ID and the secret.
 		// This is used for secrets that are multi part and could have the same ID. Ex: AWS credentials
 		RawV2 string
+		// Credential contains the named parts of a multi-part secret.
+		Credential *detectors.Credential `json:",omitempty"`
 		// Redacted contains the redacted version of the raw secret identification data for display purposes.
 		// A secret ID should be used if available.
 		Redacted       string
@@ -61,11 +65,13 @@ func (p *JSONPrinter) Print(_ context.Context, r *detectors.ResultWithMetadata)
 		DetectorType:        r.DetectorType,
 		DetectorName:        r.DetectorType.String(),
 		DetectorDescription: r.DetectorDescription,
+		Generic:             detectors.IsGeneric(r.DetectorType),
 		DecoderName:         r.DecoderType.String(),
 		Verified:            r.Verified,
 		VerificationError:   verificationErr,
 		Raw:                 string(r.Raw),
 		RawV2:               string(r.RawV2),
+		Credential:          r.Credential,
 		Redacted:            r.Redacted,
 		ExtraData:           r.ExtraData,
 		StructuredData:      r.StructuredData,
@@ -3,6 +3,7 @@ package output
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"sort"
 	"strings"
 	"sync"
@@ -42,16 +43,34 @@ func (p *PlainPrinter) Print(_ context.Context, r *detectors.ResultWithMetadata)
 	if err != nil {
 		return fmt.Errorf("could not marshal result: %w", err)
 	}
+	// File handlers describe where within a file the data was extracted from.
+	extracted, err := structToMap(struct {
+		AppBundle  *source_metadatapb.AppBundle  `json:",omitempty"`
+		Extraction *source_metadatapb.Extraction `json:",omitempty"`
+	}{out.MetaData.GetAppBundle(), out.MetaData.GetExtraction()})
+	if err != nil {
+		return fmt.Errorf("could not marshal result: %w", err)
+	}
+	if meta == nil {
+		meta = extracted
+	} else {
+		maps.Copy(meta, extracted)
+	}
 
 	printer := greenPrinter
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
+	// Results of generic detectors are told apart from those of vendor detectors.
+	kind := "result"
+	if detectors.IsGeneric(r.Result.DetectorType) {
+		kind = "generic result"
+	}
 	if out.Verified {
-		boldGreenPrinter.Print("✅ Found verified result 🐷🔑\n")
+		boldGreenPrinter.Printf("✅ Found verified %s 🐷🔑\n", kind)
 	} else {
 		printer = whitePrinter
-		boldWhitePrinter.Print("Found unverified result 🐷🔑❓\n")
+		boldWhitePrinter.Printf("Found unverified %s 🐷🔑❓\n", kind)
 		if out.VerificationError != nil {
 			yellowPrinter.Printf("Verification issue: %s\n", out.VerificationError)
 		}
@@ -59,6 +78,9 @@ func (p *PlainPrinter) Print(_ context.Context, r *detectors.ResultWithMetadata)
 	printer.Printf("Detector Type: %s\n", out.DetectorType)
 	printer.Printf("Decoder Type: %s\n", out.DecoderType)
 	printer.Printf("Raw result: %s\n", whitePrinter.Sprint(out.Raw))
+	if class := out.MetaData.GetFileClass(); class != "" {
+		printer.Printf("File Class: %s\n", class)
+	}
 
 	for k, v := range r.Result.ExtraData {
 		printer.Printf(
@@ -4015,6 +4015,228 @@ var _ interface {
 	ErrorName() string
 } = SentryValidationError{}
 
+// Validate checks the field values on AppBundle with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *AppBundle) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AppBundle with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in AppBundleMultiError, or nil
+// if none found.
+func (m *AppBundle) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AppBundle) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for BundleId
+
+	// no validation rules for BundleVersion
+
+	// no validation rules for BundlePath
+
+	// no validation rules for SplitName
+
+	if len(errors) > 0 {
+		return AppBundleMultiError(errors)
+	}
+
+	return nil
+}
+
+// AppBundleMultiError is an error wrapping multiple validation errors returned
+// by AppBundle.ValidateAll() if the designated constraints aren't met.
+type AppBundleMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AppBundleMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AppBundleMultiError) AllErrors() []error { return m }
+
+// AppBundleValidationError is the validation error returned by
+// AppBundle.Validate if the designated constraints aren't met.
+type AppBundleValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AppBundleValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AppBundleValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AppBundleValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AppBundleValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AppBundleValidationError) ErrorName() string { return "AppBundleValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AppBundleValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAppBundle.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AppBundleValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AppBundleValidationError{}
+
+// Validate checks the field values on Extraction with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Extraction) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Extraction with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ExtractionMultiError, or
+// nil if none found.
+func (m *Extraction) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Extraction) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Stage
+
+	// no validation rules for KeyPath
+
+	// no validation rules for Section
+
+	// no validation rules for Address
+
+	// no validation rules for EntryPath
+
+	// no validation rules for Line
+
+	// no validation rules for FormatVersion
+
+	// no validation rules for SourcePath
+
+	if len(errors) > 0 {
+		return ExtractionMultiError(errors)
+	}
+
+	return nil
+}
+
+// ExtractionMultiError is an error wrapping multiple validation errors
+// returned by Extraction.ValidateAll() if the designated constraints aren't met.
+type ExtractionMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ExtractionMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ExtractionMultiError) AllErrors() []error { return m }
+
+// ExtractionValidationError is the validation error returned by
+// Extraction.Validate if the designated constraints aren't met.
+type ExtractionValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ExtractionValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ExtractionValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ExtractionValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ExtractionValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ExtractionValidationError) ErrorName() string { return "ExtractionValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ExtractionValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sExtraction.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ExtractionValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ExtractionValidationError{}
+
 // Validate checks the field values on MetaData with the rules defined in the
 // proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
@@ -4037,6 +4259,66 @@ func (m *MetaData) validate(all bool) error {
 
 	var errors []error
 
+	if all {
+		switch v := interface{}(m.GetAppBundle()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, MetaDataValidationError{
+					field:  "AppBundle",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, MetaDataValidationError{
+					field:  "AppBundle",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetAppBundle()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return MetaDataValidationError{
+				field:  "AppBundle",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetExtraction()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, MetaDataValidationError{
+					field:  "Extraction",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, MetaDataValidationError{
+					field:  "Extraction",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetExtraction()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return MetaDataValidationError{
+				field:  "Extraction",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for FileClass
+
 	switch v := m.Data.(type) {
 	case *MetaData_Azure:
 		if v == nil {
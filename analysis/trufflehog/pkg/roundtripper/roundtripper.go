@@ -94,7 +94,7 @@ func (r *RoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
 			)
 		}
 
-		reason, shouldRetry, duration := r.RetryableRoundtripper.shouldRetryRequest(response, err)
+		reason, shouldRetry, duration := r.RetryableRoundtripper.ShouldRetryRequest(response, err)
 		if shouldRetry {
 			if retries >= int(r.RetryableRoundtripper.maxRetries) {
 				r.logger.V(2).Info("max retries reached",
@@ -155,7 +155,10 @@ func WithBasicAuth(username, password string) func(*RoundTripper) {
 
 // Retryable
 
-func (r *RetryableRoundtripper) shouldRetryRequest(response *http.Response, err error) (reason string, shouldRetry bool, after time.Duration) {
+// ShouldRetryRequest reports whether a request that got response or err should
+// be retried, why, and how long to wait before retrying it. 429 and 503
+// responses wait for as long as their Retry-After header asks.
+func (r *RetryableRoundtripper) ShouldRetryRequest(response *http.Response, err error) (reason string, shouldRetry bool, after time.Duration) {
 	if !r.enabled {
 		return "", false, 0
 	}
@@ -183,34 +186,50 @@ func (r *RetryableRoundtripper) shouldRetryRequest(response *http.Response, err
 	}
 }
 
+// getRetryAfter returns the delay a Retry-After header asks for. The header
+// is either a number of seconds or an HTTP date.
 func getRetryAfter(response *http.Response) time.Duration {
 	if s, ok := response.Header["Retry-After"]; ok {
 		if sleep, err := strconv.ParseInt(s[0], 10, 64); err == nil {
 			return time.Second * time.Duration(sleep)
 		}
+		if date, err := http.ParseTime(s[0]); err == nil {
+			return max(time.Until(date), 0)
+		}
 	}
 	return 0
 }
 
-func WithRetryable(opts ...func(*RetryableRoundtripper)) func(*RoundTripper) {
-	return func(r *RoundTripper) {
-		rt := &RetryableRoundtripper{
-			enabled:                  true,
-			maxRetries:               3,
-			shouldRetryError:         true,
-			shouldRetryErrorDuration: time.Second * 5,
-			shouldRetry5XX:           true,
-			shouldRetry5XXDuration:   time.Second * 5,
-			shouldRetry401:           false,
-			shouldRetry401Duration:   time.Second * 5,
-			default429RetryDuration:  time.Second * 30,
-		}
+// NewRetryableRoundtripper creates an enabled RetryableRoundtripper, for
+// callers that schedule retries themselves with ShouldRetryRequest.
+func NewRetryableRoundtripper(opts ...func(*RetryableRoundtripper)) *RetryableRoundtripper {
+	rt := &RetryableRoundtripper{
+		enabled:                  true,
+		maxRetries:               3,
+		shouldRetryError:         true,
+		shouldRetryErrorDuration: time.Second * 5,
+		shouldRetry5XX:           true,
+		shouldRetry5XXDuration:   time.Second * 5,
+		shouldRetry401:           false,
+		shouldRetry401Duration:   time.Second * 5,
+		default429RetryDuration:  time.Second * 30,
+	}
 
-		for _, opt := range opts {
-			opt(rt)
-		}
+	for _, opt := range opts {
+		opt(rt)
+	}
+
+	return rt
+}
 
-		r.RetryableRoundtripper = rt
+// MaxRetries returns the number of times a request is retried.
+func (r *RetryableRoundtripper) MaxRetries() uint {
+	return r.maxRetries
+}
+
+func WithRetryable(opts ...func(*RetryableRoundtripper)) func(*RoundTripper) {
+	return func(r *RoundTripper) {
+		r.RetryableRoundtripper = NewRetryableRoundtripper(opts...)
 	}
 }
 
@@ -15,6 +15,7 @@ import (
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/fileclass"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/handlers"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
@@ -33,6 +34,7 @@ type Source struct {
 	paths       []string
 	log         logr.Logger
 	filter      *common.Filter
+	classFilter *fileclass.Filter
 	sources.Progress
 	sources.CommonSourceUnitUnmarshaller
 }
@@ -78,6 +80,12 @@ func (s *Source) Init(aCtx context.Context, name string, jobId sources.JobID, so
 	}
 	s.filter = filter
 
+	classFilter, err := fileclass.NewFilter(conn.IncludeClasses, conn.ExcludeClasses)
+	if err != nil {
+		return fmt.Errorf("unable to create file class filter: %w", err)
+	}
+	s.classFilter = classFilter
+
 	return nil
 }
 
@@ -105,7 +113,13 @@ func (s *Source) Chunks(ctx context.Context, chunksChan chan *sources.Chunk, _ .
 		if fileInfo.IsDir() {
 			err = s.scanDir(ctx, cleanPath, chunksChan)
 		} else {
-			err = s.scanFile(ctx, cleanPath, chunksChan)
+			splits := s.splitAPKsOf(ctx, cleanPath)
+			err = s.scanFile(ctx, cleanPath, splits, chunksChan)
+			for _, split := range s.splitAPKSiblings(cleanPath) {
+				if splitErr := s.scanFile(ctx, split, splits, chunksChan); splitErr != nil {
+					logger.Error(splitErr, "error scanning split APK", "split", split)
+				}
+			}
 		}
 
 		if err != nil && !errors.Is(err, io.EOF) {
@@ -117,6 +131,7 @@ func (s *Source) Chunks(ctx context.Context, chunksChan chan *sources.Chunk, _ .
 
 	return nil
 }
+
 func (s *Source) scanDir(ctx context.Context, path string, chunksChan chan *sources.Chunk) error {
 	workerPool := new(errgroup.Group)
 	workerPool.SetLimit(s.concurrency)
@@ -140,7 +155,7 @@ func (s *Source) scanDir(ctx context.Context, path string, chunksChan chan *sour
 		}
 
 		workerPool.Go(func() error {
-			if err = s.scanFile(ctx, fullPath, chunksChan); err != nil {
+			if err = s.scanFile(ctx, fullPath, nil, chunksChan); err != nil {
 				ctx.Logger().Error(err, "error scanning file", "path", fullPath, "error", err)
 			}
 			return nil
@@ -152,7 +167,13 @@ func (s *Source) scanDir(ctx context.Context, path string, chunksChan chan *sour
 
 var skipSymlinkErr = errors.New("skipping symlink")
 
-func (s *Source) scanFile(ctx context.Context, path string, chunksChan chan *sources.Chunk) error {
+// scanFile scans the file at path. splits are the split APKs of the app the file is part of, if any.
+func (s *Source) scanFile(
+	ctx context.Context,
+	path string,
+	splits *handlers.SplitAPKs,
+	chunksChan chan *sources.Chunk,
+) error {
 	fileCtx := context.WithValues(ctx, "path", path)
 	fileStat, err := os.Lstat(path)
 	if err != nil {
@@ -162,6 +183,15 @@ func (s *Source) scanFile(ctx context.Context, path string, chunksChan chan *sou
 		return skipSymlinkErr
 	}
 
+	// Files whose extension isn't known are classified by the file handlers, by their detected MIME type. Files of
+	// excluded classes are skipped before they're read; other files the filter doesn't pass may be archives whose
+	// entries it does, so the file handlers filter them.
+	class, _ := fileclass.ByExtension(path)
+	if s.classFilter.Excludes(class) {
+		fileCtx.Logger().V(5).Info("skipping file of filtered class", "class", class)
+		return nil
+	}
+
 	inputFile, err := os.Open(path)
 	if err != nil {
 		return fmt.Errorf("unable to open file: %w", err)
@@ -181,11 +211,19 @@ func (s *Source) scanFile(ctx context.Context, path string, chunksChan chan *sou
 					File: sanitizer.UTF8(path),
 				},
 			},
+			FileClass: string(class),
 		},
 		Verify: s.verify,
 	}
 
-	return handlers.HandleFile(fileCtx, inputFile, chunkSkel, sources.ChanReporter{Ch: chunksChan})
+	return handlers.HandleFile(
+		fileCtx,
+		inputFile,
+		chunkSkel,
+		sources.ChanReporter{Ch: chunksChan},
+		handlers.WithFileClassFilter(s.classFilter),
+		handlers.WithSplitAPKs(splits, path),
+	)
 }
 
 // Enumerate implements SourceUnitEnumerator interface. This implementation simply
@@ -205,6 +243,11 @@ func (s *Source) Enumerate(ctx context.Context, reporter sources.UnitReporter) e
 			if err := reporter.UnitOk(ctx, item); err != nil {
 				return err
 			}
+			for _, split := range s.splitAPKSiblings(filepath.Clean(path)) {
+				if err := reporter.UnitOk(ctx, sources.CommonSourceUnit{ID: split}); err != nil {
+					return err
+				}
+			}
 			continue
 		}
 		err = fs.WalkDir(os.DirFS(path), ".", func(relativePath string, d fs.DirEntry, err error) error {
@@ -251,7 +294,7 @@ func (s *Source) ChunkUnit(ctx context.Context, unit sources.SourceUnit, reporte
 		} else {
 			// TODO: Finer grain error tracking of individual
 			// chunks (in the case of archives).
-			scanErr = s.scanFile(ctx, cleanPath, ch)
+			scanErr = s.scanFile(ctx, cleanPath, s.splitAPKsOf(ctx, cleanPath), ch)
 		}
 	}()
 
@@ -1,6 +1,8 @@
 package filesystem
 
 import (
+	"archive/zip"
+	"bytes"
 	"os"
 	"path/filepath"
 	"strings"
@@ -49,6 +51,7 @@ func TestSource_Scan(t *testing.T) {
 						File: "filesystem.go",
 					},
 				},
+				FileClass: "code",
 			},
 			wantErr: false,
 		},
@@ -110,7 +113,7 @@ func TestScanFile(t *testing.T) {
 	ctx := context.WithLogger(context.Background(), logr.Discard())
 	go func() {
 		defer close(chunksChan)
-		err = source.scanFile(ctx, tmpfile.Name(), chunksChan)
+		err = source.scanFile(ctx, tmpfile.Name(), nil, chunksChan)
 		assert.Nil(t, err)
 	}()
 
@@ -180,6 +183,75 @@ func TestEnumerate(t *testing.T) {
 	}
 }
 
+func TestEnumerateSplitAPKs(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	for _, name := range []string{
+		"app.apk",
+		"app.split.config.arm64_v8a.apk",
+		"app.split.assets.apk",
+		"app.split.notes.txt",
+		"other.split.config.en.apk",
+	} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0644))
+	}
+	conn, err := anypb.New(&sourcespb.Filesystem{
+		// The asset pack split is given explicitly, so it must not be enumerated twice.
+		Paths: []string{filepath.Join(dir, "app.apk"), filepath.Join(dir, "app.split.assets.apk")},
+	})
+	assert.NoError(t, err)
+
+	s := Source{}
+	err = s.Init(ctx, "test enumerate split apks", 0, 0, true, conn, 1)
+	assert.NoError(t, err)
+
+	reporter := sourcestest.TestReporter{}
+	err = s.Enumerate(ctx, &reporter)
+	assert.NoError(t, err)
+
+	assert.ElementsMatch(t, []sources.SourceUnit{
+		sources.CommonSourceUnit{ID: filepath.Join(dir, "app.apk")},
+		sources.CommonSourceUnit{ID: filepath.Join(dir, "app.split.config.arm64_v8a.apk")},
+		sources.CommonSourceUnit{ID: filepath.Join(dir, "app.split.assets.apk")},
+	}, reporter.Units)
+}
+
+func TestSplitAPKsOf(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	for name, libs := range map[string][]string{
+		"app.apk":                        nil,
+		"app.split.config.arm64_v8a.apk": {"lib/arm64-v8a/libapp.so"},
+		"app.split.config.x86_64.apk":    {"lib/x86_64/libapp.so"},
+		"other.split.config.en.apk":      nil,
+	} {
+		var buf bytes.Buffer
+		w := zip.NewWriter(&buf)
+		for _, lib := range libs {
+			_, err := w.Create(lib)
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, w.Close())
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), buf.Bytes(), 0644))
+	}
+	conn, err := anypb.New(&sourcespb.Filesystem{Paths: []string{filepath.Join(dir, "app.apk")}})
+	assert.NoError(t, err)
+
+	s := Source{}
+	err = s.Init(ctx, "test split apks of", 0, 0, true, conn, 1)
+	assert.NoError(t, err)
+
+	// The split APKs scanned along with the base APK are grouped with it, whichever of them is being scanned.
+	assert.NotNil(t, s.splitAPKsOf(ctx, filepath.Join(dir, "app.apk")))
+	assert.NotNil(t, s.splitAPKsOf(ctx, filepath.Join(dir, "app.split.config.x86_64.apk")))
+	// The base APK of this split isn't being scanned.
+	assert.Nil(t, s.splitAPKsOf(ctx, filepath.Join(dir, "other.split.config.en.apk")))
+}
+
 func TestChunkUnit(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -233,6 +305,47 @@ func TestChunkUnit(t *testing.T) {
 	assert.Contains(t, dataFound, "baz")
 }
 
+func TestChunkUnitFileClasses(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	tmpdir, err := os.MkdirTemp("", "trufflehogtest")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpdir)
+	for name, content := range map[string]string{
+		"app.yaml": "api_key: secret",
+		"main.go":  "package main",
+		"notes":    "plain text notes",
+		"logo.png": "not really a png",
+	} {
+		assert.NoError(t, os.WriteFile(filepath.Join(tmpdir, name), []byte(content), 0644))
+	}
+
+	conn, err := anypb.New(&sourcespb.Filesystem{
+		IncludeClasses: []string{"config", "document", "media"},
+		ExcludeClasses: []string{"media"},
+	})
+	assert.NoError(t, err)
+
+	s := Source{}
+	err = s.Init(ctx, "test chunk unit file classes", 0, 0, true, conn, 1)
+	assert.NoError(t, err)
+
+	reporter := sourcestest.TestReporter{}
+	err = s.ChunkUnit(ctx, sources.CommonSourceUnit{ID: tmpdir}, &reporter)
+	assert.NoError(t, err)
+
+	classes := make(map[string]string, len(reporter.Chunks))
+	for _, chunk := range reporter.Chunks {
+		classes[filepath.Base(chunk.SourceMetadata.GetFilesystem().GetFile())] = chunk.SourceMetadata.GetFileClass()
+	}
+	assert.Equal(t, map[string]string{"app.yaml": "config", "notes": "document"}, classes)
+
+	invalid, err := anypb.New(&sourcespb.Filesystem{IncludeClasses: []string{"configs"}})
+	assert.NoError(t, err)
+	assert.Error(t, (&Source{}).Init(ctx, "test invalid file class", 0, 0, true, invalid, 1))
+}
+
 func TestEnumerateReporterErr(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -0,0 +1,66 @@
+package filesystem
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/handlers"
+)
+
+// splitAPKSiblings returns the split APKs stored next to a base APK as <app>.split.<name>.apk. They are scanned
+// along with the base APK, as parts of the same app, unless they were given as paths to scan themselves.
+func (s *Source) splitAPKSiblings(path string) []string {
+	ext := filepath.Ext(path)
+	app := strings.TrimSuffix(filepath.Base(path), ext)
+	if !strings.EqualFold(ext, ".apk") || strings.Contains(app, ".split.") {
+		return nil
+	}
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		return nil
+	}
+
+	var splits []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.Type().IsRegular() || !strings.HasPrefix(name, app+".split.") || !strings.EqualFold(filepath.Ext(name), ".apk") {
+			continue
+		}
+		split := filepath.Join(filepath.Dir(path), name)
+		if slices.ContainsFunc(s.paths, func(p string) bool { return filepath.Clean(p) == split }) {
+			continue
+		}
+		if s.filter != nil && !s.filter.Pass(split) {
+			continue
+		}
+		splits = append(splits, split)
+	}
+	return splits
+}
+
+// splitAPKsOf returns the split APKs of the app whose base APK, or one of whose split APKs scanned along with it, is
+// at path, so the copies of its native libraries built for the different ABIs are only scanned once across them.
+// It returns nil if the file isn't part of such an app.
+func (s *Source) splitAPKsOf(ctx context.Context, path string) *handlers.SplitAPKs {
+	base := path
+	if app, _, ok := strings.Cut(filepath.Base(path), ".split."); ok {
+		base = filepath.Join(filepath.Dir(path), app+filepath.Ext(path))
+		if !slices.ContainsFunc(s.paths, func(p string) bool { return filepath.Clean(p) == base }) {
+			return nil
+		}
+	}
+	splits := s.splitAPKSiblings(base)
+	if len(splits) == 0 || (base != path && !slices.Contains(splits, path)) {
+		return nil
+	}
+
+	apks, err := handlers.NewSplitAPKs(append([]string{base}, splits...))
+	if err != nil {
+		ctx.Logger().V(2).Info("unable to group split APKs", "path", base, "error", err)
+		return nil
+	}
+	return apks
+}
@@ -304,6 +304,10 @@ type FilesystemConfig struct {
 	IncludePathsFile string
 	// ExcludePathsFile is the path to a file containing a list of regexps to exclude from the scan.
 	ExcludePathsFile string
+	// IncludeClasses is the list of file classes to scan, or every class if empty.
+	IncludeClasses []string
+	// ExcludeClasses is the list of file classes to skip.
+	ExcludeClasses []string
 }
 
 // S3Config defines the optional configuration for an S3 source.
@@ -0,0 +1,229 @@
+// Package verificationcache remembers the outcome of verifying a secret, so
+// secrets that are found over and over again, like keys embedded in many
+// apps, are only sent to their provider once.
+//
+// Outcomes are keyed by detector type, detector version and a SHA-256 hash of
+// the secret; the secret itself is never stored. Only conclusive outcomes
+// (verified or invalid) are cached, so indeterminate ones are retried.
+package verificationcache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/cache"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/config"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+)
+
+// DefaultTTL is how long a cached outcome is used if no TTL is configured.
+const DefaultTTL = 30 * 24 * time.Hour
+
+// Entry is a cached verification outcome.
+type Entry struct {
+	Status     detectors.VerificationStatus `json:"status"`
+	Error      string                       `json:"error,omitempty"`
+	HTTPStatus int                          `json:"http_status,omitempty"`
+	ExtraData  map[string]string            `json:"extra_data,omitempty"`
+	VerifiedAt time.Time                    `json:"verified_at"`
+}
+
+// Outcome returns the verification outcome stored in the entry.
+func (e Entry) Outcome() detectors.VerificationOutcome {
+	outcome := detectors.VerificationOutcome{
+		Status:     e.Status,
+		HTTPStatus: e.HTTPStatus,
+		ExtraData:  e.ExtraData,
+	}
+	if e.Error != "" {
+		outcome.Error = errors.New(e.Error)
+	}
+	return outcome
+}
+
+// Cache caches verification outcomes in a cache.Cache, typically a file-backed
+// one so outcomes are shared across runs. It is safe for concurrent use if the
+// underlying cache is. A nil *Cache caches nothing.
+type Cache struct {
+	store cache.Cache[Entry]
+	ttl   time.Duration
+	now   func() time.Time
+}
+
+// Option configures a Cache.
+type Option func(*Cache)
+
+// WithTTL sets how long a cached outcome is used before the secret is verified
+// again. A TTL of 0 uses DefaultTTL.
+func WithTTL(ttl time.Duration) Option {
+	return func(c *Cache) {
+		if ttl > 0 {
+			c.ttl = ttl
+		}
+	}
+}
+
+// New creates a Cache that stores its entries in store.
+func New(store cache.Cache[Entry], opts ...Option) *Cache {
+	c := &Cache{store: store, ttl: DefaultTTL, now: time.Now}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Key returns the cache key for secret as verified by the detector id.
+func Key(id config.DetectorID, secret string) string {
+	return fmt.Sprintf("%d.v%d:%s", id.ID, id.Version, detectors.HashSecret(secret))
+}
+
+// ResultKey returns the cache key for the outcome of verifying secret in the
+// FromData of the detector id. FromData may verify with more context than
+// Verify, so its outcomes are kept apart from those of Verify.
+func ResultKey(id config.DetectorID, secret string) string {
+	return fmt.Sprintf("%d.v%d.results:%s", id.ID, id.Version, detectors.HashSecret(secret))
+}
+
+// Get returns the cached outcome of verifying secret with d, if there is one
+// that hasn't expired.
+func (c *Cache) Get(d detectors.Detector, secret string) (detectors.VerificationOutcome, bool) {
+	if c == nil {
+		return detectors.VerificationOutcome{}, false
+	}
+	return c.get(Key(config.GetDetectorID(d), secret))
+}
+
+func (c *Cache) get(key string) (detectors.VerificationOutcome, bool) {
+	entry, ok := c.store.Get(key)
+	if !ok {
+		return detectors.VerificationOutcome{}, false
+	}
+	if c.now().Sub(entry.VerifiedAt) > c.ttl {
+		c.store.Delete(key)
+		return detectors.VerificationOutcome{}, false
+	}
+	return entry.Outcome(), true
+}
+
+// Set caches the outcome of verifying secret with d. Indeterminate outcomes
+// are not cached.
+func (c *Cache) Set(d detectors.Detector, secret string, outcome detectors.VerificationOutcome) {
+	if c == nil {
+		return
+	}
+	c.set(Key(config.GetDetectorID(d), secret), outcome)
+}
+
+func (c *Cache) set(key string, outcome detectors.VerificationOutcome) {
+	if outcome.Status != detectors.VerificationStatusVerified && outcome.Status != detectors.VerificationStatusInvalid {
+		return
+	}
+	entry := Entry{
+		Status:     outcome.Status,
+		HTTPStatus: outcome.HTTPStatus,
+		ExtraData:  outcome.ExtraData,
+		VerifiedAt: c.now(),
+	}
+	if outcome.Error != nil {
+		entry.Error = outcome.Error.Error()
+	}
+	c.store.Set(key, entry)
+}
+
+// VerifySecret returns the cached outcome of verifying secret with d, or
+// verifies it with detectors.VerifySecret and caches the outcome.
+func (c *Cache) VerifySecret(ctx context.Context, d detectors.Detector, secret string) detectors.VerificationOutcome {
+	if outcome, ok := c.Get(d, secret); ok {
+		return outcome
+	}
+	outcome := detectors.VerifySecret(ctx, d, secret)
+	c.Set(d, secret, outcome)
+	return outcome
+}
+
+// VerifyCredential is like VerifySecret for multi-part credentials. The
+// credential is keyed by its legacy form, so it shares entries with secrets
+// verified in that form.
+func (c *Cache) VerifyCredential(ctx context.Context, d detectors.Detector, cred detectors.Credential) detectors.VerificationOutcome {
+	secret := cred.String()
+	if outcome, ok := c.Get(d, secret); ok {
+		return outcome
+	}
+	outcome := detectors.VerifyCredential(ctx, d, cred)
+	c.Set(d, secret, outcome)
+	return outcome
+}
+
+// VerifyResult verifies a result that d found without verifying, using the
+// cached outcome if there is one, and fills in its verification status. It is
+// only equivalent to verifying in FromData if detectors.VerifiesResults(d).
+func (c *Cache) VerifyResult(ctx context.Context, d detectors.Detector, r *detectors.Result) {
+	var outcome detectors.VerificationOutcome
+	if r.Credential != nil {
+		outcome = c.VerifyCredential(ctx, d, *r.Credential)
+	} else {
+		outcome = c.VerifySecret(ctx, d, r.VerificationSecret())
+	}
+	applyOutcome(r, outcome)
+}
+
+// ApplyResults fills in the verification status of results, which d found
+// without verifying, from the outcomes cached by SetResults. It returns false,
+// and leaves results untouched, unless every result has a cached outcome.
+func (c *Cache) ApplyResults(d detectors.Detector, results []detectors.Result) bool {
+	if c == nil {
+		return false
+	}
+	id := config.GetDetectorID(d)
+	outcomes := make([]detectors.VerificationOutcome, len(results))
+	for i := range results {
+		outcome, ok := c.get(ResultKey(id, results[i].VerificationSecret()))
+		if !ok {
+			return false
+		}
+		outcomes[i] = outcome
+	}
+	for i := range results {
+		applyOutcome(&results[i], outcomes[i])
+	}
+	return true
+}
+
+// SetResults caches the verification status of results that d found and
+// verified in FromData. Results that couldn't be verified are not cached.
+func (c *Cache) SetResults(d detectors.Detector, results []detectors.Result) {
+	if c == nil {
+		return
+	}
+	id := config.GetDetectorID(d)
+	for i := range results {
+		r := &results[i]
+		if r.VerificationError() != nil {
+			continue
+		}
+		// An unverified result is cached as invalid even if FromData didn't
+		// try to verify it: applying the outcome leaves it unverified, just
+		// like FromData did.
+		outcome := detectors.NewVerificationOutcome(r.Verified, nil).WithExtraData(r.ExtraData)
+		c.set(ResultKey(id, r.VerificationSecret()), outcome)
+	}
+}
+
+// applyOutcome fills in the verification status of r from outcome.
+func applyOutcome(r *detectors.Result, outcome detectors.VerificationOutcome) {
+	r.Verified = outcome.Verified()
+	if outcome.Status == detectors.VerificationStatusIndeterminate || outcome.Status == detectors.VerificationStatusUnsupported {
+		r.SetVerificationError(outcome.Error)
+	}
+	if len(outcome.ExtraData) == 0 {
+		return
+	}
+	if r.ExtraData == nil {
+		r.ExtraData = make(map[string]string, len(outcome.ExtraData))
+	}
+	for k, v := range outcome.ExtraData {
+		r.ExtraData[k] = v
+	}
+}
@@ -0,0 +1,158 @@
+package verificationcache
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/cache/file"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/cache/simple"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/config"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+// countingDetector counts how often it verifies a secret.
+type countingDetector struct {
+	calls int
+}
+
+func (d *countingDetector) FromData(context.Context, bool, []byte) ([]detectors.Result, error) {
+	return nil, nil
+}
+func (d *countingDetector) Keywords() []string             { return nil }
+func (d *countingDetector) Type() detectorspb.DetectorType { return detectorspb.DetectorType_AWS }
+func (d *countingDetector) Description() string            { return "" }
+func (d *countingDetector) Verify(_ context.Context, secret string) detectors.VerificationOutcome {
+	d.calls++
+	switch secret {
+	case "valid":
+		return detectors.NewVerificationOutcome(true, nil).WithExtraData(map[string]string{"account": "1234"})
+	case "timeout":
+		return detectors.NewVerificationOutcome(false, errors.New("timed out"))
+	default:
+		return detectors.NewVerificationOutcome(false, nil)
+	}
+}
+
+func TestCache_VerifySecret(t *testing.T) {
+	ctx := context.Background()
+	c := New(simple.NewCache[Entry]())
+	d := &countingDetector{}
+
+	for i := 0; i < 2; i++ {
+		outcome := c.VerifySecret(ctx, d, "valid")
+		assert.True(t, outcome.Verified())
+		assert.Equal(t, "1234", outcome.ExtraData["account"])
+
+		assert.Equal(t, detectors.VerificationStatusInvalid, c.VerifySecret(ctx, d, "invalid").Status)
+		assert.Equal(t, detectors.VerificationStatusIndeterminate, c.VerifySecret(ctx, d, "timeout").Status)
+	}
+	// Only the indeterminate outcome is verified again.
+	assert.Equal(t, 4, d.calls)
+}
+
+func TestCache_TTL(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	c := New(simple.NewCache[Entry](), WithTTL(time.Hour))
+	c.now = func() time.Time { return now }
+	d := &countingDetector{}
+
+	c.VerifySecret(ctx, d, "valid")
+	now = now.Add(30 * time.Minute)
+	c.VerifySecret(ctx, d, "valid")
+	assert.Equal(t, 1, d.calls)
+
+	now = now.Add(time.Hour)
+	c.VerifySecret(ctx, d, "valid")
+	assert.Equal(t, 2, d.calls)
+}
+
+func TestCache_Persistence(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "verification.jsonl")
+	d := &countingDetector{}
+
+	store, err := file.NewCache[Entry](path)
+	require.NoError(t, err)
+	New(store).VerifySecret(ctx, d, "valid")
+	require.NoError(t, store.Close())
+
+	store, err = file.NewCache[Entry](path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+	assert.True(t, New(store).VerifySecret(ctx, d, "valid").Verified())
+	assert.Equal(t, 1, d.calls)
+
+	// The secret is only stored as a hash.
+	assert.Equal(t, []string{Key(config.GetDetectorID(d), "valid")}, store.Keys())
+	assert.NotContains(t, store.Contents(), "valid")
+}
+
+func TestCache_VerifyResult(t *testing.T) {
+	ctx := context.Background()
+	c := New(simple.NewCache[Entry]())
+	d := &countingDetector{}
+
+	for i := 0; i < 2; i++ {
+		r := detectors.Result{Raw: []byte("valid")}
+		c.VerifyResult(ctx, d, &r)
+		assert.True(t, r.Verified)
+		assert.Equal(t, "1234", r.ExtraData["account"])
+		assert.NoError(t, r.VerificationError())
+
+		r = detectors.Result{Raw: []byte("id"), RawV2: []byte("timeout")}
+		c.VerifyResult(ctx, d, &r)
+		assert.False(t, r.Verified)
+		assert.EqualError(t, r.VerificationError(), "timed out")
+	}
+	// Only the indeterminate outcome is verified again.
+	assert.Equal(t, 3, d.calls)
+
+	var nilCache *Cache
+	r := detectors.Result{Raw: []byte("valid")}
+	nilCache.VerifyResult(ctx, d, &r)
+	assert.True(t, r.Verified)
+	assert.Equal(t, 4, d.calls)
+}
+
+func TestCache_Results(t *testing.T) {
+	ctx := context.Background()
+	c := New(simple.NewCache[Entry]())
+	d := &countingDetector{}
+
+	verified := []detectors.Result{
+		{Raw: []byte("a"), Verified: true, ExtraData: map[string]string{"account": "1234"}},
+		{Raw: []byte("b")},
+		{Raw: []byte("c")},
+	}
+	verified[2].SetVerificationError(errors.New("timed out"))
+	c.SetResults(d, verified)
+
+	// The indeterminate result isn't cached, so nothing is applied.
+	results := []detectors.Result{{Raw: []byte("a")}, {Raw: []byte("b")}, {Raw: []byte("c")}}
+	assert.False(t, c.ApplyResults(d, results))
+	assert.False(t, results[0].Verified)
+
+	results = results[:2]
+	assert.True(t, c.ApplyResults(d, results))
+	assert.True(t, results[0].Verified)
+	assert.Equal(t, "1234", results[0].ExtraData["account"])
+	assert.False(t, results[1].Verified)
+	assert.NoError(t, results[1].VerificationError())
+
+	// Outcomes of FromData don't answer Verify.
+	_, ok := c.Get(d, "b")
+	assert.False(t, ok)
+	assert.Equal(t, detectors.VerificationStatusInvalid, c.VerifySecret(ctx, d, "a").Status)
+	assert.Equal(t, 1, d.calls)
+
+	var nilCache *Cache
+	assert.False(t, nilCache.ApplyResults(d, results))
+}
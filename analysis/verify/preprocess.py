@@ -25,6 +25,21 @@ def prepare_gitleaks(secret):
     """
     return secret["Match"].strip()
 
+def prepare_credential(secret):
+    """
+    Get the named parts of a multi-part trufflehog secret.
+
+    Args:
+        secret (dict): Secret dictionary.
+
+    Returns:
+        dict: Mapping of part name to stripped value, in order, or None for single-part secrets.
+    """
+    credential = secret.get("Credential")
+    if not credential:
+        return None
+    return {name: value.strip() for name, value in credential.items()}
+
 def prepare_trufflehog(secret):
     """
     Prepare a trufflehog secret for processing.
@@ -35,6 +50,8 @@ def prepare_trufflehog(secret):
     Returns:
         str: The processed raw secret string.
     """
+    # The key is always derived from Raw and RawV2, even for secrets with a
+    # Credential, so it matches the keys of previous results
     raw = secret["Raw"].strip()
     # If RawV2 exists and DetectorType is not 17, process further
     if secret.get("RawV2", "") != "" and secret.get("DetectorType", -1) != 17:
@@ -89,11 +106,12 @@ def get_unique_secrets(all_secrets):
         all_secrets (list): List of secrets from the database.
 
     Returns:
-        tuple: (results_secrets, rule_ids, unique_per_file)
+        tuple: (results_secrets, rule_ids, unique_per_file, credentials)
     """
     results_secrets = {}
     rule_ids = {}
     unique_per_file = {}
+    credentials = {}
     for secret, detection_rule, file_path, appid in all_secrets:
         json_secret = json.loads(secret)
         # Process gitleaks secrets
@@ -110,6 +128,9 @@ def get_unique_secrets(all_secrets):
             continue
 
         rule_ids[secret] = detector_type
+        credential = prepare_credential(json_secret)
+        if credential:
+            credentials[secret] = credential
         tmp = results_secrets.get(secret, set())
         tmp.add(appid)
         results_secrets[secret] = tmp
@@ -120,7 +141,7 @@ def get_unique_secrets(all_secrets):
         findings[detector_type] = tmp_findings
         unique_per_file[file_path] = findings
 
-    return results_secrets, rule_ids, unique_per_file
+    return results_secrets, rule_ids, unique_per_file, credentials
 
 def load_previous_results(path):
     """
@@ -197,16 +218,20 @@ def filter_secrets_per_file(filtered_secrets, file_info, max_secrets_per_file=14
                     filtered[secret] = filtered_secrets[secret]
     return filtered
 
-def save_for_trufflehog_verify(secrets, output= "./to_verify/"):
+def save_for_trufflehog_verify(secrets, credentials, output= "./to_verify/"):
     """
     Save secrets for trufflehog verification as JSON files.
 
     Args:
         secrets (dict): Mapping of secret to detector type.
+        credentials (dict): Mapping of secret to its named parts, for multi-part secrets.
         output (str): Output folder path.
     """
     for secret, detector in secrets.items():
         result = {"secret": secret, "detector": str(detector)}
+        # trufflehog verify prefers the named parts over the ";-|" joined secret
+        if secret in credentials:
+            result["credential"] = credentials[secret]
         md5_hash = hashlib.md5((secret+str(detector)).encode()).hexdigest()
         if os.path.exists(output + md5_hash + ".txt"):
             continue
@@ -248,7 +273,7 @@ def main():
     previous_results = load_previous_results(args.old_results)
     logging.info(f"Loaded {len(previous_results)} previous results.")
 
-    secrect_map, rule_ids, secrets_per_file = get_unique_secrets(secrets)
+    secrect_map, rule_ids, secrets_per_file, credentials = get_unique_secrets(secrets)
     logging.info(f"Found {len(secrect_map)} unique secrets.")
     filtered_secrets = filter_secrets(secrect_map, previous_results, rule_ids)
 
@@ -257,7 +282,7 @@ def main():
 
     logging.info(f"Further filtered {len(filtered_secrets)} secrets.")
 
-    save_for_trufflehog_verify(filtered_secrets, output=args.output_folder)
+    save_for_trufflehog_verify(filtered_secrets, credentials, output=args.output_folder)
 
 if __name__ == "__main__":
     main()
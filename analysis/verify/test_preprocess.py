@@ -0,0 +1,31 @@
+import unittest
+
+from preprocess import prepare_credential, prepare_trufflehog
+
+
+class PrepareTrufflehogTest(unittest.TestCase):
+    def test_credential_keeps_legacy_key(self):
+        # A result of a previous run, before detectors reported credentials
+        legacy = {
+            "DetectorType": 16,
+            "Raw": "SKxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx",
+            "RawV2": "ACxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxSKxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx",
+        }
+        current = dict(legacy, Credential={
+            "id": "ACxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx",
+            "secret": "SKxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx",
+        })
+        self.assertEqual(prepare_trufflehog(legacy), prepare_trufflehog(current))
+
+    def test_separated_raw_v2(self):
+        secret = {"DetectorType": 2, "Raw": "AKIAEXAMPLE", "RawV2": "AKIAEXAMPLE;-|secret"}
+        self.assertEqual(prepare_trufflehog(secret), "AKIAEXAMPLE;-|secret")
+
+    def test_credential_parts(self):
+        secret = {"Raw": "id", "Credential": {"id": " id ", "secret": "secret\n"}}
+        self.assertEqual(prepare_credential(secret), {"id": "id", "secret": "secret"})
+        self.assertIsNone(prepare_credential({"Raw": "token"}))
+
+
+if __name__ == "__main__":
+    unittest.main()